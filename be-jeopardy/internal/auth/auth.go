@@ -1,7 +1,9 @@
 package auth
 
 import (
+	"crypto/rand"
 	"crypto/rsa"
+	"crypto/subtle"
 	"fmt"
 	"os"
 	"time"
@@ -37,6 +39,18 @@ func SetJWTKeys() error {
 	return nil
 }
 
+// GenerateJWTKeys signs tokens with a new key pair that only lives as long
+// as the process, for running the server locally without configured keys.
+func GenerateJWTKeys() error {
+	var err error
+	privateKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return err
+	}
+	publicKey = &privateKey.PublicKey
+	return nil
+}
+
 func GenerateJWT(id string) (string, error) {
 	return jwt.NewWithClaims(jwt.SigningMethodRS512, jwt.MapClaims{
 		"iss": issuer,
@@ -91,3 +105,39 @@ func GetJWTSubject(jwtStr string) (string, error) {
 
 	return sub, nil
 }
+
+// GetUserEmail returns the email of the user a Supabase session token was
+// issued to. Supabase signs its tokens with the project's JWT secret, set in
+// the SUPABASE_JWT_SECRET environment variable.
+func GetUserEmail(sessionToken string) (string, error) {
+	secret := os.Getenv("SUPABASE_JWT_SECRET")
+	if secret == "" {
+		return "", fmt.Errorf("Sign in is not configured")
+	}
+	token, err := jwt.Parse(sessionToken, func(token *jwt.Token) (any, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(secret), nil
+	}, jwt.WithAudience("authenticated"), jwt.WithExpirationRequired())
+	if err != nil {
+		return "", fmt.Errorf("Error parsing session token: %s", err)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return "", fmt.Errorf("Error parsing claims")
+	}
+	email, ok := claims["email"].(string)
+	if !ok || email == "" {
+		return "", fmt.Errorf("Error parsing email")
+	}
+	return email, nil
+}
+
+// IsAdmin reports whether the token is the admin token set in the
+// ADMIN_TOKEN environment variable. There are no admins if it isn't set.
+func IsAdmin(token string) bool {
+	adminToken := os.Getenv("ADMIN_TOKEN")
+	return adminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) == 1
+}
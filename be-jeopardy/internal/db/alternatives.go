@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+	_ "embed"
+	"time"
+)
+
+const (
+	PendingStatus  = "pending"
+	ApprovedStatus = "approved"
+	RejectedStatus = "rejected"
+)
+
+// PendingAlternative is a response accepted by a dispute, waiting for an
+// admin to approve it as an alternative to the clue's correct response.
+// It keeps who gave the response and who voted for it.
+type PendingAlternative struct {
+	Id          int        `json:"id"`
+	ClueId      int        `json:"clueId"`
+	Alternative string     `json:"alternative"`
+	Answer      string     `json:"answer"`
+	Clue        string     `json:"clue"`
+	GameId      string     `json:"gameId"`
+	AnsweredBy  string     `json:"answeredBy"`
+	DisputedBy  []string   `json:"disputedBy"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	Reviewer    string     `json:"reviewer"`
+	ReviewedAt  *time.Time `json:"reviewedAt"`
+}
+
+//go:embed sql/add_pending_alternative.sql
+var addPendingAlternative string
+
+func (db *JeopardyDB) AddPendingAlternative(ctx context.Context, alt PendingAlternative) error {
+	_, err := db.pool.Exec(ctx, addPendingAlternative, alt.ClueId, alt.Alternative, alt.Answer, alt.Clue, alt.GameId, alt.AnsweredBy, alt.DisputedBy)
+	return err
+}
+
+//go:embed sql/get_pending_alternatives.sql
+var getPendingAlternatives string
+
+// GetPendingAlternatives returns the alternatives with the given status,
+// oldest first.
+func (db *JeopardyDB) GetPendingAlternatives(ctx context.Context, status string) ([]PendingAlternative, error) {
+	rows, err := db.pool.Query(ctx, getPendingAlternatives, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	alts := []PendingAlternative{}
+	for rows.Next() {
+		var alt PendingAlternative
+		err := rows.Scan(
+			&alt.Id, &alt.ClueId, &alt.Alternative, &alt.Answer, &alt.Clue, &alt.GameId, &alt.AnsweredBy,
+			&alt.DisputedBy, &alt.Status, &alt.CreatedAt, &alt.Reviewer, &alt.ReviewedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		alts = append(alts, alt)
+	}
+
+	return alts, nil
+}
+
+//go:embed sql/review_pending_alternative.sql
+var reviewPendingAlternative string
+
+// ReviewPendingAlternative approves or rejects a pending alternative,
+// adding it to the clue's alternatives if it is approved. It returns
+// pgx.ErrNoRows if there is no pending alternative with the id.
+func (db *JeopardyDB) ReviewPendingAlternative(ctx context.Context, id int, approved bool, reviewer string) (PendingAlternative, error) {
+	status := RejectedStatus
+	if approved {
+		status = ApprovedStatus
+	}
+	var alt PendingAlternative
+	err := db.pool.QueryRow(ctx, reviewPendingAlternative, id, status, reviewer).Scan(
+		&alt.Id, &alt.ClueId, &alt.Alternative, &alt.Answer, &alt.Clue, &alt.GameId, &alt.AnsweredBy,
+		&alt.DisputedBy, &alt.Status, &alt.CreatedAt, &alt.Reviewer, &alt.ReviewedAt,
+	)
+	return alt, err
+}
@@ -22,6 +22,7 @@ type (
 	}
 
 	AnalyticsQuestion struct {
+		ClueId  int               `json:"clueId"`
 		Answers []AnalyticsAnswer `json:"answers"`
 	}
 
@@ -44,6 +45,19 @@ type (
 		MaxCorrect int `json:"maxCorrect"`
 	}
 
+	// ClueDifficulty is how many times the clues of a value in a round were
+	// shown in games saved to analytics, how many of those someone answered,
+	// and how many answers there were and were correct. Bots' answers are
+	// left out.
+	ClueDifficulty struct {
+		Round    int `json:"round"`
+		Value    int `json:"value"`
+		Shown    int `json:"shown"`
+		Answered int `json:"answered"`
+		Answers  int `json:"answers"`
+		Correct  int `json:"correct"`
+	}
+
 	LeaderboardUser struct {
 		User
 		PlayerAnalytics
@@ -64,6 +78,28 @@ func (db *JeopardyDB) SaveGameAnalytics(ctx context.Context, gameID uuid.UUID, c
 	return err
 }
 
+//go:embed sql/get_clue_difficulty.sql
+var getClueDifficulty string
+
+func (db *JeopardyDB) GetClueDifficulty(ctx context.Context) ([]ClueDifficulty, error) {
+	rows, err := db.pool.Query(ctx, getClueDifficulty)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	difficulty := []ClueDifficulty{}
+	for rows.Next() {
+		var d ClueDifficulty
+		if err := rows.Scan(&d.Round, &d.Value, &d.Shown, &d.Answered, &d.Answers, &d.Correct); err != nil {
+			return nil, err
+		}
+		difficulty = append(difficulty, d)
+	}
+
+	return difficulty, rows.Err()
+}
+
 //go:embed sql/get_analytics.sql
 var getAnalytics string
 
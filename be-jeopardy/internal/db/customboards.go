@@ -0,0 +1,137 @@
+package db
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type (
+	// CustomBoard is a board a player wrote themselves. Anyone with its id
+	// can play it, but only its owner can change it.
+	CustomBoard struct {
+		Id            string           `json:"id"`
+		Owner         string           `json:"-"`
+		Name          string           `json:"name"`
+		FirstRound    []CustomCategory `json:"firstRound"`
+		SecondRound   []CustomCategory `json:"secondRound"`
+		FinalJeopardy CustomCategory   `json:"finalJeopardy"`
+		CreatedAt     time.Time        `json:"createdAt"`
+		UpdatedAt     time.Time        `json:"updatedAt"`
+	}
+
+	CustomCategory struct {
+		Name  string       `json:"name"`
+		Clues []CustomClue `json:"clues"`
+	}
+
+	CustomClue struct {
+		Clue         string   `json:"clue"`
+		Answer       string   `json:"answer,omitempty"`
+		Alternatives []string `json:"alternatives,omitempty"`
+	}
+
+	// customBoardContent is what goes in the board column of custom_boards.
+	customBoardContent struct {
+		FirstRound    []CustomCategory `json:"firstRound"`
+		SecondRound   []CustomCategory `json:"secondRound"`
+		FinalJeopardy CustomCategory   `json:"finalJeopardy"`
+	}
+)
+
+func (b CustomBoard) content() ([]byte, error) {
+	return json.Marshal(customBoardContent{b.FirstRound, b.SecondRound, b.FinalJeopardy})
+}
+
+//go:embed sql/add_custom_board.sql
+var addCustomBoard string
+
+func (db *JeopardyDB) AddCustomBoard(ctx context.Context, b CustomBoard) error {
+	content, err := b.content()
+	if err != nil {
+		return err
+	}
+	_, err = db.pool.Exec(ctx, addCustomBoard, b.Id, b.Owner, b.Name, content, b.UpdatedAt)
+	return err
+}
+
+//go:embed sql/update_custom_board.sql
+var updateCustomBoard string
+
+// UpdateCustomBoard replaces the name and clues of a board, returning
+// pgx.ErrNoRows if the owner has no board with its id.
+func (db *JeopardyDB) UpdateCustomBoard(ctx context.Context, b CustomBoard) (CustomBoard, error) {
+	content, err := b.content()
+	if err != nil {
+		return CustomBoard{}, err
+	}
+	if err := db.pool.QueryRow(ctx, updateCustomBoard, b.Id, b.Owner, b.Name, content, b.UpdatedAt).Scan(&b.CreatedAt); err != nil {
+		return CustomBoard{}, err
+	}
+	return b, nil
+}
+
+//go:embed sql/get_custom_board.sql
+var getCustomBoard string
+
+// GetCustomBoard returns the board with the id, or pgx.ErrNoRows if there
+// isn't one.
+func (db *JeopardyDB) GetCustomBoard(ctx context.Context, id string) (CustomBoard, error) {
+	return scanCustomBoard(db.pool.QueryRow(ctx, getCustomBoard, id))
+}
+
+//go:embed sql/get_custom_boards.sql
+var getCustomBoards string
+
+// GetCustomBoards returns the boards a player owns, the last changed first.
+func (db *JeopardyDB) GetCustomBoards(ctx context.Context, owner string) ([]CustomBoard, error) {
+	rows, err := db.pool.Query(ctx, getCustomBoards, owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	boards := []CustomBoard{}
+	for rows.Next() {
+		b, err := scanCustomBoard(rows)
+		if err != nil {
+			return nil, err
+		}
+		boards = append(boards, b)
+	}
+
+	return boards, rows.Err()
+}
+
+//go:embed sql/delete_custom_board.sql
+var deleteCustomBoard string
+
+// DeleteCustomBoard deletes a board, returning pgx.ErrNoRows if the owner
+// has no board with the id.
+func (db *JeopardyDB) DeleteCustomBoard(ctx context.Context, id, owner string) error {
+	tag, err := db.pool.Exec(ctx, deleteCustomBoard, id, owner)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func scanCustomBoard(row pgx.Row) (CustomBoard, error) {
+	var b CustomBoard
+	var content []byte
+	if err := row.Scan(&b.Id, &b.Owner, &b.Name, &content, &b.CreatedAt, &b.UpdatedAt); err != nil {
+		return CustomBoard{}, err
+	}
+	var c customBoardContent
+	if err := json.Unmarshal(content, &c); err != nil {
+		return CustomBoard{}, err
+	}
+	b.FirstRound, b.SecondRound, b.FinalJeopardy = c.FirstRound, c.SecondRound, c.FinalJeopardy
+	return b, nil
+}
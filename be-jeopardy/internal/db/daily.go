@@ -0,0 +1,136 @@
+package db
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// DailyResult is how a player did on the daily challenge of a day, which
+// is a date like 2024-01-31. It is recorded when they start the day's game,
+// and has no score until they finish it.
+type DailyResult struct {
+	Day      string `json:"day"`
+	Email    string `json:"-"`
+	Name     string `json:"name"`
+	Score    int    `json:"score"`
+	Seconds  int    `json:"seconds"`
+	Finished bool   `json:"finished"`
+}
+
+//go:embed sql/get_daily_questions.sql
+var getDailyQuestions string
+
+// GetDailyQuestions is GetQuestions with the categories and Final Jeopardy
+// clue picked by the day instead of at random, so every board for a day is
+// the same.
+func (db *JeopardyDB) GetDailyQuestions(ctx context.Context, day string, frCategories, srCategories, clues int) ([]Question, error) {
+	rows, err := db.pool.Query(ctx, getDailyQuestions, frCategories, srCategories, clues, day)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	questions := []Question{}
+	for rows.Next() {
+		var q Question
+		err := rows.Scan(&q.Id, &q.Round, &q.Value, &q.Category, &q.Comments, &q.Clue, &q.Answer, &q.Alternatives, &q.Incorrect)
+		if err != nil {
+			return nil, err
+		}
+		questions = append(questions, q)
+	}
+
+	return questions, nil
+}
+
+//go:embed sql/start_daily_result.sql
+var startDailyResult string
+
+// StartDailyResult records that a player started the day's challenge, and
+// reports false if they had already started it.
+func (db *JeopardyDB) StartDailyResult(ctx context.Context, r DailyResult) (bool, error) {
+	tag, err := db.pool.Exec(ctx, startDailyResult, r.Day, r.Email, r.Name)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+//go:embed sql/save_daily_result.sql
+var saveDailyResult string
+
+// SaveDailyResult fills in the score of a player's daily challenge, keeping
+// their first result if they already finished one for the day.
+func (db *JeopardyDB) SaveDailyResult(ctx context.Context, r DailyResult) error {
+	_, err := db.pool.Exec(ctx, saveDailyResult, r.Day, r.Email, r.Name, r.Score, r.Seconds)
+	return err
+}
+
+//go:embed sql/get_daily_result.sql
+var getDailyResult string
+
+// GetDailyResult returns the player's result on the day's challenge, or
+// nil if they haven't started it.
+func (db *JeopardyDB) GetDailyResult(ctx context.Context, day, email string) (*DailyResult, error) {
+	r := DailyResult{Email: email}
+	err := db.pool.QueryRow(ctx, getDailyResult, day, email).Scan(&r.Day, &r.Name, &r.Score, &r.Seconds, &r.Finished)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+//go:embed sql/add_daily_board.sql
+var addDailyBoard string
+
+// AddDailyBoard stores the board of a day's challenge and returns the day's
+// board, which is the one already stored if there is one.
+func (db *JeopardyDB) AddDailyBoard(ctx context.Context, day string, board []byte) ([]byte, error) {
+	var stored []byte
+	err := db.pool.QueryRow(ctx, addDailyBoard, day, board).Scan(&stored)
+	return stored, err
+}
+
+//go:embed sql/get_daily_board.sql
+var getDailyBoard string
+
+// GetDailyBoard returns the stored board of a day's challenge, or nil if
+// the day hasn't been played yet.
+func (db *JeopardyDB) GetDailyBoard(ctx context.Context, day string) ([]byte, error) {
+	var board []byte
+	err := db.pool.QueryRow(ctx, getDailyBoard, day).Scan(&board)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return board, err
+}
+
+//go:embed sql/get_daily_leaderboard.sql
+var getDailyLeaderboard string
+
+// GetDailyLeaderboard returns the best finished results of a day, the
+// highest score first and the fastest first among equal scores.
+func (db *JeopardyDB) GetDailyLeaderboard(ctx context.Context, day string, limit int) ([]DailyResult, error) {
+	rows, err := db.pool.Query(ctx, getDailyLeaderboard, day, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []DailyResult{}
+	for rows.Next() {
+		r := DailyResult{Finished: true}
+		if err := rows.Scan(&r.Day, &r.Name, &r.Score, &r.Seconds); err != nil {
+			return nil, err
+		}
+		results = append(results, r)
+	}
+
+	return results, nil
+}
@@ -5,11 +5,15 @@ import (
 	_ "embed"
 	"os"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type (
 	Question struct {
+		// Id is the clue's id in jeopardy_clues, which write-backs like
+		// alternatives and incorrect answers are keyed on.
+		Id           int      `json:"id"`
 		Round        int      `json:"round"`
 		Value        int      `json:"value"`
 		Category     string   `json:"category"`
@@ -17,6 +21,7 @@ type (
 		Clue         string   `json:"question"`
 		Answer       string   `json:"-"`
 		Alternatives []string `json:"-"`
+		Incorrect    []string `json:"-"`
 	}
 
 	Category struct {
@@ -25,6 +30,14 @@ type (
 		AirDate string `json:"airDate"`
 	}
 
+	// DailyDoubleOccurrence is how many Daily Doubles have been behind the
+	// clues of a value in a round.
+	DailyDoubleOccurrence struct {
+		Round int `json:"round"`
+		Value int `json:"value"`
+		Count int `json:"count"`
+	}
+
 	JeopardyDB struct {
 		pool *pgxpool.Pool
 	}
@@ -49,8 +62,10 @@ func (db *JeopardyDB) Close() {
 //go:embed sql/get_questions.sql
 var getQuestions string
 
-func (db *JeopardyDB) GetQuestions(ctx context.Context, frCategories, srCategories int) ([]Question, error) {
-	rows, err := db.pool.Query(ctx, getQuestions, frCategories, srCategories)
+// GetQuestions picks random categories for each round, with the given
+// number of the lowest valued clues from each, and a Final Jeopardy clue.
+func (db *JeopardyDB) GetQuestions(ctx context.Context, frCategories, srCategories, clues int) ([]Question, error) {
+	rows, err := db.pool.Query(ctx, getQuestions, frCategories, srCategories, clues)
 	if err != nil {
 		return nil, err
 	}
@@ -59,7 +74,7 @@ func (db *JeopardyDB) GetQuestions(ctx context.Context, frCategories, srCategori
 	questions := []Question{}
 	for rows.Next() {
 		var q Question
-		err := rows.Scan(&q.Round, &q.Value, &q.Category, &q.Comments, &q.Clue, &q.Answer, &q.Alternatives)
+		err := rows.Scan(&q.Id, &q.Round, &q.Value, &q.Category, &q.Comments, &q.Clue, &q.Answer, &q.Alternatives, &q.Incorrect)
 		if err != nil {
 			return nil, err
 		}
@@ -82,7 +97,7 @@ func (db *JeopardyDB) GetCategoryQuestions(ctx context.Context, category Categor
 	questions := []Question{}
 	for rows.Next() {
 		var q Question
-		err := rows.Scan(&q.Round, &q.Value, &q.Category, &q.Comments, &q.Clue, &q.Answer, &q.Alternatives)
+		err := rows.Scan(&q.Id, &q.Round, &q.Value, &q.Category, &q.Comments, &q.Clue, &q.Answer, &q.Alternatives, &q.Incorrect)
 		if err != nil {
 			return nil, err
 		}
@@ -92,22 +107,97 @@ func (db *JeopardyDB) GetCategoryQuestions(ctx context.Context, category Categor
 	return questions, nil
 }
 
+//go:embed sql/get_daily_double_occurrences.sql
+var getDailyDoubleOccurrences string
+
+func (db *JeopardyDB) GetDailyDoubleOccurrences(ctx context.Context) ([]DailyDoubleOccurrence, error) {
+	rows, err := db.pool.Query(ctx, getDailyDoubleOccurrences)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	occurrences := []DailyDoubleOccurrence{}
+	for rows.Next() {
+		var o DailyDoubleOccurrence
+		if err := rows.Scan(&o.Round, &o.Value, &o.Count); err != nil {
+			return nil, err
+		}
+		occurrences = append(occurrences, o)
+	}
+
+	return occurrences, nil
+}
+
 //go:embed sql/add_alternatives.sql
 var addAlternative string
 
-func (db *JeopardyDB) AddAlternative(ctx context.Context, alternative, answer string) error {
-	_, err := db.pool.Exec(ctx, addAlternative, alternative, answer)
+func (db *JeopardyDB) AddAlternative(ctx context.Context, clueId int, alternative string) error {
+	_, err := db.pool.Exec(ctx, addAlternative, alternative, clueId)
 	return err
 }
 
 //go:embed sql/add_incorrect.sql
 var addIncorrect string
 
-func (db *JeopardyDB) AddIncorrect(ctx context.Context, incorrect, clue string) error {
-	_, err := db.pool.Exec(ctx, addIncorrect, incorrect, clue)
+func (db *JeopardyDB) AddIncorrect(ctx context.Context, clueId int, incorrect string) error {
+	_, err := db.pool.Exec(ctx, addIncorrect, incorrect, clueId)
 	return err
 }
 
+//go:embed sql/insert_clue.sql
+var insertClue string
+
+// InsertClues adds the clues that aren't already in jeopardy_clues, matching
+// on round, category, air date and clue text, and returns how many it added.
+func (db *JeopardyDB) InsertClues(ctx context.Context, clues []Clue) (int, error) {
+	inserted := 0
+	err := pgx.BeginFunc(ctx, db.pool, func(tx pgx.Tx) error {
+		batch := &pgx.Batch{}
+		for _, c := range clues {
+			c.setDefaults()
+			batch.Queue(insertClue, c.Round, c.Value, c.DailyDoubleValue, c.Category, c.Comments, c.Clue, c.Answer, c.AirDate, c.Notes, c.Alternatives, c.Incorrect)
+		}
+		results := tx.SendBatch(ctx, batch)
+		for range clues {
+			tag, err := results.Exec()
+			if err != nil {
+				results.Close()
+				return err
+			}
+			inserted += int(tag.RowsAffected())
+		}
+		return results.Close()
+	})
+	if err != nil {
+		return 0, err
+	}
+	return inserted, nil
+}
+
+//go:embed sql/export_answers.sql
+var exportAnswers string
+
+// ExportAnswers returns a random sample of at most limit clues that have
+// alternatives or incorrect responses besides their correct response, with
+// only those responses filled in.
+func (db *JeopardyDB) ExportAnswers(ctx context.Context, limit int) ([]Clue, error) {
+	rows, err := db.pool.Query(ctx, exportAnswers, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	clues := []Clue{}
+	for rows.Next() {
+		var c Clue
+		if err := rows.Scan(&c.Answer, &c.Alternatives, &c.Incorrect); err != nil {
+			return nil, err
+		}
+		clues = append(clues, c)
+	}
+	return clues, rows.Err()
+}
+
 //go:embed sql/search_categories.sql
 var searchCategories string
 
@@ -2,19 +2,23 @@ package db
 
 import (
 	"context"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 )
 
 func TestGetQuestions(t *testing.T) {
+	if os.Getenv("DATABASE_URL") == "" {
+		t.Skip("DATABASE_URL is not set")
+	}
 	t.Run("test getting questions", func(t *testing.T) {
 		ctx := context.Background()
 		questionDB, err := NewJeopardyDB(ctx)
 		if err != nil {
 			t.Fatalf("Error connecting to database: %s", err.Error())
 		}
-		questions, err := questionDB.GetQuestions(ctx, 6, 6)
+		questions, err := questionDB.GetQuestions(ctx, 6, 6, 5)
 		if err != nil {
 			t.Fatalf("Error getting questions: %s", err.Error())
 		}
@@ -0,0 +1,78 @@
+package db
+
+import (
+	"context"
+	_ "embed"
+)
+
+//go:embed sql/save_game_snapshot.sql
+var saveGameSnapshot string
+
+// SaveGameSnapshot stores the latest snapshot of an in-progress game,
+// replacing any earlier snapshot of the same game.
+func (db *JeopardyDB) SaveGameSnapshot(ctx context.Context, name string, snapshot []byte) error {
+	_, err := db.pool.Exec(ctx, saveGameSnapshot, name, snapshot)
+	return err
+}
+
+//go:embed sql/delete_game_snapshot.sql
+var deleteGameSnapshot string
+
+func (db *JeopardyDB) DeleteGameSnapshot(ctx context.Context, name string) error {
+	_, err := db.pool.Exec(ctx, deleteGameSnapshot, name)
+	return err
+}
+
+//go:embed sql/get_game_snapshots.sql
+var getGameSnapshots string
+
+func (db *JeopardyDB) GetGameSnapshots(ctx context.Context) ([][]byte, error) {
+	rows, err := db.pool.Query(ctx, getGameSnapshots)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	snapshots := [][]byte{}
+	for rows.Next() {
+		var snapshot []byte
+		if err := rows.Scan(&snapshot); err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, nil
+}
+
+//go:embed sql/append_game_events.sql
+var appendGameEvents string
+
+// AppendGameEvents adds events to the end of a game's replay log, numbering
+// them from firstSeq.
+func (db *JeopardyDB) AppendGameEvents(ctx context.Context, gameId string, firstSeq int, events []string) error {
+	_, err := db.pool.Exec(ctx, appendGameEvents, gameId, firstSeq, events)
+	return err
+}
+
+//go:embed sql/get_game_events.sql
+var getGameEvents string
+
+func (db *JeopardyDB) GetGameEvents(ctx context.Context, gameId string) ([][]byte, error) {
+	rows, err := db.pool.Query(ctx, getGameEvents, gameId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := [][]byte{}
+	for rows.Next() {
+		var event []byte
+		if err := rows.Scan(&event); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,958 @@
+package db
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type (
+	// Clue mirrors a row of the jeopardy_clues table.
+	Clue struct {
+		Id               int      `json:"id"`
+		Round            int      `json:"round"`
+		Value            int      `json:"value"`
+		DailyDoubleValue int      `json:"dailyDoubleValue"`
+		Category         string   `json:"category"`
+		Comments         string   `json:"comments"`
+		Clue             string   `json:"clue"`
+		Answer           string   `json:"answer"`
+		AirDate          string   `json:"airDate"`
+		Notes            string   `json:"notes"`
+		Alternatives     []string `json:"alternatives"`
+		Incorrect        []string `json:"incorrect"`
+	}
+
+	analyticsRow struct {
+		gameID    uuid.UUID
+		createdAt int64
+		fr        AnalyticsRound
+		sr        AnalyticsRound
+	}
+
+	// MemoryDB is an in-memory implementation of the jeopardy database
+	// for running the server and tests without Postgres.
+	MemoryDB struct {
+		mu           sync.RWMutex
+		rngMu        sync.Mutex
+		rng          *rand.Rand
+		clues        []*Clue
+		analytics    []analyticsRow
+		playerGames  map[string]*PlayerAnalytics
+		snapshots    map[string][]byte
+		gameEvents   map[string][][]byte
+		practice     map[string][]PracticeResult
+		dailyResults []DailyResult
+		dailyBoards  map[string][]byte
+		pendingAlts  []PendingAlternative
+		customBoards map[string]CustomBoard
+	}
+)
+
+func NewMemoryDB(clues []Clue) *MemoryDB {
+	db := &MemoryDB{
+		rng:          rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
+		clues:        []*Clue{},
+		analytics:    []analyticsRow{},
+		playerGames:  map[string]*PlayerAnalytics{},
+		snapshots:    map[string][]byte{},
+		gameEvents:   map[string][][]byte{},
+		practice:     map[string][]PracticeResult{},
+		dailyResults: []DailyResult{},
+		dailyBoards:  map[string][]byte{},
+		pendingAlts:  []PendingAlternative{},
+		customBoards: map[string]CustomBoard{},
+	}
+	for i, c := range clues {
+		clue := c
+		if clue.Id == 0 {
+			clue.Id = i + 1
+		}
+		clue.setDefaults()
+		db.clues = append(db.clues, &clue)
+	}
+	return db
+}
+
+// setDefaults gives a clue the alternatives and incorrect responses a new
+// row of jeopardy_clues starts with.
+func (c *Clue) setDefaults() {
+	if len(c.Alternatives) == 0 {
+		c.Alternatives = []string{c.Answer}
+	}
+	if c.Incorrect == nil {
+		c.Incorrect = []string{}
+	}
+}
+
+func NewMemoryDBFromFile(path string) (*MemoryDB, error) {
+	clues, err := LoadClues(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewMemoryDB(clues), nil
+}
+
+// LoadClues reads clues from either a JSON array of clues or a TSV or CSV
+// file with the columns of jeopardy_clues, as written by the scrapers.
+func LoadClues(path string) ([]Clue, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		clues := []Clue{}
+		if err := json.NewDecoder(f).Decode(&clues); err != nil {
+			return nil, fmt.Errorf("error parsing clue file %s: %w", path, err)
+		}
+		return clues, nil
+	case ".tsv":
+		return readDelimitedClues(f, '\t')
+	case ".csv":
+		return readDelimitedClues(f, ',')
+	}
+	return nil, fmt.Errorf("unsupported clue file type: %s", path)
+}
+
+func readDelimitedClues(r io.Reader, comma rune) ([]Clue, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = comma
+	reader.LazyQuotes = true
+	reader.FieldsPerRecord = 9
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	clues := []Clue{}
+	for i, row := range rows {
+		if i == 0 {
+			continue
+		}
+		round, err := strconv.Atoi(row[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid round on line %d: %s", i+1, row[0])
+		}
+		value, err := strconv.Atoi(row[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid clue value on line %d: %s", i+1, row[1])
+		}
+		ddValue, err := strconv.Atoi(row[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid daily double value on line %d: %s", i+1, row[2])
+		}
+		clues = append(clues, Clue{
+			Round:            round,
+			Value:            value,
+			DailyDoubleValue: ddValue,
+			Category:         row[3],
+			Comments:         row[4],
+			Clue:             row[5],
+			Answer:           row[6],
+			AirDate:          row[7],
+			Notes:            row[8],
+		})
+	}
+	return clues, nil
+}
+
+func (c *Clue) question() Question {
+	return Question{
+		Id:           c.Id,
+		Round:        c.Round,
+		Value:        c.Value,
+		Category:     c.Category,
+		Comments:     c.Comments,
+		Clue:         c.Clue,
+		Answer:       c.Answer,
+		Alternatives: append([]string{}, c.Alternatives...),
+		Incorrect:    append([]string{}, c.Incorrect...),
+	}
+}
+
+// SetSeed makes the random category and Final Jeopardy selection repeatable.
+func (db *MemoryDB) SetSeed(seed uint64) {
+	db.rngMu.Lock()
+	defer db.rngMu.Unlock()
+	db.rng = rand.New(rand.NewPCG(seed, seed))
+}
+
+func (db *MemoryDB) Close() {}
+
+// categories groups the clues of a round by category and air date, keeping
+// only categories with exactly five clues like get_questions.sql does.
+func (db *MemoryDB) categories(round int) [][]*Clue {
+	groups := map[Category][]*Clue{}
+	keys := []Category{}
+	for _, c := range db.clues {
+		if c.Round != round {
+			continue
+		}
+		key := Category{Name: c.Category, Round: c.Round, AirDate: c.AirDate}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], c)
+	}
+	categories := [][]*Clue{}
+	for _, key := range keys {
+		if len(groups[key]) == 5 {
+			categories = append(categories, groups[key])
+		}
+	}
+	return categories
+}
+
+func (db *MemoryDB) pickCategories(categories [][]*Clue, n int) [][]*Clue {
+	db.rngMu.Lock()
+	defer db.rngMu.Unlock()
+	db.rng.Shuffle(len(categories), func(i, j int) {
+		categories[i], categories[j] = categories[j], categories[i]
+	})
+	categories = categories[:min(n, len(categories))]
+	sort.SliceStable(categories, func(i, j int) bool {
+		return categories[i][0].Category < categories[j][0].Category
+	})
+	return categories
+}
+
+func sortedQuestions(clues []*Clue) []Question {
+	sorted := append([]*Clue{}, clues...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Value < sorted[j].Value
+	})
+	questions := []Question{}
+	for _, c := range sorted {
+		questions = append(questions, c.question())
+	}
+	return questions
+}
+
+func (db *MemoryDB) GetQuestions(ctx context.Context, frCategories, srCategories, clues int) ([]Question, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	questions := []Question{}
+	for _, category := range db.pickCategories(db.categories(1), frCategories) {
+		sorted := sortedQuestions(category)
+		questions = append(questions, sorted[:min(clues, len(sorted))]...)
+	}
+	for _, category := range db.pickCategories(db.categories(2), srCategories) {
+		sorted := sortedQuestions(category)
+		questions = append(questions, sorted[:min(clues, len(sorted))]...)
+	}
+	finals := []*Clue{}
+	for _, c := range db.clues {
+		if c.Round == 3 {
+			finals = append(finals, c)
+		}
+	}
+	if len(finals) > 0 {
+		db.rngMu.Lock()
+		final := finals[db.rng.IntN(len(finals))]
+		db.rngMu.Unlock()
+		questions = append(questions, final.question())
+	}
+	return questions, nil
+}
+
+func (db *MemoryDB) GetCategoryQuestions(ctx context.Context, category Category) ([]Question, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	clues := []*Clue{}
+	for _, c := range db.clues {
+		if c.Category == category.Name && c.AirDate == category.AirDate && c.Round == category.Round {
+			clues = append(clues, c)
+		}
+	}
+	return sortedQuestions(clues), nil
+}
+
+func (db *MemoryDB) GetDailyDoubleOccurrences(ctx context.Context) ([]DailyDoubleOccurrence, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	counts := map[[2]int]int{}
+	for _, c := range db.clues {
+		if c.DailyDoubleValue > 0 && (c.Round == 1 || c.Round == 2) {
+			counts[[2]int{c.Round, c.Value}]++
+		}
+	}
+	occurrences := []DailyDoubleOccurrence{}
+	for key, count := range counts {
+		occurrences = append(occurrences, DailyDoubleOccurrence{Round: key[0], Value: key[1], Count: count})
+	}
+	sort.Slice(occurrences, func(i, j int) bool {
+		if occurrences[i].Round != occurrences[j].Round {
+			return occurrences[i].Round < occurrences[j].Round
+		}
+		return occurrences[i].Value < occurrences[j].Value
+	})
+	return occurrences, nil
+}
+
+func (db *MemoryDB) AddAlternative(ctx context.Context, clueId int, alternative string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if c := db.clue(clueId); c != nil {
+		c.Alternatives = append(c.Alternatives, alternative)
+	}
+	return nil
+}
+
+func (db *MemoryDB) AddIncorrect(ctx context.Context, clueId int, incorrect string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if c := db.clue(clueId); c != nil {
+		c.Incorrect = append(c.Incorrect, incorrect)
+	}
+	return nil
+}
+
+func (db *MemoryDB) clue(id int) *Clue {
+	for _, c := range db.clues {
+		if c.Id == id {
+			return c
+		}
+	}
+	return nil
+}
+
+func (db *MemoryDB) InsertClues(ctx context.Context, clues []Clue) (int, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	nextId := 1
+	for _, c := range db.clues {
+		nextId = max(nextId, c.Id+1)
+	}
+	inserted := 0
+	for _, c := range clues {
+		if slices.ContainsFunc(db.clues, func(existing *Clue) bool {
+			return existing.Round == c.Round && existing.Category == c.Category && existing.AirDate == c.AirDate && existing.Clue == c.Clue
+		}) {
+			continue
+		}
+		clue := c
+		clue.Id = nextId
+		nextId++
+		clue.setDefaults()
+		db.clues = append(db.clues, &clue)
+		inserted++
+	}
+	return inserted, nil
+}
+
+func (db *MemoryDB) ExportAnswers(ctx context.Context, limit int) ([]Clue, error) {
+	db.mu.RLock()
+	clues := []Clue{}
+	for _, c := range db.clues {
+		if len(c.Alternatives) > 1 || len(c.Incorrect) > 0 {
+			clues = append(clues, Clue{
+				Answer:       c.Answer,
+				Alternatives: append([]string{}, c.Alternatives...),
+				Incorrect:    append([]string{}, c.Incorrect...),
+			})
+		}
+	}
+	db.mu.RUnlock()
+
+	db.rngMu.Lock()
+	db.rng.Shuffle(len(clues), func(i, j int) {
+		clues[i], clues[j] = clues[j], clues[i]
+	})
+	db.rngMu.Unlock()
+	return clues[:min(limit, len(clues))], nil
+}
+
+func (db *MemoryDB) AddPendingAlternative(ctx context.Context, alt PendingAlternative) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	alt.Id = len(db.pendingAlts) + 1
+	alt.Status = PendingStatus
+	alt.CreatedAt = time.Now()
+	db.pendingAlts = append(db.pendingAlts, alt)
+	return nil
+}
+
+func (db *MemoryDB) GetPendingAlternatives(ctx context.Context, status string) ([]PendingAlternative, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	alts := []PendingAlternative{}
+	for _, alt := range db.pendingAlts {
+		if alt.Status == status {
+			alts = append(alts, alt)
+		}
+	}
+	return alts, nil
+}
+
+func (db *MemoryDB) ReviewPendingAlternative(ctx context.Context, id int, approved bool, reviewer string) (PendingAlternative, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if id < 1 || id > len(db.pendingAlts) || db.pendingAlts[id-1].Status != PendingStatus {
+		return PendingAlternative{}, pgx.ErrNoRows
+	}
+	alt := &db.pendingAlts[id-1]
+	now := time.Now()
+	alt.Status, alt.Reviewer, alt.ReviewedAt = RejectedStatus, reviewer, &now
+	if approved {
+		alt.Status = ApprovedStatus
+		if c := db.clue(alt.ClueId); c != nil {
+			c.Alternatives = append(c.Alternatives, alt.Alternative)
+		}
+	}
+	return *alt, nil
+}
+
+func (db *MemoryDB) SearchCategories(ctx context.Context, query, start string, secondRound int) ([]Category, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	rounds := []int{1}
+	if secondRound == 2 {
+		rounds = append(rounds, 2)
+	}
+	categories := []Category{}
+	for _, round := range rounds {
+		for _, category := range db.categories(round) {
+			name := strings.ToLower(category[0].Category)
+			if (start == "" && strings.HasPrefix(name, query)) || (start != "" && strings.Contains(name, query)) {
+				categories = append(categories, Category{
+					Name:    category[0].Category,
+					Round:   category[0].Round,
+					AirDate: category[0].AirDate,
+				})
+			}
+		}
+	}
+	sort.SliceStable(categories, func(i, j int) bool {
+		return categories[i].Name < categories[j].Name
+	})
+	return categories, nil
+}
+
+func (db *MemoryDB) SaveGameAnalytics(ctx context.Context, gameID uuid.UUID, createdAt int64, fr AnalyticsRound, sr AnalyticsRound) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.analytics = append(db.analytics, analyticsRow{gameID: gameID, createdAt: createdAt, fr: fr, sr: sr})
+	return nil
+}
+
+func (db *MemoryDB) IncrementPlayerGames(ctx context.Context, email string, win, points, answered, correct int) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	p, ok := db.playerGames[email]
+	if !ok {
+		db.playerGames[email] = &PlayerAnalytics{
+			Games:      1,
+			Wins:       win,
+			Points:     points,
+			Answers:    answered,
+			Correct:    correct,
+			MaxPoints:  points,
+			MaxCorrect: correct,
+		}
+		return nil
+	}
+	p.Games++
+	p.Wins += win
+	p.Points += points
+	p.Answers += answered
+	p.Correct += correct
+	p.MaxPoints = max(p.MaxPoints, points)
+	p.MaxCorrect = max(p.MaxCorrect, correct)
+	return nil
+}
+
+// GetClueDifficulty computes the same counts as get_clue_difficulty.sql.
+func (db *MemoryDB) GetClueDifficulty(ctx context.Context) ([]ClueDifficulty, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	counts := map[[2]int]*ClueDifficulty{}
+	for _, a := range db.analytics {
+		for _, round := range [][]AnalyticsCategory{a.fr.Categories, a.sr.Categories} {
+			for _, category := range round {
+				for _, q := range category.Question {
+					c := db.clue(q.ClueId)
+					if c == nil || (c.Round != 1 && c.Round != 2) {
+						continue
+					}
+					key := [2]int{c.Round, c.Value}
+					if counts[key] == nil {
+						counts[key] = &ClueDifficulty{Round: c.Round, Value: c.Value}
+					}
+					d := counts[key]
+					d.Shown++
+					answers := 0
+					for _, ans := range q.Answers {
+						if ans.Bot {
+							continue
+						}
+						answers++
+						if ans.Correct {
+							d.Correct++
+						}
+					}
+					d.Answers += answers
+					if answers > 0 {
+						d.Answered++
+					}
+				}
+			}
+		}
+	}
+	difficulty := []ClueDifficulty{}
+	for _, d := range counts {
+		difficulty = append(difficulty, *d)
+	}
+	sort.Slice(difficulty, func(i, j int) bool {
+		if difficulty[i].Round != difficulty[j].Round {
+			return difficulty[i].Round < difficulty[j].Round
+		}
+		return difficulty[i].Value < difficulty[j].Value
+	})
+	return difficulty, nil
+}
+
+// GetAnalytics computes the same aggregates as get_analytics.sql.
+func (db *MemoryDB) GetAnalytics(ctx context.Context) (any, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	avg := func(vals []float64) int {
+		if len(vals) == 0 {
+			return 0
+		}
+		total := 0.0
+		for _, v := range vals {
+			total += v
+		}
+		return int(math.Round(total / float64(len(vals))))
+	}
+	var frAns, frCorr, frScore, srAns, srCorr, srScore []float64
+	for _, a := range db.analytics {
+		for _, r := range []struct {
+			round        AnalyticsRound
+			ans, corr, s *[]float64
+		}{
+			{a.fr, &frAns, &frCorr, &frScore},
+			{a.sr, &srAns, &srCorr, &srScore},
+		} {
+			if r.round.Answers == nil {
+				continue
+			}
+			*r.ans = append(*r.ans, 100*float64(*r.round.Answers)/30)
+			if *r.round.Answers > 0 && r.round.Correct != nil {
+				*r.corr = append(*r.corr, 100*float64(*r.round.Correct)/float64(*r.round.Answers))
+			}
+			if r.round.Score != nil {
+				*r.s = append(*r.s, *r.round.Score)
+			}
+		}
+	}
+	return struct {
+		GamesPlayed         int `json:"gamesPlayed"`
+		FirstRoundAnsRate   int `json:"firstRoundAnsRate"`
+		FirstRoundCorrRate  int `json:"firstRoundCorrRate"`
+		FirstRoundScore     int `json:"firstRoundScore"`
+		SecondRoundAnsRate  int `json:"secondRoundAnsRate"`
+		SecondRoundCorrRate int `json:"secondRoundCorrRate"`
+		SecondRoundScore    int `json:"secondRoundScore"`
+	}{
+		GamesPlayed:         len(db.analytics),
+		FirstRoundAnsRate:   avg(frAns),
+		FirstRoundCorrRate:  avg(frCorr),
+		FirstRoundScore:     avg(frScore),
+		SecondRoundAnsRate:  avg(srAns),
+		SecondRoundCorrRate: avg(srCorr),
+		SecondRoundScore:    avg(srScore),
+	}, nil
+}
+
+func (db *MemoryDB) GetPlayerAnalytics(ctx context.Context, email string) (PlayerAnalytics, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	p, ok := db.playerGames[email]
+	if !ok {
+		return PlayerAnalytics{}, pgx.ErrNoRows
+	}
+	return *p, nil
+}
+
+func (db *MemoryDB) GetLeaderboard(ctx context.Context, leaderboardType string) ([]*LeaderboardUser, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	rate := func(a, b int) float64 {
+		if b == 0 {
+			return 0
+		}
+		return float64(a) / float64(b)
+	}
+	leaderboard := []*LeaderboardUser{}
+	for email, p := range db.playerGames {
+		leaderboard = append(leaderboard, &LeaderboardUser{
+			User:            User{Email: email},
+			PlayerAnalytics: *p,
+			WinRate:         rate(p.Wins, p.Games),
+			CorrectRate:     rate(p.Correct, p.Answers),
+		})
+	}
+	var key func(u *LeaderboardUser) float64
+	switch leaderboardType {
+	case "win_rate":
+		key = func(u *LeaderboardUser) float64 { return u.WinRate }
+	case "wins":
+		key = func(u *LeaderboardUser) float64 { return float64(u.Wins) }
+	case "games":
+		key = func(u *LeaderboardUser) float64 { return float64(u.Games) }
+	case "correct_rate":
+		key = func(u *LeaderboardUser) float64 { return u.CorrectRate }
+	case "correct":
+		key = func(u *LeaderboardUser) float64 { return float64(u.Correct) }
+	case "answers":
+		key = func(u *LeaderboardUser) float64 { return float64(u.Answers) }
+	case "points":
+		key = func(u *LeaderboardUser) float64 { return float64(u.Points) }
+	case "max_points":
+		key = func(u *LeaderboardUser) float64 { return float64(u.MaxPoints) }
+	case "max_correct":
+		key = func(u *LeaderboardUser) float64 { return float64(u.MaxCorrect) }
+	default:
+		return nil, fmt.Errorf("invalid leaderboard type: %s", leaderboardType)
+	}
+	sort.SliceStable(leaderboard, func(i, j int) bool {
+		if key(leaderboard[i]) != key(leaderboard[j]) {
+			return key(leaderboard[i]) > key(leaderboard[j])
+		}
+		if leaderboard[i].CorrectRate != leaderboard[j].CorrectRate {
+			return leaderboard[i].CorrectRate > leaderboard[j].CorrectRate
+		}
+		return leaderboard[i].Email < leaderboard[j].Email
+	})
+	return leaderboard[:min(10, len(leaderboard))], nil
+}
+
+// GetUserByEmail stands in for the Supabase auth.users lookup, using the
+// local part of the email as the display name.
+func (db *MemoryDB) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	name, _, _ := strings.Cut(email, "@")
+	return User{
+		ID:          uuid.NewSHA1(uuid.NameSpaceOID, []byte(email)).String(),
+		Email:       email,
+		DisplayName: name,
+		Public:      true,
+	}, nil
+}
+
+func (db *MemoryDB) GetUserByName(ctx context.Context, name string) (User, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	for email := range db.playerGames {
+		if local, _, _ := strings.Cut(email, "@"); local == name {
+			return db.GetUserByEmail(ctx, email)
+		}
+	}
+	return User{}, pgx.ErrNoRows
+}
+
+func (db *MemoryDB) SaveGameSnapshot(ctx context.Context, name string, snapshot []byte) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.snapshots[name] = append([]byte{}, snapshot...)
+	return nil
+}
+
+func (db *MemoryDB) DeleteGameSnapshot(ctx context.Context, name string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	delete(db.snapshots, name)
+	return nil
+}
+
+func (db *MemoryDB) GetGameSnapshots(ctx context.Context) ([][]byte, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	names := []string{}
+	for name := range db.snapshots {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	snapshots := [][]byte{}
+	for _, name := range names {
+		snapshots = append(snapshots, append([]byte{}, db.snapshots[name]...))
+	}
+	return snapshots, nil
+}
+
+func (db *MemoryDB) AppendGameEvents(ctx context.Context, gameId string, firstSeq int, events []string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if firstSeq != len(db.gameEvents[gameId]) {
+		return fmt.Errorf("event %d of game %s is out of order", firstSeq, gameId)
+	}
+	for _, event := range events {
+		db.gameEvents[gameId] = append(db.gameEvents[gameId], []byte(event))
+	}
+	return nil
+}
+
+func (db *MemoryDB) GetGameEvents(ctx context.Context, gameId string) ([][]byte, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	return append([][]byte{}, db.gameEvents[gameId]...), nil
+}
+
+func (db *MemoryDB) SavePracticeResult(ctx context.Context, r PracticeResult) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	results := db.practice[r.Email]
+	for i, result := range results {
+		if result.Category == r.Category && result.Clue == r.Clue {
+			results[i] = r
+			return nil
+		}
+	}
+	db.practice[r.Email] = append(results, r)
+	return nil
+}
+
+func (db *MemoryDB) GetPracticeResults(ctx context.Context, email string) ([]PracticeResult, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	results := append([]PracticeResult{}, db.practice[email]...)
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].DueAt.Before(results[j].DueAt)
+	})
+	return results, nil
+}
+
+func (db *MemoryDB) GetRandomCategory(ctx context.Context) (Category, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	categories := append(db.categories(1), db.categories(2)...)
+	if len(categories) == 0 {
+		return Category{}, pgx.ErrNoRows
+	}
+	db.rngMu.Lock()
+	c := categories[db.rng.IntN(len(categories))][0]
+	db.rngMu.Unlock()
+	return Category{Name: c.Category, Round: c.Round, AirDate: c.AirDate}, nil
+}
+
+func (db *MemoryDB) GetDailyQuestions(ctx context.Context, day string, frCategories, srCategories, clues int) ([]Question, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(day))
+	rng := rand.New(rand.NewPCG(h.Sum64(), h.Sum64()))
+	pick := func(categories [][]*Clue, n int) [][]*Clue {
+		rng.Shuffle(len(categories), func(i, j int) {
+			categories[i], categories[j] = categories[j], categories[i]
+		})
+		categories = categories[:min(n, len(categories))]
+		sort.SliceStable(categories, func(i, j int) bool {
+			return categories[i][0].Category < categories[j][0].Category
+		})
+		return categories
+	}
+
+	questions := []Question{}
+	for _, category := range pick(db.categories(1), frCategories) {
+		sorted := sortedQuestions(category)
+		questions = append(questions, sorted[:min(clues, len(sorted))]...)
+	}
+	for _, category := range pick(db.categories(2), srCategories) {
+		sorted := sortedQuestions(category)
+		questions = append(questions, sorted[:min(clues, len(sorted))]...)
+	}
+	finals := []*Clue{}
+	for _, c := range db.clues {
+		if c.Round == 3 {
+			finals = append(finals, c)
+		}
+	}
+	if len(finals) > 0 {
+		questions = append(questions, finals[rng.IntN(len(finals))].question())
+	}
+	return questions, nil
+}
+
+func (db *MemoryDB) StartDailyResult(ctx context.Context, r DailyResult) (bool, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for _, result := range db.dailyResults {
+		if result.Day == r.Day && result.Email == r.Email {
+			return false, nil
+		}
+	}
+	db.dailyResults = append(db.dailyResults, DailyResult{Day: r.Day, Email: r.Email, Name: r.Name})
+	return true, nil
+}
+
+func (db *MemoryDB) SaveDailyResult(ctx context.Context, r DailyResult) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	r.Finished = true
+	for i, result := range db.dailyResults {
+		if result.Day == r.Day && result.Email == r.Email {
+			if !result.Finished {
+				db.dailyResults[i] = r
+			}
+			return nil
+		}
+	}
+	db.dailyResults = append(db.dailyResults, r)
+	return nil
+}
+
+func (db *MemoryDB) GetDailyResult(ctx context.Context, day, email string) (*DailyResult, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	for _, r := range db.dailyResults {
+		if r.Day == day && r.Email == email {
+			return &r, nil
+		}
+	}
+	return nil, nil
+}
+
+func (db *MemoryDB) GetDailyLeaderboard(ctx context.Context, day string, limit int) ([]DailyResult, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	results := []DailyResult{}
+	for _, r := range db.dailyResults {
+		if r.Day == day && r.Finished {
+			results = append(results, r)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Seconds < results[j].Seconds
+	})
+	return results[:min(limit, len(results))], nil
+}
+
+func (db *MemoryDB) AddDailyBoard(ctx context.Context, day string, board []byte) ([]byte, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if stored, ok := db.dailyBoards[day]; ok {
+		return stored, nil
+	}
+	db.dailyBoards[day] = board
+	return board, nil
+}
+
+func (db *MemoryDB) GetDailyBoard(ctx context.Context, day string) ([]byte, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	return db.dailyBoards[day], nil
+}
+
+func (db *MemoryDB) AddCustomBoard(ctx context.Context, b CustomBoard) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	b.CreatedAt = b.UpdatedAt
+	db.customBoards[b.Id] = b
+	return nil
+}
+
+func (db *MemoryDB) UpdateCustomBoard(ctx context.Context, b CustomBoard) (CustomBoard, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	existing, ok := db.customBoards[b.Id]
+	if !ok || existing.Owner != b.Owner {
+		return CustomBoard{}, pgx.ErrNoRows
+	}
+	b.CreatedAt = existing.CreatedAt
+	db.customBoards[b.Id] = b
+	return b, nil
+}
+
+func (db *MemoryDB) GetCustomBoard(ctx context.Context, id string) (CustomBoard, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	b, ok := db.customBoards[id]
+	if !ok {
+		return CustomBoard{}, pgx.ErrNoRows
+	}
+	return b, nil
+}
+
+func (db *MemoryDB) GetCustomBoards(ctx context.Context, owner string) ([]CustomBoard, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	boards := []CustomBoard{}
+	for _, b := range db.customBoards {
+		if b.Owner == owner {
+			boards = append(boards, b)
+		}
+	}
+	sort.Slice(boards, func(i, j int) bool {
+		return boards[i].UpdatedAt.After(boards[j].UpdatedAt)
+	})
+	return boards, nil
+}
+
+func (db *MemoryDB) DeleteCustomBoard(ctx context.Context, id, owner string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	b, ok := db.customBoards[id]
+	if !ok || b.Owner != owner {
+		return pgx.ErrNoRows
+	}
+	delete(db.customBoards, id)
+	return nil
+}
@@ -0,0 +1,211 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestMemoryDB(t *testing.T) *MemoryDB {
+	t.Helper()
+	memoryDB, err := NewMemoryDBFromFile("testdata/clues.json")
+	if err != nil {
+		t.Fatalf("Error loading test clues: %s", err.Error())
+	}
+	return memoryDB
+}
+
+func TestMemoryGetQuestions(t *testing.T) {
+	t.Run("test getting questions from memory", func(t *testing.T) {
+		ctx := context.Background()
+		questionDB := newTestMemoryDB(t)
+		questions, err := questionDB.GetQuestions(ctx, 6, 6, 5)
+		if err != nil {
+			t.Fatalf("Error getting questions: %s", err.Error())
+		}
+		assert.Len(t, questions, 61)
+		for i, question := range questions {
+			if i < 30 {
+				assert.Equal(t, 1, question.Round)
+				assert.Equal(t, 200*(i%5+1), question.Value)
+				assert.NotEqual(t, "INCOMPLETE", question.Category)
+			} else if i < 60 {
+				assert.Equal(t, 2, question.Round)
+				assert.Equal(t, 400*(i%5+1), question.Value)
+			} else {
+				assert.Equal(t, 3, question.Round)
+				assert.Equal(t, 0, question.Value)
+			}
+			assert.Contains(t, question.Alternatives, question.Answer)
+		}
+	})
+
+	t.Run("test getting a smaller board from memory", func(t *testing.T) {
+		questionDB := newTestMemoryDB(t)
+		questions, err := questionDB.GetQuestions(context.Background(), 4, 2, 3)
+		assert.NoError(t, err)
+		assert.Len(t, questions, 19)
+		for i, question := range questions[:18] {
+			assert.Equal(t, 200*(1+i%3)*question.Round, question.Value)
+		}
+	})
+}
+
+func TestMemoryAlternativesAndIncorrect(t *testing.T) {
+	t.Run("test adding alternatives and incorrect answers", func(t *testing.T) {
+		ctx := context.Background()
+		questionDB := newTestMemoryDB(t)
+		category := Category{Name: "SCIENCE", Round: 1, AirDate: "2004-05-12"}
+		questions, err := questionDB.GetCategoryQuestions(ctx, category)
+		assert.NoError(t, err)
+		assert.Len(t, questions, 5)
+		assert.NoError(t, questionDB.AddAlternative(ctx, questions[4].Id, "Newton"))
+		assert.NoError(t, questionDB.AddIncorrect(ctx, questions[1].Id, "Venus"))
+		assert.NoError(t, questionDB.AddIncorrect(ctx, 0, "Pluto"))
+		questions, err = questionDB.GetCategoryQuestions(ctx, category)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Isaac Newton", "Newton"}, questions[4].Alternatives)
+		assert.Equal(t, []string{"Venus"}, questions[1].Incorrect)
+		assert.Equal(t, 17, questions[1].Id)
+		assert.Equal(t, []string{"Venus"}, questionDB.clues[16].Incorrect)
+
+		answers, err := questionDB.ExportAnswers(ctx, 10)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []Clue{
+			{Answer: "Isaac Newton", Alternatives: []string{"Isaac Newton", "Newton"}, Incorrect: []string{}},
+			{Answer: questions[1].Answer, Alternatives: []string{questions[1].Answer}, Incorrect: []string{"Venus"}},
+		}, answers)
+		answers, err = questionDB.ExportAnswers(ctx, 1)
+		assert.NoError(t, err)
+		assert.Len(t, answers, 1)
+	})
+}
+
+func TestMemoryPlayerGames(t *testing.T) {
+	t.Run("test player games and leaderboard", func(t *testing.T) {
+		ctx := context.Background()
+		analyticsDB := NewMemoryDB(nil)
+		assert.NoError(t, analyticsDB.IncrementPlayerGames(ctx, "a@example.com", 1, 5000, 20, 15))
+		assert.NoError(t, analyticsDB.IncrementPlayerGames(ctx, "a@example.com", 0, 2000, 10, 5))
+		assert.NoError(t, analyticsDB.IncrementPlayerGames(ctx, "b@example.com", 1, 8000, 10, 10))
+
+		a, err := analyticsDB.GetPlayerAnalytics(ctx, "a@example.com")
+		assert.NoError(t, err)
+		assert.Equal(t, PlayerAnalytics{Games: 2, Wins: 1, Points: 7000, Answers: 30, Correct: 20, MaxPoints: 5000, MaxCorrect: 15}, a)
+
+		_, err = analyticsDB.GetPlayerAnalytics(ctx, "c@example.com")
+		assert.Error(t, err)
+
+		leaderboard, err := analyticsDB.GetLeaderboard(ctx, "points")
+		assert.NoError(t, err)
+		assert.Len(t, leaderboard, 2)
+		assert.Equal(t, "b@example.com", leaderboard[0].Email)
+		assert.Equal(t, 0.5, leaderboard[1].WinRate)
+
+		_, err = analyticsDB.GetLeaderboard(ctx, "drop table")
+		assert.Error(t, err)
+	})
+}
+
+func TestMemoryClueDifficulty(t *testing.T) {
+	t.Run("test counting answers to clues by round and value", func(t *testing.T) {
+		ctx := context.Background()
+		analyticsDB := newTestMemoryDB(t)
+		fr := AnalyticsRound{Categories: []AnalyticsCategory{{Title: "CATEGORY", Question: []AnalyticsQuestion{
+			{ClueId: 1, Answers: []AnalyticsAnswer{{Correct: true}, {Bot: true}}},
+			{ClueId: 6, Answers: []AnalyticsAnswer{{}, {Correct: true}}},
+			{ClueId: 2},
+			{ClueId: 0, Answers: []AnalyticsAnswer{{Correct: true}}},
+		}}}}
+		assert.NoError(t, analyticsDB.SaveGameAnalytics(ctx, uuid.New(), 0, fr, AnalyticsRound{}))
+
+		difficulty, err := analyticsDB.GetClueDifficulty(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []ClueDifficulty{
+			{Round: 1, Value: 200, Shown: 2, Answered: 2, Answers: 3, Correct: 2},
+			{Round: 1, Value: 400, Shown: 1},
+		}, difficulty)
+	})
+}
+
+func TestMemoryPracticeResults(t *testing.T) {
+	t.Run("test saving practice results", func(t *testing.T) {
+		ctx := context.Background()
+		practiceDB := NewMemoryDB(nil)
+		category := Category{Name: "SCIENCE", Round: 1, AirDate: "2020-01-01"}
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		first := PracticeResult{Email: "a@example.com", Category: category, Clue: "first", Attempts: 1, DueAt: now.Add(time.Hour)}
+		second := PracticeResult{Email: "a@example.com", Category: category, Clue: "second", Attempts: 1, DueAt: now.Add(2 * time.Hour)}
+		assert.NoError(t, practiceDB.SavePracticeResult(ctx, first))
+		assert.NoError(t, practiceDB.SavePracticeResult(ctx, second))
+		first.Attempts, first.DueAt = 2, now.Add(3*time.Hour)
+		assert.NoError(t, practiceDB.SavePracticeResult(ctx, first))
+
+		results, err := practiceDB.GetPracticeResults(ctx, "a@example.com")
+		assert.NoError(t, err)
+		assert.Equal(t, []PracticeResult{second, first}, results)
+
+		results, err = practiceDB.GetPracticeResults(ctx, "b@example.com")
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+
+		_, err = practiceDB.GetRandomCategory(ctx)
+		assert.Error(t, err)
+	})
+}
+
+func TestMemoryDailyResults(t *testing.T) {
+	t.Run("test only the first result of the day counts", func(t *testing.T) {
+		ctx := context.Background()
+		dailyDB := NewMemoryDB(nil)
+		slow := DailyResult{Day: "2024-01-01", Email: "a@example.com", Name: "a", Score: 4000, Seconds: 600, Finished: true}
+		fast := DailyResult{Day: "2024-01-01", Email: "b@example.com", Name: "b", Score: 4000, Seconds: 300, Finished: true}
+		started, err := dailyDB.StartDailyResult(ctx, DailyResult{Day: "2024-01-01", Email: "a@example.com", Name: "a"})
+		assert.NoError(t, err)
+		assert.True(t, started)
+		started, err = dailyDB.StartDailyResult(ctx, DailyResult{Day: "2024-01-01", Email: "a@example.com", Name: "a"})
+		assert.NoError(t, err)
+		assert.False(t, started)
+		result, err := dailyDB.GetDailyResult(ctx, "2024-01-01", "a@example.com")
+		assert.NoError(t, err)
+		assert.Equal(t, &DailyResult{Day: "2024-01-01", Email: "a@example.com", Name: "a"}, result)
+
+		assert.NoError(t, dailyDB.SaveDailyResult(ctx, slow))
+		assert.NoError(t, dailyDB.SaveDailyResult(ctx, fast))
+		assert.NoError(t, dailyDB.SaveDailyResult(ctx, DailyResult{Day: "2024-01-01", Email: "a@example.com", Score: 9000}))
+		assert.NoError(t, dailyDB.SaveDailyResult(ctx, DailyResult{Day: "2024-01-02", Email: "a@example.com", Score: 100}))
+
+		leaderboard, err := dailyDB.GetDailyLeaderboard(ctx, "2024-01-01", 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []DailyResult{fast, slow}, leaderboard)
+
+		result, err = dailyDB.GetDailyResult(ctx, "2024-01-01", "a@example.com")
+		assert.NoError(t, err)
+		assert.Equal(t, &slow, result)
+
+		result, err = dailyDB.GetDailyResult(ctx, "2024-01-01", "c@example.com")
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("test only the first board of the day is stored", func(t *testing.T) {
+		ctx := context.Background()
+		dailyDB := NewMemoryDB(nil)
+		board, err := dailyDB.GetDailyBoard(ctx, "2024-01-01")
+		assert.NoError(t, err)
+		assert.Nil(t, board)
+
+		board, err = dailyDB.AddDailyBoard(ctx, "2024-01-01", []byte(`{"first": true}`))
+		assert.NoError(t, err)
+		assert.Equal(t, `{"first": true}`, string(board))
+		board, err = dailyDB.AddDailyBoard(ctx, "2024-01-01", []byte(`{"second": true}`))
+		assert.NoError(t, err)
+		assert.Equal(t, `{"first": true}`, string(board))
+		board, err = dailyDB.GetDailyBoard(ctx, "2024-01-01")
+		assert.NoError(t, err)
+		assert.Equal(t, `{"first": true}`, string(board))
+	})
+}
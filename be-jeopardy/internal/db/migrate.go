@@ -0,0 +1,199 @@
+package db
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type (
+	// Migration is a versioned change to the schema, with the SQL that makes
+	// it and the SQL that undoes it.
+	Migration struct {
+		Version int
+		Name    string
+		Up      string
+		Down    string
+	}
+
+	AppliedMigration struct {
+		Version   int       `json:"version"`
+		Name      string    `json:"name"`
+		AppliedAt time.Time `json:"appliedAt"`
+	}
+
+	// migrationStep runs a migration up, or down if it is being undone.
+	migrationStep struct {
+		Migration
+		down bool
+	}
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+var migrationFile = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migrations returns the migrations in migrations/, in order. Each version
+// has an up and a down file named like 0001_create_jeopardy_clues.up.sql,
+// and versions count up from 1.
+func Migrations() ([]Migration, error) {
+	return loadMigrations(migrationFiles, "migrations")
+}
+
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	byVersion := map[int]*Migration{}
+	for _, entry := range entries {
+		match := migrationFile.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %s", entry.Name())
+		}
+		version, _ := strconv.Atoi(match[1])
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d is named both %s and %s", version, m.Name, match[2])
+		}
+		sql, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if match[3] == "up" {
+			m.Up = string(sql)
+		} else {
+			m.Down = string(sql)
+		}
+	}
+
+	migrations := []Migration{}
+	for _, m := range byVersion {
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			return nil, fmt.Errorf("migration %d is missing", i+1)
+		}
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d needs both an up and a down file", m.Version)
+		}
+	}
+	return migrations, nil
+}
+
+// planMigrations returns the steps that take the schema from the applied
+// version to the target version: migrations up in order, or down in
+// reverse order.
+func planMigrations(migrations []Migration, applied, target int) ([]migrationStep, error) {
+	if target < 0 || target > len(migrations) {
+		return nil, fmt.Errorf("no migration %d, the latest is %d", target, len(migrations))
+	}
+	if applied > len(migrations) {
+		return nil, fmt.Errorf("database is at migration %d, newer than the latest migration %d", applied, len(migrations))
+	}
+	steps := []migrationStep{}
+	for v := applied + 1; v <= target; v++ {
+		steps = append(steps, migrationStep{Migration: migrations[v-1]})
+	}
+	for v := applied; v > target; v-- {
+		steps = append(steps, migrationStep{Migration: migrations[v-1], down: true})
+	}
+	return steps, nil
+}
+
+//go:embed sql/create_schema_migrations.sql
+var createSchemaMigrations string
+
+//go:embed sql/get_schema_migrations.sql
+var getSchemaMigrations string
+
+//go:embed sql/add_schema_migration.sql
+var addSchemaMigration string
+
+//go:embed sql/delete_schema_migration.sql
+var deleteSchemaMigration string
+
+// AppliedMigrations returns the migrations the database has had applied,
+// in order.
+func (db *JeopardyDB) AppliedMigrations(ctx context.Context) ([]AppliedMigration, error) {
+	if _, err := db.pool.Exec(ctx, createSchemaMigrations); err != nil {
+		return nil, err
+	}
+	rows, err := db.pool.Query(ctx, getSchemaMigrations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := []AppliedMigration{}
+	for rows.Next() {
+		var m AppliedMigration
+		if err := rows.Scan(&m.Version, &m.Name, &m.AppliedAt); err != nil {
+			return nil, err
+		}
+		applied = append(applied, m)
+	}
+
+	return applied, rows.Err()
+}
+
+// Migrate runs migrations up or down until the database is at the target
+// version, returning the migrations it ran. Each migration runs in its own
+// transaction along with the update to schema_migrations.
+func (db *JeopardyDB) Migrate(ctx context.Context, target int) ([]Migration, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := db.AppliedMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	version := 0
+	if len(applied) > 0 {
+		version = applied[len(applied)-1].Version
+	}
+	steps, err := planMigrations(migrations, version, target)
+	if err != nil {
+		return nil, err
+	}
+
+	ran := []Migration{}
+	for _, step := range steps {
+		err := pgx.BeginFunc(ctx, db.pool, func(tx pgx.Tx) error {
+			if step.down {
+				if _, err := tx.Exec(ctx, step.Down); err != nil {
+					return err
+				}
+				_, err := tx.Exec(ctx, deleteSchemaMigration, step.Version)
+				return err
+			}
+			if _, err := tx.Exec(ctx, step.Up); err != nil {
+				return err
+			}
+			_, err := tx.Exec(ctx, addSchemaMigration, step.Version, step.Name)
+			return err
+		})
+		if err != nil {
+			return ran, fmt.Errorf("error running migration %d_%s: %w", step.Version, step.Name, err)
+		}
+		ran = append(ran, step.Migration)
+	}
+	return ran, nil
+}
@@ -0,0 +1,80 @@
+package db
+
+import (
+	"context"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrations(t *testing.T) {
+	t.Run("test loading migrations", func(t *testing.T) {
+		migrations, err := Migrations()
+		assert.NoError(t, err)
+		assert.NotEmpty(t, migrations)
+		for i, m := range migrations {
+			assert.Equal(t, i+1, m.Version)
+			assert.NotEmpty(t, m.Up)
+			assert.NotEmpty(t, m.Down)
+		}
+		assert.Equal(t, "create_jeopardy_clues", migrations[0].Name)
+	})
+
+	t.Run("test invalid migrations", func(t *testing.T) {
+		file := &fstest.MapFile{Data: []byte("select 1;")}
+		for name, fsys := range map[string]fstest.MapFS{
+			"bad name":     {"m/0001_first.sql": file},
+			"missing down": {"m/0001_first.up.sql": file},
+			"gap":          {"m/0001_first.up.sql": file, "m/0001_first.down.sql": file, "m/0003_third.up.sql": file, "m/0003_third.down.sql": file},
+			"renamed":      {"m/0001_first.up.sql": file, "m/0001_other.down.sql": file},
+		} {
+			_, err := loadMigrations(fsys, "m")
+			assert.Error(t, err, name)
+		}
+	})
+
+	t.Run("test planning migrations", func(t *testing.T) {
+		migrations := []Migration{{Version: 1, Name: "one"}, {Version: 2, Name: "two"}, {Version: 3, Name: "three"}}
+
+		steps, err := planMigrations(migrations, 1, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, []migrationStep{{Migration: migrations[1]}, {Migration: migrations[2]}}, steps)
+
+		steps, err = planMigrations(migrations, 3, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []migrationStep{{Migration: migrations[2], down: true}, {Migration: migrations[1], down: true}}, steps)
+
+		steps, err = planMigrations(migrations, 2, 2)
+		assert.NoError(t, err)
+		assert.Empty(t, steps)
+
+		_, err = planMigrations(migrations, 0, 4)
+		assert.Error(t, err)
+		_, err = planMigrations(migrations, 4, 3)
+		assert.Error(t, err)
+	})
+}
+
+func TestMigrate(t *testing.T) {
+	if os.Getenv("DATABASE_URL") == "" {
+		t.Skip("DATABASE_URL is not set")
+	}
+	t.Run("test migrating to the latest version", func(t *testing.T) {
+		ctx := context.Background()
+		jeopardyDB, err := NewJeopardyDB(ctx)
+		if err != nil {
+			t.Fatalf("Error connecting to database: %s", err.Error())
+		}
+		defer jeopardyDB.Close()
+
+		migrations, err := Migrations()
+		assert.NoError(t, err)
+		_, err = jeopardyDB.Migrate(ctx, len(migrations))
+		assert.NoError(t, err)
+		applied, err := jeopardyDB.AppliedMigrations(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, applied, len(migrations))
+	})
+}
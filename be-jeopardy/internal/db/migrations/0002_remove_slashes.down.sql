@@ -0,0 +1 @@
+-- the escaped quotes aren't worth putting back
@@ -0,0 +1,6 @@
+-- the scrapers escaped quotes with backslashes
+update jeopardy_clues
+set category = regexp_replace(category, '\\''+', '''', 'g'),
+answer = regexp_replace(answer, '\\''+', '''', 'g'),
+question = regexp_replace(question, '\\''+', '''', 'g')
+where category like '%\\%' or answer like '%\\%' or question like '%\\%';
@@ -0,0 +1,2 @@
+alter table jeopardy_clues drop column if exists incorrect;
+alter table jeopardy_clues drop column if exists alternatives;
@@ -0,0 +1,7 @@
+alter table jeopardy_clues add column if not exists alternatives text[];
+alter table jeopardy_clues add column if not exists incorrect text[];
+
+-- the answer matcher handles articles, brackets and accents, so each clue
+-- only needs its own correct response to start with
+update jeopardy_clues set alternatives = array[question] where alternatives is null;
+update jeopardy_clues set incorrect = '{}' where incorrect is null;
@@ -0,0 +1,5 @@
+insert into jeopardy_clues (round, clue_value, daily_double_value, category, comments, answer, question, air_date, notes, alternatives, incorrect)
+select round, clue_value, daily_double_value, category, comments, answer, question, air_date, notes, alternatives, incorrect
+from deprecated_clues;
+
+drop table if exists deprecated_clues;
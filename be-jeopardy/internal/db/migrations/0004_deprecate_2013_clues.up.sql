@@ -0,0 +1,20 @@
+create table if not exists deprecated_clues (
+	round int,
+	clue_value int,
+	daily_double_value int,
+	category text,
+	comments text,
+	answer text,
+	question text,
+	air_date text,
+	notes text,
+	alternatives text[],
+	incorrect text[]
+);
+
+insert into deprecated_clues (round, clue_value, daily_double_value, category, comments, answer, question, air_date, notes, alternatives, incorrect)
+select round, clue_value, daily_double_value, category, comments, answer, question, air_date, notes, alternatives, incorrect
+from jeopardy_clues
+where air_date like '2013%';
+
+delete from jeopardy_clues where air_date like '2013%';
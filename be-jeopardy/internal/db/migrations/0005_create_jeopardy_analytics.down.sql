@@ -0,0 +1 @@
+drop table if exists jeopardy_analytics;
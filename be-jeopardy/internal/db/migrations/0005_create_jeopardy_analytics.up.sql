@@ -9,4 +9,4 @@ create table if not exists jeopardy_analytics (
     second_round_ans int,
     second_round_corr int,
     second_round_score double precision
-);
\ No newline at end of file
+);
@@ -7,4 +7,4 @@ create table if not exists player_games (
     correct int,
     max_points int,
     max_correct int
-);
\ No newline at end of file
+);
@@ -0,0 +1,5 @@
+create table if not exists game_snapshots (
+    name text primary key,
+    snapshot jsonb,
+    updated_at timestamptz default now()
+);
@@ -0,0 +1 @@
+drop table if exists game_events;
@@ -0,0 +1,6 @@
+create table if not exists game_events (
+    game_id text,
+    seq int,
+    event jsonb,
+    primary key (game_id, seq)
+);
@@ -0,0 +1,13 @@
+create table if not exists practice_results (
+    email text,
+    category text,
+    round int,
+    air_date text,
+    clue text,
+    attempts int,
+    correct int,
+    streak int,
+    last_correct boolean,
+    due_at timestamptz,
+    primary key (email, category, round, air_date, clue)
+);
@@ -0,0 +1,8 @@
+create table if not exists daily_results (
+    day text,
+    email text,
+    name text,
+    score int,
+    seconds int,
+    primary key (day, email)
+);
@@ -0,0 +1,13 @@
+create table if not exists pending_alternatives (
+    id serial primary key,
+    alternative text,
+    answer text,
+    clue text,
+    game_id text,
+    answered_by text,
+    disputed_by text[],
+    status text default 'pending',
+    created_at timestamptz default now(),
+    reviewer text,
+    reviewed_at timestamptz
+);
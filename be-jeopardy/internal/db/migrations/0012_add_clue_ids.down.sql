@@ -0,0 +1,2 @@
+alter table pending_alternatives drop column if exists clue_id;
+alter table jeopardy_clues drop column if exists id;
@@ -0,0 +1,10 @@
+-- Gives existing clues a stable id for write-backs to key on, and points
+-- pending alternatives queued before clues had ids at their clue.
+alter table jeopardy_clues add column if not exists id serial primary key;
+
+alter table pending_alternatives add column if not exists clue_id int references jeopardy_clues (id);
+
+update pending_alternatives p
+set clue_id = c.id
+from jeopardy_clues c
+where p.clue_id is null and c.question = p.answer and c.answer = p.clue;
@@ -0,0 +1,10 @@
+create table if not exists custom_boards (
+    id uuid primary key,
+    owner text not null,
+    name text not null,
+    board jsonb not null,
+    created_at timestamptz not null,
+    updated_at timestamptz not null
+);
+
+create index if not exists custom_boards_owner on custom_boards (owner);
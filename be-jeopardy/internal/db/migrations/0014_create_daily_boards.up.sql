@@ -0,0 +1,4 @@
+create table if not exists daily_boards (
+    day text primary key,
+    board jsonb not null
+);
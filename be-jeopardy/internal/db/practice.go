@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+	_ "embed"
+	"time"
+)
+
+// PracticeResult is how a player has done on a clue in solo practice, and
+// when it is next due to be practiced.
+type PracticeResult struct {
+	Email       string    `json:"-"`
+	Category    Category  `json:"category"`
+	Clue        string    `json:"clue"`
+	Attempts    int       `json:"attempts"`
+	Correct     int       `json:"correct"`
+	Streak      int       `json:"streak"`
+	LastCorrect bool      `json:"lastCorrect"`
+	DueAt       time.Time `json:"dueAt"`
+}
+
+//go:embed sql/save_practice_result.sql
+var savePracticeResult string
+
+// SavePracticeResult stores a player's result on a clue, replacing their
+// earlier result on it.
+func (db *JeopardyDB) SavePracticeResult(ctx context.Context, r PracticeResult) error {
+	_, err := db.pool.Exec(ctx, savePracticeResult,
+		r.Email, r.Category.Name, r.Category.Round, r.Category.AirDate, r.Clue,
+		r.Attempts, r.Correct, r.Streak, r.LastCorrect, r.DueAt,
+	)
+	return err
+}
+
+//go:embed sql/get_practice_results.sql
+var getPracticeResults string
+
+// GetPracticeResults returns a player's practice results, the first due
+// first.
+func (db *JeopardyDB) GetPracticeResults(ctx context.Context, email string) ([]PracticeResult, error) {
+	rows, err := db.pool.Query(ctx, getPracticeResults, email)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []PracticeResult{}
+	for rows.Next() {
+		r := PracticeResult{Email: email}
+		err := rows.Scan(
+			&r.Category.Name, &r.Category.Round, &r.Category.AirDate, &r.Clue,
+			&r.Attempts, &r.Correct, &r.Streak, &r.LastCorrect, &r.DueAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, r)
+	}
+
+	return results, nil
+}
+
+//go:embed sql/get_random_category.sql
+var getRandomCategory string
+
+func (db *JeopardyDB) GetRandomCategory(ctx context.Context) (Category, error) {
+	var category Category
+	err := db.pool.QueryRow(ctx, getRandomCategory).Scan(&category.Name, &category.Round, &category.AirDate)
+	return category, err
+}
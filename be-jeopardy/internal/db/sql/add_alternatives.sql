@@ -1,3 +1,3 @@
 update jeopardy_clues 
 set alternatives = array_append(alternatives, $1)
-where question = $2;
\ No newline at end of file
+where id = $2;
\ No newline at end of file
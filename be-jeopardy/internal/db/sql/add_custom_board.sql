@@ -0,0 +1,2 @@
+insert into custom_boards (id, owner, name, board, created_at, updated_at)
+values ($1, $2, $3, $4, $5, $5);
@@ -0,0 +1,10 @@
+with added as (
+	insert into daily_boards (day, board)
+	values ($1, $2)
+	on conflict (day) do nothing
+	returning board
+)
+select board from added
+union all
+select board from daily_boards where day = $1
+limit 1;
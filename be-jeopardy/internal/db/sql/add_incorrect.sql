@@ -1,3 +1,3 @@
 update jeopardy_clues 
 set incorrect = array_append(incorrect, $1)
-where answer = $2;
\ No newline at end of file
+where id = $2;
\ No newline at end of file
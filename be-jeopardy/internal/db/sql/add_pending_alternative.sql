@@ -0,0 +1,2 @@
+insert into pending_alternatives (clue_id, alternative, answer, clue, game_id, answered_by, disputed_by)
+values ($1, $2, $3, $4, $5, $6, $7);
@@ -0,0 +1,3 @@
+insert into game_events (game_id, seq, event)
+select $1, $2 + e.ordinality - 1, e.event::jsonb
+from unnest($3::text[]) with ordinality as e(event, ordinality);
@@ -0,0 +1,5 @@
+create table if not exists schema_migrations (
+    version int primary key,
+    name text,
+    applied_at timestamptz default now()
+);
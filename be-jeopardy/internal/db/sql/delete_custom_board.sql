@@ -0,0 +1,2 @@
+delete from custom_boards
+where id = $1 and owner = $2;
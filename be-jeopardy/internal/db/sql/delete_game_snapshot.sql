@@ -0,0 +1 @@
+delete from game_snapshots where name = $1;
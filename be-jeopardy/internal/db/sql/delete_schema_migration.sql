@@ -0,0 +1,2 @@
+delete from schema_migrations
+where version = $1;
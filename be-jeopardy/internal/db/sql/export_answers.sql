@@ -0,0 +1,5 @@
+select question, alternatives, incorrect
+from jeopardy_clues
+where cardinality(alternatives) > 1 or cardinality(incorrect) > 0
+order by random()
+limit $1;
@@ -0,0 +1,30 @@
+-- how often people answered the clues of each round and value in the games
+-- saved to jeopardy_analytics, and how often they were right
+with rounds as (
+    select first_round as categories from jeopardy_analytics where jsonb_typeof(first_round) = 'array'
+    union all
+    select second_round from jeopardy_analytics where jsonb_typeof(second_round) = 'array'
+),
+questions as (
+    select (q->>'clueId')::int as clue_id,
+        case when jsonb_typeof(q->'answers') = 'array' then q->'answers' else '[]'::jsonb end as answers
+    from rounds,
+        jsonb_array_elements(categories) c,
+        jsonb_array_elements(case when jsonb_typeof(c->'question') = 'array' then c->'question' else '[]'::jsonb end) q
+),
+clues as (
+    select clue_id,
+        (select count(*) from jsonb_array_elements(answers) a where not (a->>'bot')::boolean) as answers,
+        (select count(*) from jsonb_array_elements(answers) a where not (a->>'bot')::boolean and (a->>'correct')::boolean) as correct
+    from questions
+)
+select j.round, j.clue_value,
+    count(*) as shown,
+    count(*) filter (where clues.answers > 0) as answered,
+    coalesce(sum(clues.answers), 0)::int as answers,
+    coalesce(sum(clues.correct), 0)::int as correct
+from clues
+join jeopardy_clues j on j.id = clues.clue_id
+where j.round in (1, 2)
+group by j.round, j.clue_value
+order by j.round asc, j.clue_value asc;
@@ -0,0 +1,3 @@
+select id::text, owner, name, board, created_at, updated_at
+from custom_boards
+where id = $1;
@@ -0,0 +1,4 @@
+select id::text, owner, name, board, created_at, updated_at
+from custom_boards
+where owner = $1
+order by updated_at desc;
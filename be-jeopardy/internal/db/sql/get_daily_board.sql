@@ -0,0 +1,3 @@
+select board
+from daily_boards
+where day = $1;
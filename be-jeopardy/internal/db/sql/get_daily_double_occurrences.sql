@@ -0,0 +1,5 @@
+select round, clue_value, count(*)
+from jeopardy_clues
+where daily_double_value > 0 and round in (1, 2)
+group by round, clue_value
+order by round asc, clue_value asc;
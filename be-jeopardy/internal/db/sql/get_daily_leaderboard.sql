@@ -0,0 +1,5 @@
+select day, name, score, seconds
+from daily_results
+where day = $1 and score is not null
+order by score desc, seconds asc
+limit $2;
@@ -0,0 +1,51 @@
+with r1_categories as (
+	select category, air_date, round
+	from jeopardy_clues
+	group by category, air_date, round
+	having count(*) = 5 and round = 1
+	order by md5(concat(category, air_date, $4::text)) asc
+	limit $1
+),
+r2_categories as (
+	select category, air_date, round
+	from jeopardy_clues
+	group by category, air_date, round
+	having count(*) = 5 and round = 2
+	order by md5(concat(category, air_date, $4::text)) asc
+	limit $2
+),
+round1 as (
+	select id, round, clue_value, category, comments, answer, question, alternatives, incorrect
+	from (
+		select jc.id, jc.round, jc.clue_value, jc.category, jc.comments, jc.answer, jc.question, jc.alternatives, jc.incorrect,
+			row_number() over (partition by jc.category, jc.air_date order by jc.clue_value asc) as clue_num
+		from jeopardy_clues as jc 
+		join r1_categories as r1
+		on jc.category = r1.category and jc.air_date = r1.air_date and jc.round = r1.round
+	) as clues
+	where clue_num <= $3
+),
+round2 as (
+	select id, round, clue_value, category, comments, answer, question, alternatives, incorrect
+	from (
+		select jc.id, jc.round, jc.clue_value, jc.category, jc.comments, jc.answer, jc.question, jc.alternatives, jc.incorrect,
+			row_number() over (partition by jc.category, jc.air_date order by jc.clue_value asc) as clue_num
+		from jeopardy_clues as jc 
+		join r2_categories as r2
+		on jc.category = r2.category and jc.air_date = r2.air_date and jc.round = r2.round
+	) as clues
+	where clue_num <= $3
+),
+final_jeopardy as (
+	select jc.id, jc.round, jc.clue_value, jc.category, jc.comments, jc.answer, jc.question, jc.alternatives, jc.incorrect
+	from jeopardy_clues as jc
+	where round = 3
+	order by md5(concat(jc.answer, jc.air_date, $4::text)) asc
+	limit 1
+)
+select * from round1
+union 
+select * from round2
+union
+select * from final_jeopardy
+order by round asc, category asc, clue_value asc;
\ No newline at end of file
@@ -0,0 +1,3 @@
+select day, name, coalesce(score, 0), coalesce(seconds, 0), score is not null
+from daily_results
+where day = $1 and email = $2;
@@ -0,0 +1 @@
+select event from game_events where game_id = $1 order by seq;
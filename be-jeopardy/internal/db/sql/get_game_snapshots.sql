@@ -0,0 +1 @@
+select snapshot from game_snapshots order by updated_at;
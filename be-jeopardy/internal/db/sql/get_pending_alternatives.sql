@@ -0,0 +1,4 @@
+select id, clue_id, alternative, answer, clue, game_id, answered_by, disputed_by, status, created_at, coalesce(reviewer, ''), reviewed_at
+from pending_alternatives
+where status = $1
+order by created_at, id;
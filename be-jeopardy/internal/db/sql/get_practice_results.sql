@@ -0,0 +1,4 @@
+select category, round, air_date, clue, attempts, correct, streak, last_correct, due_at
+from practice_results
+where email = $1
+order by due_at asc;
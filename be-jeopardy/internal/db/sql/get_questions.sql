@@ -15,19 +15,29 @@ r2_categories as (
 	limit $2
 ),
 round1 as (
-	select jc.round, jc.clue_value, jc.category, jc.comments, jc.answer, jc.question, jc.alternatives
-	from jeopardy_clues as jc 
-	join r1_categories as r1 
-	on jc.category = r1.category and jc.air_date = r1.air_date and jc.round = r1.round
+	select id, round, clue_value, category, comments, answer, question, alternatives, incorrect
+	from (
+		select jc.id, jc.round, jc.clue_value, jc.category, jc.comments, jc.answer, jc.question, jc.alternatives, jc.incorrect,
+			row_number() over (partition by jc.category, jc.air_date order by jc.clue_value asc) as clue_num
+		from jeopardy_clues as jc 
+		join r1_categories as r1
+		on jc.category = r1.category and jc.air_date = r1.air_date and jc.round = r1.round
+	) as clues
+	where clue_num <= $3
 ),
 round2 as (
-	select jc.round, jc.clue_value, jc.category, jc.comments, jc.answer, jc.question, jc.alternatives
-	from jeopardy_clues as jc 
-	join r2_categories as r2
-	on jc.category = r2.category and jc.air_date = r2.air_date and jc.round = r2.round
+	select id, round, clue_value, category, comments, answer, question, alternatives, incorrect
+	from (
+		select jc.id, jc.round, jc.clue_value, jc.category, jc.comments, jc.answer, jc.question, jc.alternatives, jc.incorrect,
+			row_number() over (partition by jc.category, jc.air_date order by jc.clue_value asc) as clue_num
+		from jeopardy_clues as jc 
+		join r2_categories as r2
+		on jc.category = r2.category and jc.air_date = r2.air_date and jc.round = r2.round
+	) as clues
+	where clue_num <= $3
 ),
 final_jeopardy as (
-	select jc.round, jc.clue_value, jc.category, jc.comments, jc.answer, jc.question, jc.alternatives
+	select jc.id, jc.round, jc.clue_value, jc.category, jc.comments, jc.answer, jc.question, jc.alternatives, jc.incorrect
 	from jeopardy_clues as jc
 	where round = 3
 	order by random()
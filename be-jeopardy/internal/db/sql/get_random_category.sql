@@ -0,0 +1,7 @@
+select category, round, air_date
+from jeopardy_clues
+where round in (1, 2)
+group by category, round, air_date
+having count(*) = 5
+order by random()
+limit 1;
@@ -0,0 +1,7 @@
+insert into jeopardy_clues (round, clue_value, daily_double_value, category, comments, answer, question, air_date, notes, alternatives, incorrect)
+select $1::int, $2::int, $3::int, $4::text, $5::text, $6::text, $7::text, $8::text, $9::text, $10::text[], $11::text[]
+where not exists (
+	select 1
+	from jeopardy_clues
+	where round = $1 and category = $4 and air_date = $8 and answer = $6
+);
@@ -0,0 +1,13 @@
+with reviewed as (
+    update pending_alternatives
+    set status = $2, reviewer = $3, reviewed_at = now()
+    where id = $1 and status = 'pending'
+    returning id, clue_id, alternative, answer, clue, game_id, answered_by, disputed_by, status, created_at, reviewer, reviewed_at
+), approved as (
+    update jeopardy_clues c
+    set alternatives = array_append(c.alternatives, r.alternative)
+    from reviewed r
+    where r.status = 'approved' and c.id = r.clue_id
+)
+select id, clue_id, alternative, answer, clue, game_id, answered_by, disputed_by, status, created_at, reviewer, reviewed_at
+from reviewed;
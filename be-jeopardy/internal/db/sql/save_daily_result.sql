@@ -0,0 +1,5 @@
+insert into daily_results (day, email, name, score, seconds)
+values ($1, $2, $3, $4, $5)
+on conflict (day, email) do update
+set name = excluded.name, score = excluded.score, seconds = excluded.seconds
+where daily_results.score is null;
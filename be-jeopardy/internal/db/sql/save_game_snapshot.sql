@@ -0,0 +1,6 @@
+insert into game_snapshots (name, snapshot, updated_at)
+values ($1, $2, now())
+on conflict (name)
+do update set
+snapshot = $2,
+updated_at = now();
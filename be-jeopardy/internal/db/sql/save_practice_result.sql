@@ -0,0 +1,9 @@
+insert into practice_results (email, category, round, air_date, clue, attempts, correct, streak, last_correct, due_at)
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+on conflict (email, category, round, air_date, clue)
+do update set
+attempts = $6,
+correct = $7,
+streak = $8,
+last_correct = $9,
+due_at = $10;
@@ -0,0 +1,3 @@
+insert into daily_results (day, email, name)
+values ($1, $2, $3)
+on conflict (day, email) do nothing;
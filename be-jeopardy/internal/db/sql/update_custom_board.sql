@@ -0,0 +1,4 @@
+update custom_boards
+set name = $3, board = $4, updated_at = $5
+where id = $1 and owner = $2
+returning created_at;
@@ -6,6 +6,8 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
@@ -26,6 +28,22 @@ type (
 	TokenRequest struct {
 		Token string `json:"token,omitempty"`
 	}
+
+	// GameResponse is a Response carrying a snapshot of the game taken on
+	// the game's event loop.
+	GameResponse struct {
+		Code    int             `json:"code"`
+		Token   string          `json:"token,omitempty"`
+		Message string          `json:"message"`
+		Game    json.RawMessage `json:"game,omitempty"`
+	}
+
+	TournamentResponse struct {
+		Code       int             `json:"code"`
+		Token      string          `json:"token,omitempty"`
+		Message    string          `json:"message"`
+		Tournament json.RawMessage `json:"tournament,omitempty"`
+	}
 )
 
 var (
@@ -55,6 +73,11 @@ var (
 			Path:    "/jeopardy/games/:joinCode",
 			Handler: JoinGameByCode,
 		},
+		{
+			Method:  http.MethodPut,
+			Path:    "/jeopardy/games/:joinCode/spectate",
+			Handler: SpectateGame,
+		},
 		{
 			Method:  http.MethodPut,
 			Path:    "/jeopardy/games/bot",
@@ -135,6 +158,116 @@ var (
 			Path:    "/jeopardy/analytics/leaderboard",
 			Handler: GetLeaderboard,
 		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/jeopardy/games/:id/replay",
+			Handler: GetGameReplay,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/jeopardy/games/board",
+			Handler: ExportBoard,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/jeopardy/games/wager-advice",
+			Handler: GetWagerAdvice,
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/jeopardy/tournaments",
+			Handler: CreateTournament,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/jeopardy/tournaments",
+			Handler: GetTournaments,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/jeopardy/tournaments/:id",
+			Handler: GetTournament,
+		},
+		{
+			Method:  http.MethodPut,
+			Path:    "/jeopardy/tournaments/:id",
+			Handler: RegisterTournamentPlayer,
+		},
+		{
+			Method:  http.MethodPut,
+			Path:    "/jeopardy/tournaments/:id/start",
+			Handler: StartTournament,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/jeopardy/alternatives",
+			Handler: GetAlternatives,
+		},
+		{
+			Method:  http.MethodPut,
+			Path:    "/jeopardy/alternatives/:id",
+			Handler: ReviewAlternative,
+		},
+		{
+			Method:  http.MethodPut,
+			Path:    "/jeopardy/daily",
+			Handler: PlayDailyChallenge,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/jeopardy/daily/leaderboard",
+			Handler: GetDailyLeaderboard,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/jeopardy/daily/:day",
+			Handler: GetDailyChallenge,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/jeopardy/practice",
+			Handler: GetPracticeClue,
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/jeopardy/practice",
+			Handler: AnswerPracticeClue,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/jeopardy/practice/stats",
+			Handler: GetPracticeStats,
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/jeopardy/boards",
+			Handler: CreateCustomBoard,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/jeopardy/boards",
+			Handler: GetCustomBoards,
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/jeopardy/boards/validate",
+			Handler: ValidateCustomBoard,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/jeopardy/boards/:id",
+			Handler: GetCustomBoard,
+		},
+		{
+			Method:  http.MethodPut,
+			Path:    "/jeopardy/boards/:id",
+			Handler: UpdateCustomBoard,
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/jeopardy/boards/:id",
+			Handler: DeleteCustomBoard,
+		},
 	}
 
 	upgrader = websocket.Upgrader{
@@ -152,6 +285,7 @@ const (
 	ErrJoiningReactionsMsg = "Uh oh, something went wrong when joining the game reactions."
 	ErrInvalidAuthCredMsg  = "Uh oh, something went wrong: Invalid authentication credentials"
 	ErrMalformedReqMsg     = "Uh oh, something went wrong: Malformed request"
+	ErrNotAdminMsg         = "Only admins can review alternatives"
 )
 
 func GetPlayerGame(c *gin.Context) {
@@ -172,11 +306,7 @@ func GetPlayerGame(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, jeopardy.Response{
-		Code:    http.StatusOK,
-		Message: "Authorized to get player game",
-		Game:    game,
-	})
+	respondWithGame(c, game, "", "Authorized to get player game")
 }
 
 func CreatePrivateGame(c *gin.Context) {
@@ -207,12 +337,7 @@ func CreatePrivateGame(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, jeopardy.Response{
-		Code:    http.StatusOK,
-		Token:   jwt,
-		Message: "Authorized to create private game",
-		Game:    game,
-	})
+	respondWithGame(c, game, jwt, "Authorized to create private game")
 }
 
 func JoinGameByCode(c *gin.Context) {
@@ -241,12 +366,36 @@ func JoinGameByCode(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, jeopardy.Response{
-		Code:    http.StatusOK,
-		Token:   jwt,
-		Message: "Authorized to join game by code",
-		Game:    game,
-	})
+	respondWithGame(c, game, jwt, "Authorized to join game by code")
+}
+
+func SpectateGame(c *gin.Context) {
+	log.Infof("Received spectate game request")
+
+	var req jeopardy.GameRequest
+	if err := parseBody(c.Request.Body, &req); err != nil {
+		log.Errorf("Error parsing spectate request: %s", err.Error())
+		respondWithError(c, http.StatusBadRequest, ErrMalformedReqMsg)
+		return
+	}
+
+	joinCode := c.Param("joinCode")
+
+	game, spectatorId, err := jeopardy.SpectateGame(req, joinCode)
+	if err != nil {
+		log.Errorf("Error spectating game: %s", err.Error())
+		respondWithError(c, http.StatusBadRequest, "Unable to spectate game: %s", err.Error())
+		return
+	}
+
+	jwt, err := auth.GenerateJWT(spectatorId)
+	if err != nil {
+		log.Errorf(ErrGeneratingJWTMsg, err.Error())
+		respondWithError(c, http.StatusInternalServerError, UnexpectedServerErrMsg)
+		return
+	}
+
+	respondWithGame(c, game, jwt, "Authorized to spectate game")
 }
 
 func JoinPublicGame(c *gin.Context) {
@@ -277,12 +426,7 @@ func JoinPublicGame(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, jeopardy.Response{
-		Code:    http.StatusOK,
-		Token:   jwt,
-		Message: "Authorized to join public game",
-		Game:    game,
-	})
+	respondWithGame(c, game, jwt, "Authorized to join public game")
 }
 
 func JoinGameChat(c *gin.Context) {
@@ -525,6 +669,54 @@ func GetUserByName(c *gin.Context) {
 	c.JSON(http.StatusOK, user)
 }
 
+func GetGameReplay(c *gin.Context) {
+	log.Infof("Received request to get game replay")
+
+	gameId := c.Param("id")
+	events, err := jeopardy.GetGameReplay(c, gameId)
+	if err != nil {
+		respondWithError(c, http.StatusNotFound, "Unable to get game replay: %s", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
+
+func ExportBoard(c *gin.Context) {
+	log.Infof("Received export board request")
+
+	token := c.Request.Header.Get("Access-Token")
+	playerId, err := auth.GetJWTSubject(token)
+	if err != nil {
+		log.Errorf(ErrGettingPlayerIdMsg, err.Error())
+		respondWithError(c, http.StatusForbidden, ErrInvalidAuthCredMsg)
+		return
+	}
+
+	board, err := jeopardy.ExportBoard(playerId)
+	if err != nil {
+		log.Errorf("Error exporting board: %s", err.Error())
+		respondWithError(c, http.StatusBadRequest, "Unable to export board: %s", err.Error())
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", board.Name+".json"))
+	c.JSON(http.StatusOK, board)
+}
+
+func GetWagerAdvice(c *gin.Context) {
+	log.Infof("Received request for wager advice")
+
+	advice, err := jeopardy.GetWagerAdvice(c.Query("round"), c.Query("score"), c.Query("opponents"), c.Query("roundMax"), c.Query("boardLeft"))
+	if err != nil {
+		log.Errorf("Error getting wager advice: %s", err.Error())
+		respondWithError(c, http.StatusBadRequest, "Unable to get wager advice: %s", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, advice)
+}
+
 func SearchCategories(c *gin.Context) {
 	category := c.Query("category")
 	rounds := c.Query("rounds")
@@ -557,6 +749,406 @@ func StartGame(c *gin.Context) {
 	}
 }
 
+func CreateTournament(c *gin.Context) {
+	log.Infof("Received create tournament request")
+
+	var req jeopardy.TournamentRequest
+	if err := parseBody(c.Request.Body, &req); err != nil {
+		log.Errorf("Error parsing create tournament request: %s", err.Error())
+		respondWithError(c, http.StatusBadRequest, ErrMalformedReqMsg)
+		return
+	}
+
+	tournament, organizerId, err := jeopardy.CreateTournament(req)
+	if err != nil {
+		log.Errorf("Error creating tournament: %s", err.Error())
+		respondWithError(c, http.StatusBadRequest, "Unable to create tournament: %s", err.Error())
+		return
+	}
+
+	jwt, err := auth.GenerateJWT(organizerId)
+	if err != nil {
+		log.Errorf(ErrGeneratingJWTMsg, err.Error())
+		respondWithError(c, http.StatusInternalServerError, UnexpectedServerErrMsg)
+		return
+	}
+
+	respondWithTournament(c, tournament, jwt, "Created tournament")
+}
+
+func GetTournaments(c *gin.Context) {
+	log.Infof("Received request to get tournaments")
+	c.JSON(http.StatusOK, jeopardy.GetTournaments())
+}
+
+func GetTournament(c *gin.Context) {
+	log.Infof("Received request to get tournament")
+
+	tournament, err := jeopardy.GetTournament(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusNotFound, "Unable to get tournament: %s", err.Error())
+		return
+	}
+
+	respondWithTournament(c, tournament, "", "Got tournament")
+}
+
+func RegisterTournamentPlayer(c *gin.Context) {
+	log.Infof("Received tournament registration request")
+
+	var req jeopardy.GameRequest
+	if err := parseBody(c.Request.Body, &req); err != nil {
+		log.Errorf("Error parsing registration request: %s", err.Error())
+		respondWithError(c, http.StatusBadRequest, ErrMalformedReqMsg)
+		return
+	}
+
+	tournamentId := c.Param("id")
+	playerId, err := jeopardy.RegisterTournamentPlayer(tournamentId, req)
+	if err != nil {
+		log.Errorf("Error registering for tournament: %s", err.Error())
+		respondWithError(c, http.StatusBadRequest, "Unable to register for tournament: %s", err.Error())
+		return
+	}
+
+	jwt, err := auth.GenerateJWT(playerId)
+	if err != nil {
+		log.Errorf(ErrGeneratingJWTMsg, err.Error())
+		respondWithError(c, http.StatusInternalServerError, UnexpectedServerErrMsg)
+		return
+	}
+
+	tournament, err := jeopardy.GetTournament(tournamentId)
+	if err != nil {
+		respondWithError(c, http.StatusNotFound, "Unable to get tournament: %s", err.Error())
+		return
+	}
+
+	respondWithTournament(c, tournament, jwt, "Registered for tournament")
+}
+
+func StartTournament(c *gin.Context) {
+	log.Infof("Received request to start tournament")
+
+	token := c.Request.Header.Get("Access-Token")
+	organizerId, err := auth.GetJWTSubject(token)
+	if err != nil {
+		log.Errorf(ErrGettingPlayerIdMsg, err.Error())
+		respondWithError(c, http.StatusForbidden, ErrInvalidAuthCredMsg)
+		return
+	}
+
+	tournamentId := c.Param("id")
+	if err = jeopardy.StartTournament(c, tournamentId, organizerId); err != nil {
+		log.Errorf("Error starting tournament: %s", err.Error())
+		respondWithError(c, http.StatusBadRequest, "Unable to start tournament: %s", err.Error())
+		return
+	}
+
+	tournament, err := jeopardy.GetTournament(tournamentId)
+	if err != nil {
+		respondWithError(c, http.StatusNotFound, "Unable to get tournament: %s", err.Error())
+		return
+	}
+
+	respondWithTournament(c, tournament, "", "Started tournament")
+}
+
+func GetAlternatives(c *gin.Context) {
+	log.Infof("Received request to get alternatives")
+
+	if !auth.IsAdmin(c.Request.Header.Get("Admin-Token")) {
+		respondWithError(c, http.StatusUnauthorized, ErrNotAdminMsg)
+		return
+	}
+
+	alts, err := jeopardy.GetAlternatives(c, c.Query("status"))
+	if err != nil {
+		log.Errorf("Error getting alternatives: %s", err.Error())
+		respondWithError(c, http.StatusBadRequest, "Unable to get alternatives: %s", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, alts)
+}
+
+func ReviewAlternative(c *gin.Context) {
+	log.Infof("Received request to review alternative")
+
+	if !auth.IsAdmin(c.Request.Header.Get("Admin-Token")) {
+		respondWithError(c, http.StatusUnauthorized, ErrNotAdminMsg)
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid alternative id %s", c.Param("id"))
+		return
+	}
+
+	var review jeopardy.AlternativeReview
+	if err := parseBody(c.Request.Body, &review); err != nil {
+		log.Errorf("Error parsing review request: %s", err.Error())
+		respondWithError(c, http.StatusBadRequest, ErrMalformedReqMsg)
+		return
+	}
+
+	alt, err := jeopardy.ReviewAlternative(c, id, review)
+	if err != nil {
+		log.Errorf("Error reviewing alternative: %s", err.Error())
+		respondWithError(c, http.StatusBadRequest, "Unable to review alternative: %s", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, alt)
+}
+
+func PlayDailyChallenge(c *gin.Context) {
+	log.Infof("Received daily challenge request")
+
+	var req jeopardy.GameRequest
+	if err := parseBody(c.Request.Body, &req); err != nil {
+		log.Errorf("Error parsing daily challenge request: %s", err.Error())
+		respondWithError(c, http.StatusBadRequest, ErrMalformedReqMsg)
+		return
+	}
+	email, ok := userEmail(c)
+	if !ok {
+		return
+	}
+	req.PlayerEmail = email
+
+	game, playerId, err, code := jeopardy.PlayDailyChallenge(c, req)
+	if err != nil {
+		log.Errorf("Error creating daily challenge: %s", err.Error())
+		if code == socket.BadRequest {
+			respondWithError(c, http.StatusBadRequest, "Unable to play daily challenge: %s", err.Error())
+		} else {
+			respondWithError(c, http.StatusInternalServerError, UnexpectedServerErrMsg)
+		}
+		return
+	}
+
+	jwt, err := auth.GenerateJWT(playerId)
+	if err != nil {
+		log.Errorf(ErrGeneratingJWTMsg, err.Error())
+		respondWithError(c, http.StatusInternalServerError, UnexpectedServerErrMsg)
+		return
+	}
+
+	respondWithGame(c, game, jwt, "Authorized to play daily challenge")
+}
+
+func GetDailyLeaderboard(c *gin.Context) {
+	log.Infof("Received request to get daily leaderboard")
+
+	leaderboard, err := jeopardy.GetDailyLeaderboard(c, c.Query("day"))
+	if err != nil {
+		log.Errorf("Error getting daily leaderboard: %s", err.Error())
+		respondWithError(c, http.StatusBadRequest, "Unable to get daily leaderboard: %s", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, leaderboard)
+}
+
+func GetDailyChallenge(c *gin.Context) {
+	log.Infof("Received request to get daily challenge")
+
+	email, ok := optionalUserEmail(c)
+	if !ok {
+		return
+	}
+	challenge, err := jeopardy.GetDailyChallenge(c, c.Param("day"), email)
+	if err != nil {
+		log.Errorf("Error getting daily challenge: %s", err.Error())
+		respondWithError(c, http.StatusBadRequest, "Unable to get daily challenge: %s", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, challenge)
+}
+
+func GetPracticeClue(c *gin.Context) {
+	log.Infof("Received request to get practice clue")
+
+	email, ok := userEmail(c)
+	if !ok {
+		return
+	}
+	clue, err := jeopardy.NextPracticeClue(c, email)
+	if err != nil {
+		log.Errorf("Error getting practice clue: %s", err.Error())
+		respondWithError(c, http.StatusBadRequest, "Unable to get practice clue: %s", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, clue)
+}
+
+func AnswerPracticeClue(c *gin.Context) {
+	log.Infof("Received practice answer")
+
+	var req jeopardy.PracticeAnswer
+	if err := parseBody(c.Request.Body, &req); err != nil {
+		log.Errorf("Error parsing practice answer: %s", err.Error())
+		respondWithError(c, http.StatusBadRequest, ErrMalformedReqMsg)
+		return
+	}
+	email, ok := userEmail(c)
+	if !ok {
+		return
+	}
+	req.Email = email
+
+	feedback, err := jeopardy.AnswerPracticeClue(c, req)
+	if err != nil {
+		log.Errorf("Error answering practice clue: %s", err.Error())
+		respondWithError(c, http.StatusBadRequest, "Unable to answer practice clue: %s", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, feedback)
+}
+
+func GetPracticeStats(c *gin.Context) {
+	log.Infof("Received request to get practice stats")
+
+	email, ok := userEmail(c)
+	if !ok {
+		return
+	}
+	stats, err := jeopardy.GetPracticeStats(c, email)
+	if err != nil {
+		log.Errorf("Error getting practice stats: %s", err.Error())
+		respondWithError(c, http.StatusBadRequest, "Unable to get practice stats: %s", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+func CreateCustomBoard(c *gin.Context) {
+	log.Infof("Received request to create custom board")
+
+	var req jeopardy.CustomBoardRequest
+	if err := parseBody(c.Request.Body, &req); err != nil {
+		log.Errorf("Error parsing custom board request: %s", err.Error())
+		respondWithError(c, http.StatusBadRequest, ErrMalformedReqMsg)
+		return
+	}
+
+	email, ok := userEmail(c)
+	if !ok {
+		return
+	}
+	req.Email = email
+
+	board, err := jeopardy.CreateCustomBoard(c, req)
+	if err != nil {
+		log.Errorf("Error creating custom board: %s", err.Error())
+		respondWithError(c, http.StatusBadRequest, "Unable to create board: %s", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, board)
+}
+
+func GetCustomBoards(c *gin.Context) {
+	log.Infof("Received request to get custom boards")
+
+	email, ok := userEmail(c)
+	if !ok {
+		return
+	}
+	boards, err := jeopardy.GetCustomBoards(c, email)
+	if err != nil {
+		log.Errorf("Error getting custom boards: %s", err.Error())
+		respondWithError(c, http.StatusBadRequest, "Unable to get boards: %s", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, boards)
+}
+
+func ValidateCustomBoard(c *gin.Context) {
+	log.Infof("Received request to validate custom board")
+
+	var req jeopardy.CustomBoardRequest
+	if err := parseBody(c.Request.Body, &req); err != nil {
+		log.Errorf("Error parsing custom board request: %s", err.Error())
+		respondWithError(c, http.StatusBadRequest, ErrMalformedReqMsg)
+		return
+	}
+	if _, ok := userEmail(c); !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, jeopardy.ValidateCustomBoard(req.CustomBoard))
+}
+
+func GetCustomBoard(c *gin.Context) {
+	log.Infof("Received request to get custom board")
+
+	email, ok := optionalUserEmail(c)
+	if !ok {
+		return
+	}
+	board, err := jeopardy.GetCustomBoard(c, c.Param("id"), email)
+	if err != nil {
+		log.Errorf("Error getting custom board: %s", err.Error())
+		respondWithError(c, http.StatusBadRequest, "Unable to get board: %s", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, board)
+}
+
+func UpdateCustomBoard(c *gin.Context) {
+	log.Infof("Received request to update custom board")
+
+	var req jeopardy.CustomBoardRequest
+	if err := parseBody(c.Request.Body, &req); err != nil {
+		log.Errorf("Error parsing custom board request: %s", err.Error())
+		respondWithError(c, http.StatusBadRequest, ErrMalformedReqMsg)
+		return
+	}
+
+	email, ok := userEmail(c)
+	if !ok {
+		return
+	}
+	req.Email = email
+
+	board, err := jeopardy.UpdateCustomBoard(c, c.Param("id"), req)
+	if err != nil {
+		log.Errorf("Error updating custom board: %s", err.Error())
+		respondWithError(c, http.StatusBadRequest, "Unable to update board: %s", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, board)
+}
+
+func DeleteCustomBoard(c *gin.Context) {
+	log.Infof("Received request to delete custom board")
+
+	email, ok := userEmail(c)
+	if !ok {
+		return
+	}
+	if err := jeopardy.DeleteCustomBoard(c, c.Param("id"), email); err != nil {
+		log.Errorf("Error deleting custom board: %s", err.Error())
+		respondWithError(c, http.StatusBadRequest, "Unable to delete board: %s", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, jeopardy.Response{
+		Code:    http.StatusOK,
+		Message: "Deleted board",
+	})
+}
+
 func GetPrivateGames(c *gin.Context) {
 	log.Infof("Received request to get private games")
 	games := jeopardy.GetPrivateGames()
@@ -607,6 +1199,58 @@ func closeConnWithMsg(conn *websocket.Conn, code int, msg string, args ...any) {
 	_ = conn.Close()
 }
 
+func respondWithGame(c *gin.Context, game *jeopardy.Game, token, msg string) {
+	snapshot, err := game.Snapshot()
+	if err != nil {
+		log.Errorf("Error getting game snapshot: %s", err.Error())
+		respondWithError(c, http.StatusInternalServerError, UnexpectedServerErrMsg)
+		return
+	}
+	c.JSON(http.StatusOK, GameResponse{
+		Code:    http.StatusOK,
+		Token:   token,
+		Message: msg,
+		Game:    snapshot,
+	})
+}
+
+func respondWithTournament(c *gin.Context, tournament *jeopardy.Tournament, token, msg string) {
+	snapshot, err := tournament.Snapshot()
+	if err != nil {
+		log.Errorf("Error getting tournament snapshot: %s", err.Error())
+		respondWithError(c, http.StatusInternalServerError, UnexpectedServerErrMsg)
+		return
+	}
+	c.JSON(http.StatusOK, TournamentResponse{
+		Code:       http.StatusOK,
+		Token:      token,
+		Message:    msg,
+		Tournament: snapshot,
+	})
+}
+
 func respondWithError(c *gin.Context, code int, msg string, args ...any) {
 	c.JSON(code, jeopardy.Response{Code: code, Message: fmt.Sprintf(msg, args...)})
 }
+
+// userEmail is the email of the user signed in with the Supabase session
+// token in the request's Authorization header.
+func userEmail(c *gin.Context) (string, bool) {
+	token := strings.TrimPrefix(c.Request.Header.Get("Authorization"), "Bearer ")
+	email, err := auth.GetUserEmail(token)
+	if err != nil {
+		log.Errorf("Error verifying session token: %s", err.Error())
+		respondWithError(c, http.StatusForbidden, ErrInvalidAuthCredMsg)
+		return "", false
+	}
+	return email, true
+}
+
+// optionalUserEmail is like userEmail, but empty rather than an error when
+// the request has no session token, for what anyone can see.
+func optionalUserEmail(c *gin.Context) (string, bool) {
+	if c.Request.Header.Get("Authorization") == "" {
+		return "", true
+	}
+	return userEmail(c)
+}
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/db"
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/jeopardy"
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/logic"
+	"github.com/stretchr/testify/assert"
+)
+
+const testJWTSecret = "super-secret-jwt-token-with-at-least-32-characters"
+
+// newTestRouter serves the routes from the memory database.
+func newTestRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	t.Setenv("SUPABASE_JWT_SECRET", testJWTSecret)
+	memoryDB, err := db.NewMemoryDBFromFile("../db/testdata/clues.json")
+	if err != nil {
+		t.Fatalf("Error loading test clues: %s", err.Error())
+	}
+	jeopardy.UseMemoryDB(memoryDB)
+	logic.SetUserDB(memoryDB)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	for _, route := range Routes {
+		router.Handle(route.Method, route.Path, route.Handler)
+	}
+	return router
+}
+
+// sessionToken signs a session token the way Supabase does for a signed in
+// user.
+func sessionToken(t *testing.T, email, secret string, exp time.Time) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":   uuid.NewString(),
+		"email": email,
+		"aud":   "authenticated",
+		"role":  "authenticated",
+		"exp":   exp.Unix(),
+	}).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("Error signing session token: %s", err.Error())
+	}
+	return token
+}
+
+// request sends body as JSON with the session token, if there is one, and
+// decodes the response into v.
+func request(t *testing.T, router *gin.Engine, method, path, token string, body, v any) int {
+	t.Helper()
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("Error encoding request: %s", err.Error())
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(data))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if v != nil {
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), v), resp.Body.String())
+	}
+	return resp.Code
+}
+
+func testBoard() db.CustomBoard {
+	board := db.CustomBoard{
+		Name:          "Birthday Quiz",
+		FinalJeopardy: db.CustomCategory{Name: "THE BIRTHDAY GIRL", Clues: []db.CustomClue{{Clue: "She turns 30 today", Answer: "Alice"}}},
+	}
+	for _, name := range []string{"FAMILY", "FRIENDS"} {
+		category := db.CustomCategory{Name: name}
+		for _, clue := range []string{"one", "two", "three", "four", "five"} {
+			category.Clues = append(category.Clues, db.CustomClue{Clue: name + " clue " + clue, Answer: clue})
+		}
+		board.FirstRound = append(board.FirstRound, category)
+	}
+	return board
+}
+
+func TestCustomBoards(t *testing.T) {
+	router := newTestRouter(t)
+	hour := time.Now().Add(time.Hour)
+	alice := sessionToken(t, "alice@example.com", testJWTSecret, hour)
+	bob := sessionToken(t, "bob@example.com", testJWTSecret, hour)
+
+	t.Run("test boards belong to the signed in user", func(t *testing.T) {
+		var board db.CustomBoard
+		assert.Equal(t, http.StatusOK, request(t, router, http.MethodPost, "/jeopardy/boards", alice, testBoard(), &board))
+		assert.NotEmpty(t, board.Id)
+
+		var boards []db.CustomBoard
+		assert.Equal(t, http.StatusOK, request(t, router, http.MethodGet, "/jeopardy/boards", alice, nil, &boards))
+		assert.Len(t, boards, 1)
+		assert.Equal(t, http.StatusOK, request(t, router, http.MethodGet, "/jeopardy/boards?email=alice@example.com", bob, nil, &boards))
+		assert.Empty(t, boards)
+
+		var got db.CustomBoard
+		assert.Equal(t, http.StatusOK, request(t, router, http.MethodGet, "/jeopardy/boards/"+board.Id, alice, nil, &got))
+		assert.Equal(t, "one", got.FirstRound[0].Clues[0].Answer)
+		for _, token := range []string{bob, ""} {
+			var got db.CustomBoard
+			assert.Equal(t, http.StatusOK, request(t, router, http.MethodGet, "/jeopardy/boards/"+board.Id+"?email=alice@example.com", token, nil, &got))
+			assert.Empty(t, got.FirstRound[0].Clues[0].Answer)
+		}
+
+		assert.Equal(t, http.StatusBadRequest, request(t, router, http.MethodDelete, "/jeopardy/boards/"+board.Id, bob, nil, nil))
+		assert.Equal(t, http.StatusOK, request(t, router, http.MethodDelete, "/jeopardy/boards/"+board.Id, alice, nil, nil))
+	})
+
+	t.Run("test board requests need a valid session token", func(t *testing.T) {
+		for name, token := range map[string]string{
+			"missing":      "",
+			"wrong secret": sessionToken(t, "alice@example.com", "not-the-secret", hour),
+			"expired":      sessionToken(t, "alice@example.com", testJWTSecret, time.Now().Add(-time.Minute)),
+			"no email":     sessionToken(t, "", testJWTSecret, hour),
+		} {
+			var resp jeopardy.Response
+			assert.Equal(t, http.StatusForbidden, request(t, router, http.MethodPost, "/jeopardy/boards", token, testBoard(), &resp), name)
+			assert.Equal(t, ErrInvalidAuthCredMsg, resp.Message, name)
+			assert.Equal(t, http.StatusForbidden, request(t, router, http.MethodGet, "/jeopardy/boards?email=alice@example.com", token, nil, nil), name)
+			assert.Equal(t, http.StatusForbidden, request(t, router, http.MethodPost, "/jeopardy/boards/validate", token, testBoard(), nil), name)
+		}
+		assert.Equal(t, http.StatusForbidden, request(t, router, http.MethodGet, "/jeopardy/boards/"+uuid.NewString(), "not-a-token", nil, nil))
+	})
+}
+
+func TestPractice(t *testing.T) {
+	router := newTestRouter(t)
+	alice := sessionToken(t, "alice@example.com", testJWTSecret, time.Now().Add(time.Hour))
+
+	t.Run("test answering a practice clue with no game running", func(t *testing.T) {
+		assert.Empty(t, jeopardy.GetPublicGames())
+		assert.Empty(t, jeopardy.GetPrivateGames())
+
+		var clue jeopardy.PracticeClue
+		assert.Equal(t, http.StatusOK, request(t, router, http.MethodGet, "/jeopardy/practice", alice, nil, &clue))
+		assert.NotEmpty(t, clue.Clue)
+
+		var feedback jeopardy.PracticeFeedback
+		answer := jeopardy.PracticeAnswer{Category: clue.Category, Clue: clue.Clue, Answer: "what is a wrong answer"}
+		assert.Equal(t, http.StatusOK, request(t, router, http.MethodPost, "/jeopardy/practice", alice, answer, &feedback))
+		assert.False(t, feedback.Correct)
+		assert.NotEmpty(t, feedback.Answer)
+		assert.Equal(t, 1, feedback.Result.Attempts)
+
+		var stats jeopardy.PracticeStats
+		assert.Equal(t, http.StatusOK, request(t, router, http.MethodGet, "/jeopardy/practice/stats", alice, nil, &stats))
+		assert.Equal(t, 1, stats.Clues)
+	})
+
+	t.Run("test practice needs a valid session token", func(t *testing.T) {
+		bob := sessionToken(t, "bob@example.com", "not-the-secret", time.Now().Add(time.Hour))
+		for _, token := range []string{"", bob} {
+			assert.Equal(t, http.StatusForbidden, request(t, router, http.MethodGet, "/jeopardy/practice?email=alice@example.com", token, nil, nil))
+			assert.Equal(t, http.StatusForbidden, request(t, router, http.MethodPost, "/jeopardy/practice", token, jeopardy.PracticeAnswer{}, nil))
+			assert.Equal(t, http.StatusForbidden, request(t, router, http.MethodGet, "/jeopardy/practice/stats?email=alice@example.com", token, nil, nil))
+		}
+	})
+}
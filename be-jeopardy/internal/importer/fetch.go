@@ -0,0 +1,107 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// JArchiveURL is where seasons and games are downloaded from.
+const JArchiveURL = "https://j-archive.com"
+
+// gameLink matches the links to games on a season page, like
+// showgame.php?game_id=9031.
+var gameLink = regexp.MustCompile(`showgame\.php\?game_id=(\d+)$`)
+
+// Fetch downloads the games of a j-archive season (showseason.php) into dir
+// as game_<id>.html, for Import to read. Games already in dir aren't
+// downloaded again, and pages are requested one at a time, delay apart. It
+// returns how many games it downloaded.
+func Fetch(ctx context.Context, baseURL, season, dir string, delay time.Duration) (int, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return 0, err
+	}
+	page, err := get(ctx, baseURL+"/showseason.php?season="+url.QueryEscape(season))
+	if err != nil {
+		return 0, err
+	}
+	doc, err := html.Parse(page)
+	page.Close()
+	if err != nil {
+		return 0, err
+	}
+	links := findAll(doc, func(n *html.Node) bool {
+		return n.Type == html.ElementNode && n.Data == "a" && gameLink.MatchString(attr(n, "href"))
+	})
+	if len(links) == 0 {
+		return 0, fmt.Errorf("no games found for season %s", season)
+	}
+
+	fetched := 0
+	for _, link := range links {
+		gameId := gameLink.FindStringSubmatch(attr(link, "href"))[1]
+		file := filepath.Join(dir, "game_"+gameId+".html")
+		if _, err := os.Stat(file); err == nil {
+			continue
+		}
+		if fetched > 0 {
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return fetched, ctx.Err()
+			}
+		}
+		if err := download(ctx, baseURL+"/showgame.php?game_id="+gameId, file); err != nil {
+			return fetched, err
+		}
+		fetched++
+	}
+	return fetched, nil
+}
+
+// download saves a page to file, writing it under another name first so
+// an interrupted download isn't left behind to be imported.
+func download(ctx context.Context, pageURL, file string) error {
+	page, err := get(ctx, pageURL)
+	if err != nil {
+		return err
+	}
+	defer page.Close()
+	tmp, err := os.CreateTemp(filepath.Dir(file), ".game_*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, page); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), file)
+}
+
+func get(ctx context.Context, pageURL string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("GET %s returned %d", pageURL, resp.StatusCode)
+	}
+	return resp.Body, nil
+}
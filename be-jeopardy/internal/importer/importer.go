@@ -0,0 +1,238 @@
+// Package importer reads clues from saved j-archive game pages and from
+// JSON, TSV and CSV clue dumps, and adds the ones that aren't already in the
+// database.
+package importer
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/db"
+)
+
+type (
+	clueStore interface {
+		InsertClues(ctx context.Context, clues []db.Clue) (int, error)
+	}
+
+	// Rejection is a category that was left out of an import, and why.
+	Rejection struct {
+		Category string `json:"category"`
+		Round    int    `json:"round"`
+		AirDate  string `json:"airDate"`
+		Reason   string `json:"reason"`
+	}
+
+	// Report is what an import read and added.
+	Report struct {
+		Files      int         `json:"files"`
+		Clues      int         `json:"clues"`
+		Duplicates int         `json:"duplicates"`
+		Inserted   int         `json:"inserted"`
+		Rejected   []Rejection `json:"rejected"`
+	}
+
+	clueKey struct {
+		round    int
+		category string
+		airDate  string
+		clue     string
+	}
+)
+
+var (
+	// escapedQuote matches the backslashes the scrapers left before quotes.
+	escapedQuote = regexp.MustCompile(`\\+(['"])`)
+	// hostComment matches category comments like "(Ken: Name the composer.)".
+	hostComment = regexp.MustCompile(`^\([^:()]+: (.*)\)$`)
+)
+
+// Import reads the clues in the given files and directories and adds the
+// valid ones that aren't already in the store.
+func Import(ctx context.Context, store clueStore, paths []string) (Report, error) {
+	clues, report, err := ReadFiles(paths)
+	if err != nil {
+		return report, err
+	}
+	inserted, err := store.InsertClues(ctx, clues)
+	if err != nil {
+		return report, err
+	}
+	report.Inserted = inserted
+	report.Duplicates += len(clues) - inserted
+	return report, nil
+}
+
+// ReadFiles reads the clues in the given files, and the files in the given
+// directories, dropping duplicates and rejecting categories that can't be
+// played. Pages ending in .html or .htm are read as j-archive games, and
+// .json, .tsv and .csv files as clue dumps.
+func ReadFiles(paths []string) ([]db.Clue, Report, error) {
+	report := Report{Rejected: []Rejection{}}
+	files, err := expandPaths(paths)
+	if err != nil {
+		return nil, report, err
+	}
+
+	all := []db.Clue{}
+	for _, file := range files {
+		clues, err := readFile(file)
+		if err != nil {
+			return nil, report, fmt.Errorf("error reading %s: %w", file, err)
+		}
+		all = append(all, clues...)
+		report.Files++
+	}
+
+	seen := map[clueKey]bool{}
+	unique := []db.Clue{}
+	for _, c := range all {
+		c = clean(c)
+		key := clueKey{c.Round, c.Category, c.AirDate, c.Clue}
+		if seen[key] {
+			report.Duplicates++
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, c)
+	}
+
+	clues, rejected := validate(unique)
+	report.Clues = len(clues)
+	report.Rejected = rejected
+	return clues, report, nil
+}
+
+func expandPaths(paths []string) ([]string, error) {
+	files := []string{}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		err = filepath.WalkDir(path, func(file string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && supported(file) {
+				files = append(files, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+func supported(file string) bool {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".html", ".htm", ".json", ".tsv", ".csv":
+		return true
+	}
+	return false
+}
+
+func readFile(file string) ([]db.Clue, error) {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".html", ".htm":
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return ParseGame(f)
+	}
+	return db.LoadClues(file)
+}
+
+// clean unescapes the quotes the scrapers escaped and keeps only what was
+// said of a host's category comment.
+func clean(c db.Clue) db.Clue {
+	for _, s := range []*string{&c.Category, &c.Comments, &c.Clue, &c.Answer} {
+		*s = strings.TrimSpace(escapedQuote.ReplaceAllString(*s, "$1"))
+	}
+	if match := hostComment.FindStringSubmatch(c.Comments); match != nil {
+		c.Comments = match[1]
+	}
+	return c
+}
+
+// validate keeps the categories that can be played: five clues of different
+// values in the first two rounds, or a single Final Jeopardy clue.
+func validate(clues []db.Clue) ([]db.Clue, []Rejection) {
+	groups := map[db.Category][]db.Clue{}
+	keys := []db.Category{}
+	for _, c := range clues {
+		key := db.Category{Name: c.Category, Round: c.Round, AirDate: c.AirDate}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], c)
+	}
+
+	valid := []db.Clue{}
+	rejected := []Rejection{}
+	for _, key := range keys {
+		if reason := invalidCategory(key, groups[key]); reason != "" {
+			rejected = append(rejected, Rejection{Category: key.Name, Round: key.Round, AirDate: key.AirDate, Reason: reason})
+			continue
+		}
+		valid = append(valid, groups[key]...)
+	}
+	return valid, rejected
+}
+
+func invalidCategory(key db.Category, clues []db.Clue) string {
+	if key.Name == "" {
+		return "missing category name"
+	}
+	if _, err := time.Parse(time.DateOnly, key.AirDate); err != nil {
+		return fmt.Sprintf("invalid air date %q", key.AirDate)
+	}
+	for _, c := range clues {
+		if c.Clue == "" || c.Answer == "" {
+			return "clue is missing its text or correct response"
+		}
+	}
+	switch key.Round {
+	case 1, 2:
+		if len(clues) != 5 {
+			return fmt.Sprintf("has %d clues instead of 5", len(clues))
+		}
+		values := []int{}
+		for _, c := range clues {
+			if c.Value <= 0 || slices.Contains(values, c.Value) {
+				return fmt.Sprintf("clue values %v are not 5 different amounts", clueValues(clues))
+			}
+			values = append(values, c.Value)
+		}
+	case 3:
+		if len(clues) != 1 {
+			return fmt.Sprintf("has %d final jeopardy clues instead of 1", len(clues))
+		}
+	default:
+		return fmt.Sprintf("invalid round %d", key.Round)
+	}
+	return ""
+}
+
+func clueValues(clues []db.Clue) []int {
+	values := []int{}
+	for _, c := range clues {
+		values = append(values, c.Value)
+	}
+	return values
+}
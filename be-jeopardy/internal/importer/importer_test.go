@@ -0,0 +1,203 @@
+package importer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func clueFor(t *testing.T, clues []db.Clue, answer string) db.Clue {
+	t.Helper()
+	for _, c := range clues {
+		if c.Answer == answer {
+			return c
+		}
+	}
+	t.Fatalf("No clue with answer %s", answer)
+	return db.Clue{}
+}
+
+func TestParseGame(t *testing.T) {
+	t.Run("test parsing a j-archive game page", func(t *testing.T) {
+		f, err := os.Open("testdata/game_9031.html")
+		if err != nil {
+			t.Fatalf("Error opening game page: %s", err.Error())
+		}
+		defer f.Close()
+
+		clues, err := ParseGame(f)
+		assert.NoError(t, err)
+		assert.Len(t, clues, 25)
+		for _, c := range clues {
+			assert.Equal(t, "2024-01-01", c.AirDate)
+			assert.NotEmpty(t, c.Clue)
+			assert.NotEmpty(t, c.Answer)
+		}
+
+		assert.Equal(t, db.Clue{Round: 1, Value: 200, Category: "STATE CAPITALS", Clue: "It's the capital of Texas", Answer: "Austin", AirDate: "2024-01-01"}, clues[0])
+		dailyDouble := clueFor(t, clues, "Idaho")
+		assert.Equal(t, 600, dailyDouble.Value)
+		assert.Equal(t, 1000, dailyDouble.DailyDoubleValue)
+
+		opera := clueFor(t, clues, "Wolfgang Amadeus Mozart")
+		assert.Equal(t, "OPERA", opera.Category)
+		assert.Equal(t, "(Ken: Name the composer.)", opera.Comments)
+		assert.Equal(t, `(Hear it here.) "The Magic Flute"`, opera.Clue)
+		assert.Equal(t, `"The Ring Cycle", 4 operas in all`, clueFor(t, clues, "Richard Wagner").Clue)
+
+		science := clueFor(t, clues, "gravity")
+		assert.Equal(t, 2, science.Round)
+		assert.Equal(t, "SCIENCE", science.Category)
+		assert.Equal(t, 1600, science.Value)
+		assert.Equal(t, 3000, science.DailyDoubleValue)
+
+		final := clues[24]
+		assert.Equal(t, 3, final.Round)
+		assert.Equal(t, "WORLD LEADERS", final.Category)
+		assert.Equal(t, "Václav Havel", final.Answer)
+		assert.Contains(t, final.Clue, "Czech & Slovak")
+	})
+
+	t.Run("test invalid game pages", func(t *testing.T) {
+		_, err := ParseGame(strings.NewReader(`<html><body><p>Not a game</p></body></html>`))
+		assert.Error(t, err)
+		_, err = ParseGame(strings.NewReader(`<div id="game_title"><h1>Show #1 - Someday</h1></div>`))
+		assert.Error(t, err)
+	})
+
+	t.Run("test board values before values doubled", func(t *testing.T) {
+		assert.Equal(t, 300, boardValue(1, 3, "1999-09-06"))
+		assert.Equal(t, 600, boardValue(1, 3, "2001-11-26"))
+		assert.Equal(t, 2000, boardValue(2, 5, "2024-01-01"))
+	})
+}
+
+func TestReadFiles(t *testing.T) {
+	t.Run("test reading and validating clue files", func(t *testing.T) {
+		clues, report, err := ReadFiles([]string{"testdata"})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, report.Files)
+		assert.Equal(t, 1, report.Duplicates)
+		assert.Equal(t, 26, report.Clues)
+		assert.Len(t, clues, 26)
+		assert.Equal(t, []Rejection{{Category: "POTENT POTABLES", Round: 1, AirDate: "2024-01-01", Reason: "has 4 clues instead of 5"}}, report.Rejected)
+
+		for _, c := range clues[:5] {
+			assert.Equal(t, "BODIES OF WATER", c.Category)
+			assert.Equal(t, "These are all lakes.", c.Comments)
+		}
+		assert.Equal(t, "This Siberian lake is the world's deepest", clueFor(t, clues, "Baikal").Clue)
+		assert.Equal(t, 1500, clueFor(t, clues, "Scotland").DailyDoubleValue)
+		assert.Equal(t, "Name the composer.", clueFor(t, clues, "Georges Bizet").Comments)
+	})
+
+	t.Run("test rejecting categories that can't be played", func(t *testing.T) {
+		category := func(round int, values ...int) []db.Clue {
+			clues := []db.Clue{}
+			for _, v := range values {
+				clues = append(clues, db.Clue{Round: round, Value: v, Category: "CATEGORY", Clue: "clue", Answer: "answer", AirDate: "2024-01-01"})
+			}
+			return clues
+		}
+		for reason, clues := range map[string][]db.Clue{
+			"has 6 clues instead of 5":                                       category(1, 200, 400, 600, 800, 1000, 1200),
+			"clue values [200 200 600 800 1000] are not 5 different amounts": category(1, 200, 200, 600, 800, 1000),
+			"clue values [0 400 600 800 1000] are not 5 different amounts":   category(2, 0, 400, 600, 800, 1000),
+			"has 2 final jeopardy clues instead of 1":                        category(3, 0, 0),
+			"invalid round 4":                              category(4, 0),
+			`invalid air date "January 1"`:                 {{Round: 3, Category: "CATEGORY", Clue: "clue", Answer: "answer", AirDate: "January 1"}},
+			"clue is missing its text or correct response": {{Round: 3, Category: "CATEGORY", Clue: "clue", AirDate: "2024-01-01"}},
+		} {
+			valid, rejected := validate(clues)
+			assert.Empty(t, valid)
+			if assert.Len(t, rejected, 1) {
+				assert.Equal(t, reason, rejected[0].Reason)
+			}
+		}
+	})
+}
+
+func TestImport(t *testing.T) {
+	t.Run("test importing clues into the database", func(t *testing.T) {
+		ctx := context.Background()
+		store := db.NewMemoryDB(nil)
+
+		report, err := Import(ctx, store, []string{"testdata/game_9031.html", "testdata/clues.csv"})
+		assert.NoError(t, err)
+		assert.Equal(t, 26, report.Inserted)
+		assert.Equal(t, 1, report.Duplicates)
+
+		questions, err := store.GetQuestions(ctx, 3, 2, 5)
+		assert.NoError(t, err)
+		assert.Len(t, questions, 26)
+		assert.Equal(t, []string{"Superior"}, questions[0].Alternatives)
+
+		report, err = Import(ctx, store, []string{"testdata"})
+		assert.NoError(t, err)
+		assert.Equal(t, 0, report.Inserted)
+		assert.Equal(t, 27, report.Duplicates)
+	})
+}
+
+// seasonPage is the part of a j-archive season page that links to its games.
+const seasonPage = `<html><body><table>
+<tr><td><a href="showgame.php?game_id=9031">#8963, aired&#160;2024-01-01</a></td><td>Isaac Hirsch vs. Katie Palumbo vs. Ben Lefkowitz</td></tr>
+<tr><td><a href="showgame.php?game_id=9032">#8964, aired&#160;2024-01-02</a></td><td>Isaac Hirsch vs. Laura Rasmussen vs. Mike Dupee</td></tr>
+</table><a href="showplayer.php?player_id=1">Isaac Hirsch</a></body></html>`
+
+func TestFetch(t *testing.T) {
+	game, err := os.ReadFile("testdata/game_9031.html")
+	if err != nil {
+		t.Fatalf("Error reading game page: %s", err.Error())
+	}
+	requests := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.String())
+		switch {
+		case r.URL.Path == "/showseason.php" && r.URL.Query().Get("season") == "40":
+			_, _ = w.Write([]byte(seasonPage))
+		case r.URL.Path == "/showgame.php":
+			_, _ = w.Write(game)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	dir := t.TempDir()
+
+	t.Run("test fetching a season's games", func(t *testing.T) {
+		fetched, err := Fetch(context.Background(), server.URL, "40", dir, time.Millisecond)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, fetched)
+		assert.Equal(t, []string{"/showseason.php?season=40", "/showgame.php?game_id=9031", "/showgame.php?game_id=9032"}, requests)
+		for _, id := range []string{"9031", "9032"} {
+			assert.FileExists(t, filepath.Join(dir, "game_"+id+".html"))
+		}
+
+		clues, report, err := ReadFiles([]string{dir})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, report.Files)
+		assert.NotEmpty(t, clues)
+	})
+
+	t.Run("test games already fetched are skipped", func(t *testing.T) {
+		requests = nil
+		fetched, err := Fetch(context.Background(), server.URL, "40", dir, time.Millisecond)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, fetched)
+		assert.Equal(t, []string{"/showseason.php?season=40"}, requests)
+	})
+
+	t.Run("test fetching a missing season", func(t *testing.T) {
+		_, err := Fetch(context.Background(), server.URL, "0", dir, time.Millisecond)
+		assert.ErrorContains(t, err, "returned 404")
+	})
+}
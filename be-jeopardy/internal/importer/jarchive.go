@@ -0,0 +1,230 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/db"
+	"golang.org/x/net/html"
+)
+
+// clueId matches the ids j-archive gives the clues of the first two rounds,
+// like clue_J_3_2 for the second clue of the third Jeopardy! category.
+var clueId = regexp.MustCompile(`^clue_(J|DJ)_(\d)_(\d)$`)
+
+// doubledValuesDate is the first show after clue values were doubled.
+const doubledValuesDate = "2001-11-26"
+
+// ParseGame reads the clues from a saved j-archive game page (showgame.php).
+// Clues that were never revealed aren't on the page and are left out, as is
+// the tiebreaker clue. Daily Doubles get the value of their place on the
+// board, with the wager as their daily double value.
+func ParseGame(r io.Reader) ([]db.Clue, error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	title := find(doc, func(n *html.Node) bool { return attr(n, "id") == "game_title" })
+	if title == nil {
+		return nil, fmt.Errorf("no game title")
+	}
+	airDate, err := parseAirDate(text(title))
+	if err != nil {
+		return nil, err
+	}
+
+	clues := []db.Clue{}
+	for _, table := range findAll(doc, byClass("table", "round")) {
+		categories := findAll(table, byClass("td", "category_name"))
+		comments := findAll(table, byClass("td", "category_comments"))
+		for _, td := range findAll(table, byClass("td", "clue")) {
+			clue, ok, err := parseClue(td, categories, comments, airDate)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				clues = append(clues, clue)
+			}
+		}
+	}
+
+	if table := find(doc, byClass("table", "final_round")); table != nil {
+		category := find(table, byClass("td", "category_name"))
+		clue := find(table, func(n *html.Node) bool { return attr(n, "id") == "clue_FJ" })
+		response := find(table, func(n *html.Node) bool { return attr(n, "id") == "clue_FJ_r" })
+		if category == nil || clue == nil || response == nil {
+			return nil, fmt.Errorf("incomplete final jeopardy clue")
+		}
+		answer := find(response, byClass("em", "correct_response"))
+		if answer == nil {
+			return nil, fmt.Errorf("no correct response for final jeopardy")
+		}
+		clues = append(clues, db.Clue{
+			Round:    3,
+			Category: text(category),
+			Comments: textOf(find(table, byClass("td", "category_comments"))),
+			Clue:     text(clue),
+			Answer:   text(answer),
+			AirDate:  airDate,
+		})
+	}
+
+	return clues, nil
+}
+
+// parseClue reads a td.clue cell of a round table, which is empty when the
+// clue wasn't revealed.
+func parseClue(td *html.Node, categories, comments []*html.Node, airDate string) (db.Clue, bool, error) {
+	clueText := find(td, func(n *html.Node) bool { return clueId.MatchString(attr(n, "id")) })
+	if clueText == nil {
+		return db.Clue{}, false, nil
+	}
+	id := attr(clueText, "id")
+	match := clueId.FindStringSubmatch(id)
+	round := 1
+	if match[1] == "DJ" {
+		round = 2
+	}
+	col, _ := strconv.Atoi(match[2])
+	row, _ := strconv.Atoi(match[3])
+	if col < 1 || col > len(categories) || row < 1 {
+		return db.Clue{}, false, fmt.Errorf("clue %s is outside the board", id)
+	}
+
+	response := find(td, func(n *html.Node) bool { return attr(n, "id") == id+"_r" })
+	if response == nil {
+		return db.Clue{}, false, fmt.Errorf("no response for clue %s", id)
+	}
+	answer := find(response, byClass("em", "correct_response"))
+	if answer == nil {
+		return db.Clue{}, false, fmt.Errorf("no correct response for clue %s", id)
+	}
+
+	clue := db.Clue{
+		Round:    round,
+		Value:    boardValue(round, row, airDate),
+		Category: text(categories[col-1]),
+		Clue:     text(clueText),
+		Answer:   text(answer),
+		AirDate:  airDate,
+	}
+	if col <= len(comments) {
+		clue.Comments = text(comments[col-1])
+	}
+	if dd := find(td, byClass("td", "clue_value_daily_double")); dd != nil {
+		wager, err := dollars(text(dd))
+		if err != nil {
+			return db.Clue{}, false, fmt.Errorf("invalid daily double wager for clue %s: %w", id, err)
+		}
+		clue.DailyDoubleValue = wager
+	} else if value := find(td, byClass("td", "clue_value")); value != nil {
+		amount, err := dollars(text(value))
+		if err != nil {
+			return db.Clue{}, false, fmt.Errorf("invalid value for clue %s: %w", id, err)
+		}
+		clue.Value = amount
+	}
+	return clue, true, nil
+}
+
+// parseAirDate reads the air date from a title like
+// "Show #8963 - Monday, January 1, 2024".
+func parseAirDate(title string) (string, error) {
+	i := strings.LastIndex(title, " - ")
+	if i == -1 {
+		return "", fmt.Errorf("no air date in game title %q", title)
+	}
+	date, err := time.Parse("Monday, January 2, 2006", title[i+3:])
+	if err != nil {
+		return "", fmt.Errorf("invalid air date in game title %q", title)
+	}
+	return date.Format(time.DateOnly), nil
+}
+
+func boardValue(round, row int, airDate string) int {
+	value := 100 * round * row
+	if airDate >= doubledValuesDate {
+		value *= 2
+	}
+	return value
+}
+
+// dollars reads an amount like "$1,200" or "DD: $1,200".
+func dollars(s string) (int, error) {
+	i := strings.Index(s, "$")
+	if i == -1 {
+		return 0, fmt.Errorf("no amount in %q", s)
+	}
+	return strconv.Atoi(strings.ReplaceAll(s[i+1:], ",", ""))
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func byClass(tag, class string) func(*html.Node) bool {
+	return func(n *html.Node) bool {
+		return n.Type == html.ElementNode && n.Data == tag && slices.Contains(strings.Fields(attr(n, "class")), class)
+	}
+}
+
+func find(n *html.Node, match func(*html.Node) bool) *html.Node {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if match(c) {
+			return c
+		}
+		if found := find(c, match); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func findAll(n *html.Node, match func(*html.Node) bool) []*html.Node {
+	found := []*html.Node{}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if match(c) {
+			found = append(found, c)
+		}
+		found = append(found, findAll(c, match)...)
+	}
+	return found
+}
+
+// text is the text inside a node with line breaks and runs of whitespace
+// collapsed to single spaces.
+func text(n *html.Node) string {
+	var sb strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		switch {
+		case n.Type == html.TextNode:
+			sb.WriteString(n.Data)
+		case n.Type == html.ElementNode && n.Data == "br":
+			sb.WriteString(" ")
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
+
+func textOf(n *html.Node) string {
+	if n == nil {
+		return ""
+	}
+	return text(n)
+}
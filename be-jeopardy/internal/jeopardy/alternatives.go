@@ -0,0 +1,49 @@
+package jeopardy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/db"
+)
+
+type (
+	alternativeStore interface {
+		GetPendingAlternatives(ctx context.Context, status string) ([]db.PendingAlternative, error)
+		ReviewPendingAlternative(ctx context.Context, id int, approved bool, reviewer string) (db.PendingAlternative, error)
+	}
+
+	AlternativeReview struct {
+		Approved bool   `json:"approved"`
+		Reviewer string `json:"reviewer"`
+	}
+)
+
+var alternativeDB alternativeStore
+
+// GetAlternatives returns the alternatives added by disputes with the given
+// status, pending ones if no status is given.
+func GetAlternatives(ctx context.Context, status string) ([]db.PendingAlternative, error) {
+	if status == "" {
+		status = db.PendingStatus
+	}
+	if status != db.PendingStatus && status != db.ApprovedStatus && status != db.RejectedStatus {
+		return nil, fmt.Errorf("Invalid status %s", status)
+	}
+	return alternativeDB.GetPendingAlternatives(ctx, status)
+}
+
+// ReviewAlternative approves or rejects a pending alternative. Approved
+// alternatives are accepted as correct from then on.
+func ReviewAlternative(ctx context.Context, id int, review AlternativeReview) (db.PendingAlternative, error) {
+	if review.Reviewer == "" {
+		return db.PendingAlternative{}, fmt.Errorf("A reviewer is required")
+	}
+	alt, err := alternativeDB.ReviewPendingAlternative(ctx, id, review.Approved, review.Reviewer)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.PendingAlternative{}, fmt.Errorf("No pending alternative with id %d", id)
+	}
+	return alt, err
+}
@@ -0,0 +1,117 @@
+package jeopardy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlternatives(t *testing.T) {
+	ctx := context.Background()
+	questionDB := newTestDB(t)
+	prevDB := alternativeDB
+	alternativeDB = questionDB
+	defer func() { alternativeDB = prevDB }()
+
+	t.Run("test disputed answers wait for review", func(t *testing.T) {
+		clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		config, err := NewConfig(true, false, 0, 30, 30, 30, 30, nil, nil)
+		assert.NoError(t, err)
+		g, err := NewGame(ctx, questionDB, config, WithClock(clock), WithSeed(7))
+		assert.NoError(t, err)
+		defer g.stop()
+
+		assert.NoError(t, g.do(func() error {
+			for i := 0; i < 3; i++ {
+				player := g.addPlayer(GameRequest{PlayerName: fmt.Sprintf("player%d", i)})
+				g.connect(player, &testConn{})
+			}
+			g.start()
+			return nil
+		}))
+		clock.Advance(boardIntroTimeout * time.Second)
+
+		picker, guesser, voter := g.Players[0], g.Players[1], g.Players[2]
+		catIdx := 0
+		for g.FirstRound[catIdx].Questions[0].DailyDouble {
+			catIdx++
+		}
+		q := g.FirstRound[catIdx].Questions[0]
+		assert.NoError(t, g.do(func() error {
+			assert.NoError(t, g.processMsg(ctx, Message{Player: picker, State: RecvPick, CatIdx: catIdx, ValIdx: 0}))
+			assert.NoError(t, g.processMsg(ctx, Message{Player: guesser, State: RecvBuzz}))
+			assert.NoError(t, g.processMsg(ctx, Message{Player: guesser, State: RecvAns, Answer: "something else"}))
+			assert.NoError(t, g.processMsg(ctx, Message{Player: picker, State: RecvBuzz, IsPass: true}))
+			return g.processMsg(ctx, Message{Player: voter, State: RecvBuzz, IsPass: true})
+		}))
+		assert.NoError(t, g.do(func() error {
+			assert.Equal(t, RecvPick, g.State)
+			assert.NoError(t, g.processMsg(ctx, Message{Player: guesser, State: RecvPick, InitDispute: true}))
+			assert.NoError(t, g.processMsg(ctx, Message{Player: voter, State: RecvDispute, Dispute: true}))
+			assert.Equal(t, q.Value, guesser.score())
+			return nil
+		}))
+
+		pending, err := GetAlternatives(ctx, "")
+		assert.NoError(t, err)
+		if !assert.Len(t, pending, 1) {
+			return
+		}
+		alt := pending[0]
+		assert.NotZero(t, alt.ClueId)
+		assert.Equal(t, q.Id, alt.ClueId)
+		assert.Equal(t, "something else", alt.Alternative)
+		assert.Equal(t, q.Answer, alt.Answer)
+		assert.Equal(t, q.Clue, alt.Clue)
+		assert.Equal(t, g.Id, alt.GameId)
+		assert.Equal(t, "player1", alt.AnsweredBy)
+		assert.Equal(t, []string{"player1", "player2"}, alt.DisputedBy)
+		assert.Equal(t, db.PendingStatus, alt.Status)
+
+		clue := func() db.Question {
+			categories, err := questionDB.SearchCategories(ctx, strings.ToLower(q.Category), "", 2)
+			assert.NoError(t, err)
+			for _, category := range categories {
+				questions, err := questionDB.GetCategoryQuestions(ctx, category)
+				assert.NoError(t, err)
+				for _, cq := range questions {
+					if cq.Id == q.Id {
+						return cq
+					}
+				}
+			}
+			return db.Question{}
+		}
+		assert.Equal(t, q.Clue, clue().Clue)
+		assert.Contains(t, clue().Incorrect, "something else")
+		assert.NotContains(t, clue().Alternatives, "something else")
+
+		_, err = ReviewAlternative(ctx, alt.Id, AlternativeReview{Approved: true})
+		assert.EqualError(t, err, "A reviewer is required")
+
+		reviewed, err := ReviewAlternative(ctx, alt.Id, AlternativeReview{Approved: true, Reviewer: "admin"})
+		assert.NoError(t, err)
+		assert.Equal(t, db.ApprovedStatus, reviewed.Status)
+		assert.Equal(t, "admin", reviewed.Reviewer)
+		assert.NotNil(t, reviewed.ReviewedAt)
+		assert.Contains(t, clue().Alternatives, "something else")
+
+		_, err = ReviewAlternative(ctx, alt.Id, AlternativeReview{Reviewer: "admin"})
+		assert.EqualError(t, err, fmt.Sprintf("No pending alternative with id %d", alt.Id))
+
+		pending, err = GetAlternatives(ctx, db.PendingStatus)
+		assert.NoError(t, err)
+		assert.Empty(t, pending)
+		approved, err := GetAlternatives(ctx, db.ApprovedStatus)
+		assert.NoError(t, err)
+		assert.Len(t, approved, 1)
+
+		_, err = GetAlternatives(ctx, "deleted")
+		assert.Error(t, err)
+	})
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"time"
 
 	"github.com/google/uuid"
 	"github.com/rileythomp/jeopardy/be-jeopardy/internal/db"
@@ -55,7 +54,8 @@ func (g *Game) answersFor(player GamePlayer) (int, int) {
 }
 
 func (g *Game) saveGameAnalytics(ctx context.Context) {
-	if !g.Penalty {
+	if !g.Penalty || g.playerBoard() {
+		// the answers to a player's board are known to whoever brought it
 		return
 	}
 	fr, sr := getRoundAnalytics(g.FirstRound), getRoundAnalytics(g.SecondRound)
@@ -67,7 +67,7 @@ func (g *Game) saveGameAnalytics(ctx context.Context) {
 	if !g.FullGame {
 		sr = db.AnalyticsRound{}
 	}
-	if err := g.jeopardyDB.SaveGameAnalytics(ctx, uuid.New(), time.Now().Unix(), fr, sr); err != nil {
+	if err := g.jeopardyDB.SaveGameAnalytics(ctx, uuid.New(), g.clock.Now().Unix(), fr, sr); err != nil {
 		log.Errorf("Error saving game analytics: %s", err.Error())
 	}
 	for _, player := range g.Players {
@@ -90,7 +90,7 @@ func getRoundAnalytics(round []Category) db.AnalyticsRound {
 	for _, category := range round {
 		c := db.AnalyticsCategory{Title: category.Title}
 		for _, question := range category.Questions {
-			q := db.AnalyticsQuestion{}
+			q := db.AnalyticsQuestion{ClueId: question.Id}
 			seenAns, seenCorr := false, false
 			for _, ans := range question.Answers {
 				if !seenAns && !ans.Bot {
@@ -0,0 +1,418 @@
+package jeopardy
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/agnivade/levenshtein"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+type (
+	// AnswerMatcher decides whether a player's response matches a correct
+	// response to a clue.
+	AnswerMatcher interface {
+		Match(response, correct string) bool
+	}
+
+	// AnswerRule rewrites the words of a normalized response, so that two
+	// ways of giving the same response end up as the same words.
+	AnswerRule func(words []string) []string
+
+	pipelineMatcher struct {
+		rules []AnswerRule
+	}
+)
+
+// DefaultAnswerRules are the rules the default matcher applies, in order,
+// after lowercasing and stripping punctuation and diacritics.
+var DefaultAnswerRules = []AnswerRule{
+	StripQuestionPhrase,
+	StripArticles,
+	JoinInitials,
+	ExpandAbbreviations,
+	NumberWords,
+}
+
+var answerMatcher = NewAnswerMatcher(DefaultAnswerRules...)
+
+// NewAnswerMatcher returns a matcher that normalizes both responses with
+// the given rules and then compares them, allowing for typos and responses
+// that sound the same. Parts of the correct response in brackets, like the
+// first name in "(John) Lennon", are optional.
+func NewAnswerMatcher(rules ...AnswerRule) AnswerMatcher {
+	return &pipelineMatcher{rules: rules}
+}
+
+// SetAnswerMatcher changes how every game judges responses.
+func SetAnswerMatcher(m AnswerMatcher) {
+	answerMatcher = m
+}
+
+func (q *Question) checkAnswer(ans string) bool {
+	for _, corr := range q.Alternatives {
+		if answerMatcher.Match(ans, corr) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *pipelineMatcher) Match(response, correct string) bool {
+	resp := m.normalize(response)
+	if len(resp) == 0 {
+		return false
+	}
+	for _, variant := range optionalParts(correct) {
+		corr := m.normalize(variant)
+		if len(corr) > 0 && wordsMatch(resp, corr) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *pipelineMatcher) normalize(s string) []string {
+	words := strings.Fields(simplify(s))
+	for _, rule := range m.rules {
+		words = rule(words)
+	}
+	return words
+}
+
+var diacritics = transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+// simplify lowercases s and strips its diacritics and punctuation. Dots,
+// commas and slashes within numbers are kept, so that "1/2" and "1,000"
+// are still numbers.
+func simplify(s string) string {
+	if t, _, err := transform.String(diacritics, s); err == nil {
+		s = t
+	}
+	s = strings.ToLower(s)
+	rs := []rune(s)
+	var b strings.Builder
+	for i, r := range rs {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		case r == '&':
+			b.WriteString(" and ")
+		case r == '\'' || r == '’':
+			// "Pepper's" is "peppers"
+		case (r == '/' || r == '.') && betweenDigits(rs, i):
+			b.WriteRune(r)
+		case r == ',' && betweenDigits(rs, i):
+		default:
+			b.WriteRune(' ')
+		}
+	}
+	return b.String()
+}
+
+func betweenDigits(rs []rune, i int) bool {
+	return i > 0 && i < len(rs)-1 && unicode.IsDigit(rs[i-1]) && unicode.IsDigit(rs[i+1])
+}
+
+var bracketed = regexp.MustCompile(`\(([^)]*)\)|\[([^\]]*)\]`)
+
+// optionalParts expands a j-archive style response with optional parts in
+// brackets, like "(John) Lennon", into each way of giving it.
+func optionalParts(s string) []string {
+	loc := bracketed.FindStringSubmatchIndex(s)
+	if loc == nil {
+		return []string{s}
+	}
+	var inner string
+	if loc[2] != -1 {
+		inner = s[loc[2]:loc[3]]
+	} else {
+		inner = s[loc[4]:loc[5]]
+	}
+	variants := []string{}
+	for _, rest := range optionalParts(s[loc[1]:]) {
+		variants = append(variants, s[:loc[0]]+inner+rest, s[:loc[0]]+rest)
+	}
+	return variants
+}
+
+var questionPhrases = [][]string{
+	{"what", "is"}, {"what", "are"}, {"what", "was"}, {"what", "were"},
+	{"who", "is"}, {"who", "are"}, {"who", "was"}, {"who", "were"},
+	{"where", "is"}, {"where", "are"}, {"when", "is"},
+	{"whats"}, {"whos"}, {"wheres"},
+}
+
+// StripQuestionPhrase drops a leading "what is", "who are" and the like.
+func StripQuestionPhrase(words []string) []string {
+	for _, phrase := range questionPhrases {
+		if len(words) > len(phrase) && slices.Equal(words[:len(phrase)], phrase) {
+			return words[len(phrase):]
+		}
+	}
+	return words
+}
+
+// StripArticles drops a leading "the", "a" or "an".
+func StripArticles(words []string) []string {
+	if len(words) > 1 && slices.Contains([]string{"the", "a", "an"}, words[0]) {
+		return words[1:]
+	}
+	return words
+}
+
+// JoinInitials joins runs of initials, so "U.S.A." and "USA" match.
+func JoinInitials(words []string) []string {
+	joined := []string{}
+	for i := 0; i < len(words); i++ {
+		j := i
+		for j < len(words) && isInitial(words[j]) {
+			j++
+		}
+		if j-i > 1 {
+			joined = append(joined, strings.Join(words[i:j], ""))
+			i = j - 1
+		} else {
+			joined = append(joined, words[i])
+		}
+	}
+	return joined
+}
+
+func isInitial(w string) bool {
+	return len([]rune(w)) == 1 && unicode.IsLetter([]rune(w)[0])
+}
+
+var abbreviations = map[string]string{
+	"st":   "saint",
+	"ste":  "sainte",
+	"mt":   "mount",
+	"ft":   "fort",
+	"pt":   "point",
+	"sgt":  "sergeant",
+	"capt": "captain",
+	"lt":   "lieutenant",
+	"gen":  "general",
+	"gov":  "governor",
+	"pres": "president",
+	"dr":   "doctor",
+	"prof": "professor",
+	"mr":   "mister",
+	"jr":   "junior",
+	"sr":   "senior",
+	"bros": "brothers",
+	"co":   "company",
+	"corp": "corporation",
+	"inc":  "incorporated",
+	"univ": "university",
+	"ave":  "avenue",
+	"blvd": "boulevard",
+	"vs":   "versus",
+	"n":    "and",
+}
+
+// ExpandAbbreviations spells out common abbreviations, so "St. Louis" and
+// "Saint Louis" match.
+func ExpandAbbreviations(words []string) []string {
+	expanded := make([]string, len(words))
+	for i, w := range words {
+		if long, ok := abbreviations[w]; ok {
+			w = long
+		}
+		expanded[i] = w
+	}
+	return expanded
+}
+
+var (
+	units = map[string]int{
+		"zero": 0, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+		"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10,
+		"eleven": 11, "twelve": 12, "thirteen": 13, "fourteen": 14, "fifteen": 15,
+		"sixteen": 16, "seventeen": 17, "eighteen": 18, "nineteen": 19,
+		"twenty": 20, "thirty": 30, "forty": 40, "fifty": 50,
+		"sixty": 60, "seventy": 70, "eighty": 80, "ninety": 90,
+	}
+	scales = map[string]int{
+		"hundred": 100, "thousand": 1000, "million": 1000000, "billion": 1000000000,
+	}
+	denominators = map[string]int{
+		"half": 2, "halves": 2, "third": 3, "thirds": 3, "quarter": 4, "quarters": 4,
+		"fourth": 4, "fourths": 4, "fifth": 5, "fifths": 5, "sixth": 6, "sixths": 6,
+		"eighth": 8, "eighths": 8, "tenth": 10, "tenths": 10,
+	}
+	ordinals = map[string]string{
+		"first": "1st", "second": "2nd", "third": "3rd", "fourth": "4th", "fifth": "5th",
+		"sixth": "6th", "seventh": "7th", "eighth": "8th", "ninth": "9th", "tenth": "10th",
+	}
+)
+
+// NumberWords writes numbers as digits, so "twenty one" and "21" match, as
+// do "one half" and "1/2".
+func NumberWords(words []string) []string {
+	numbers := []string{}
+	for i := 0; i < len(words); i++ {
+		w, last := words[i], len(numbers)-1
+		d, isDenominator := denominators[w]
+		if n, end, ok := parseNumber(words, i); ok {
+			numbers = append(numbers, strconv.Itoa(n))
+			i = end - 1
+		} else if isDenominator && last >= 0 && (isInt(numbers[last]) || numbers[last] == "a") {
+			// "three quarters" is "3/4" and "a half" is "1/2"
+			n := numbers[last]
+			if n == "a" {
+				n = "1"
+			}
+			numbers[last] = n + "/" + strconv.Itoa(d)
+		} else if isDenominator && d == 2 {
+			numbers = append(numbers, "1/2")
+		} else if ord, ok := ordinals[w]; ok {
+			numbers = append(numbers, ord)
+		} else {
+			numbers = append(numbers, w)
+		}
+	}
+	// "1 and 1/2" is "1 1/2"
+	mixed := []string{}
+	for i, w := range numbers {
+		if w == "and" && i > 0 && i < len(numbers)-1 && isInt(numbers[i-1]) && strings.Contains(numbers[i+1], "/") {
+			continue
+		}
+		mixed = append(mixed, w)
+	}
+	return mixed
+}
+
+func isInt(w string) bool {
+	_, err := strconv.Atoi(w)
+	return err == nil
+}
+
+// parseNumber reads a number written in words starting at words[i], like
+// "one hundred and five" or "3 million", returning it and where it ends.
+func parseNumber(words []string, i int) (int, int, bool) {
+	total, cur, end := 0, 0, i
+	for j := i; j < len(words); j++ {
+		w := words[j]
+		if n, ok := units[w]; ok {
+			// "twenty one" is a number but "one two" is two
+			if j > i && cur%100 != 0 && (cur%100 < 20 || cur%10 != 0 || n >= 10) {
+				break
+			}
+			cur += n
+		} else if n, err := strconv.Atoi(w); err == nil && j == i && j+1 < len(words) && scales[words[j+1]] > 0 {
+			cur += n
+		} else if s, ok := scales[w]; ok {
+			if cur == 0 {
+				cur = 1
+			}
+			if s == 100 {
+				cur *= s
+			} else {
+				total += cur * s
+				cur = 0
+			}
+		} else if w == "and" && j > i && j+1 < len(words) && units[words[j+1]] > 0 {
+			continue
+		} else {
+			break
+		}
+		end = j + 1
+	}
+	return total + cur, end, end > i
+}
+
+// wordsMatch compares two normalized responses, allowing more typos the
+// longer the correct response is. Numbers and single letters can't be
+// typos, so "vitamin c" doesn't match "vitamin a". Responses with the same
+// number of words also match if each of their words sounds the same.
+func wordsMatch(resp, corr []string) bool {
+	if !slices.Equal(numbersIn(resp), numbersIn(corr)) {
+		return false
+	}
+	if len(resp) == len(corr) {
+		for i := range resp {
+			if (isInitial(resp[i]) || isInitial(corr[i])) && resp[i] != corr[i] {
+				return false
+			}
+		}
+	}
+	ans, cor := strings.Join(resp, " "), strings.Join(corr, " ")
+	if levenshtein.ComputeDistance(ans, cor) <= maxTypos(len(cor)) {
+		return true
+	}
+	if len(resp) != len(corr) {
+		return false
+	}
+	for i := range resp {
+		r, c := resp[i], corr[i]
+		if r == c || levenshtein.ComputeDistance(r, c) <= maxTypos(len(c)) {
+			continue
+		}
+		if len(r) < phoneticLength || len(c) < phoneticLength || phonetic(r) != phonetic(c) {
+			return false
+		}
+	}
+	return true
+}
+
+func numbersIn(words []string) []string {
+	return slices.DeleteFunc(slices.Clone(words), func(w string) bool {
+		return !strings.ContainsFunc(w, unicode.IsDigit)
+	})
+}
+
+func maxTypos(n int) int {
+	switch {
+	case n <= 5:
+		return 0
+	case n <= 7:
+		return 1
+	case n <= 9:
+		return 2
+	case n <= 12:
+		return 3
+	case n <= 15:
+		return 4
+	default:
+		return 5
+	}
+}
+
+// phoneticLength is how long both words have to be to match by sound.
+const phoneticLength = 5
+
+var (
+	phoneticPrefixes = map[string]string{"kn": "n", "gn": "n", "pn": "n", "wr": "r", "ps": "s", "wh": "w", "x": "s"}
+	phoneticSounds   = strings.NewReplacer(
+		"tch", "x", "sch", "x", "ch", "x", "sh", "x", "th", "0", "ph", "f", "ck", "k", "dg", "j",
+		"ce", "se", "ci", "si", "cy", "sy", "c", "k", "q", "k", "x", "ks", "z", "s", "v", "f", "gh", "",
+	)
+)
+
+// phonetic is a rough key for how a word sounds: its spelling with letters
+// and pairs of letters that sound alike merged, and silent letters dropped.
+func phonetic(w string) string {
+	for prefix, sound := range phoneticPrefixes {
+		if strings.HasPrefix(w, prefix) {
+			w = sound + w[len(prefix):]
+			break
+		}
+	}
+	w = phoneticSounds.Replace(w)
+	w = strings.NewReplacer("h", "", "y", "i").Replace(w)
+	var b strings.Builder
+	var last rune
+	for _, r := range w {
+		if r != last {
+			b.WriteRune(r)
+		}
+		last = r
+	}
+	return b.String()
+}
@@ -0,0 +1,164 @@
+package jeopardy
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/db"
+	"github.com/stretchr/testify/assert"
+)
+
+// exportedAnswers is a sample of the alternatives and incorrect responses in
+// jeopardy_clues, written by `jeopardy export-answers`.
+const exportedAnswers = "testdata/answers.json"
+
+// answerCorpus is a golden corpus of responses players gave, in the shape of
+// the alternatives and incorrect columns of jeopardy_clues: alternatives were
+// accepted, either as they were or after a dispute, and incorrect responses
+// were rejected.
+func answerCorpus(t *testing.T, file string) []db.Clue {
+	t.Helper()
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("Error reading answer corpus: %s", err.Error())
+	}
+	var clues []db.Clue
+	if err := json.Unmarshal(data, &clues); err != nil {
+		t.Fatalf("Error parsing answer corpus: %s", err.Error())
+	}
+	return clues
+}
+
+func TestAnswerMatcher(t *testing.T) {
+	matcher := NewAnswerMatcher(DefaultAnswerRules...)
+
+	t.Run("test golden answer corpus", func(t *testing.T) {
+		clues := answerCorpus(t, "../db/testdata/clues.json")
+		if _, err := os.Stat(exportedAnswers); err == nil {
+			clues = append(clues, answerCorpus(t, exportedAnswers)...)
+		} else {
+			t.Logf("No exported answers in %s, run `jeopardy export-answers` to export them", exportedAnswers)
+		}
+		for _, clue := range clues {
+			for _, alt := range clue.Alternatives {
+				assert.True(t, matcher.Match(alt, clue.Answer), "%q should match %q", alt, clue.Answer)
+			}
+			for _, inc := range clue.Incorrect {
+				assert.False(t, matcher.Match(inc, clue.Answer), "%q should not match %q", inc, clue.Answer)
+			}
+		}
+	})
+
+	t.Run("test simplifying", func(t *testing.T) {
+		tests := []struct{ s, want string }{
+			{"Pelé", "pele"},
+			{"Sgt. Pepper's", "sgt peppers"},
+			{"Rock & Roll", "rock and roll"},
+			{"1,000 or 1/2 or 3.5", "1000 or 1/2 or 3.5"},
+			{"U.S.A.", "u s a"},
+		}
+		for _, tt := range tests {
+			assert.Equal(t, strings.Fields(tt.want), strings.Fields(simplify(tt.s)), tt.s)
+		}
+	})
+
+	t.Run("test each normalization rule", func(t *testing.T) {
+		tests := []struct {
+			rule  AnswerRule
+			words string
+			want  string
+		}{
+			{StripQuestionPhrase, "what are the beatles", "the beatles"},
+			{StripQuestionPhrase, "whos john lennon", "john lennon"},
+			{StripQuestionPhrase, "what is", "what is"},
+			{StripArticles, "the beatles", "beatles"},
+			{StripArticles, "a half", "half"},
+			{StripArticles, "the", "the"},
+			{JoinInitials, "u s a", "usa"},
+			{JoinInitials, "j r r tolkien", "jrr tolkien"},
+			{JoinInitials, "vitamin a", "vitamin a"},
+			{ExpandAbbreviations, "st louis", "saint louis"},
+			{ExpandAbbreviations, "mt everest", "mount everest"},
+			{ExpandAbbreviations, "sgt peppers lonely hearts club band", "sergeant peppers lonely hearts club band"},
+			{ExpandAbbreviations, "dr seuss", "doctor seuss"},
+			{ExpandAbbreviations, "rock n roll", "rock and roll"},
+			{NumberWords, "twenty one", "21"},
+			{NumberWords, "one thousand", "1000"},
+			{NumberWords, "a half", "1/2"},
+			{NumberWords, "half", "1/2"},
+			{NumberWords, "one and one half", "1 1/2"},
+			{NumberWords, "1 and 1/2", "1 1/2"},
+			{NumberWords, "henry the eighth", "henry the 8th"},
+		}
+		for _, tt := range tests {
+			assert.Equal(t, strings.Fields(tt.want), tt.rule(strings.Fields(tt.words)), tt.words)
+		}
+	})
+
+	t.Run("test typos and sounds", func(t *testing.T) {
+		tests := []struct {
+			response, correct string
+			match             bool
+		}{
+			{"the beetles", "the beatles", true},
+			{"sweeden", "sweden", true},
+			{"tchaikowsky", "tchaikovsky", true},
+			{"chaikovsky", "tchaikovsky", true},
+			{"filadelfia", "philadelphia", true},
+			{"schuman", "schumann", true},
+			{"nossos", "knossos", true},
+			{"mars", "mars", true},
+			{"marks", "mars", false},
+			{"moors", "mars", false},
+			{"sudan", "sweden", false},
+			{"australia", "austria", false},
+			{"schubert", "schumann", false},
+			{"vitamin c", "vitamin a", false},
+			{"20", "21", false},
+			{"31", "21", false},
+		}
+		for _, tt := range tests {
+			assert.Equal(t, tt.match, wordsMatch(strings.Fields(tt.response), strings.Fields(tt.correct)), "%q and %q", tt.response, tt.correct)
+		}
+	})
+
+	t.Run("test normalization rules", func(t *testing.T) {
+		normalize := func(s string) []string {
+			return matcher.(*pipelineMatcher).normalize(s)
+		}
+		assert.Equal(t, []string{"beatles"}, normalize("What are The Beatles?"))
+		assert.Equal(t, []string{"saint", "louis"}, normalize("St. Louis"))
+		assert.Equal(t, []string{"1/2"}, normalize("one half"))
+		assert.Equal(t, []string{"3/4"}, normalize("three quarters"))
+		assert.Equal(t, []string{"1", "1/2"}, normalize("one and a half"))
+		assert.Equal(t, []string{"105"}, normalize("one hundred and five"))
+		assert.Equal(t, []string{"3000000"}, normalize("3 million"))
+		assert.Equal(t, []string{"1", "2", "3"}, normalize("one two three"))
+		assert.Equal(t, []string{"jrr", "tolkien"}, normalize("J.R.R. Tolkien"))
+		assert.Equal(t, []string{"antonin", "dvorak"}, normalize("Antonín Dvořák"))
+		assert.Equal(t, []string{"henry", "the", "8th"}, normalize("Henry the Eighth"))
+		assert.Equal(t, []string{"a"}, normalize("a"))
+	})
+
+	t.Run("test optional parts", func(t *testing.T) {
+		assert.Equal(t, []string{"John Lennon", " Lennon"}, optionalParts("(John) Lennon"))
+		assert.Len(t, optionalParts("(Sir) Paul [McCartney]"), 4)
+		assert.False(t, matcher.Match("", "Lennon"))
+		assert.False(t, matcher.Match("the", "The Who"))
+	})
+
+	t.Run("test rules are pluggable", func(t *testing.T) {
+		bare := NewAnswerMatcher()
+		assert.False(t, bare.Match("Beatles", "The Beatles"))
+		assert.True(t, NewAnswerMatcher(StripArticles).Match("Beatles", "The Beatles"))
+
+		prev := answerMatcher
+		defer SetAnswerMatcher(prev)
+		SetAnswerMatcher(bare)
+		q := &Question{Question: db.Question{Alternatives: []string{"The Beatles"}}}
+		assert.False(t, q.checkAnswer("Beatles"))
+		assert.True(t, q.checkAnswer("the beatles"))
+	})
+}
@@ -0,0 +1,354 @@
+package jeopardy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/db"
+)
+
+// BoardFileVersion is the version of the board file format this server
+// writes and reads. It is bumped whenever the format changes in a way that
+// older readers can't understand.
+const BoardFileVersion = 1
+
+const maxClueValue = 100000
+
+type (
+	// BoardFile is a board with its answers, in the format games are
+	// exported to and imported from.
+	BoardFile struct {
+		Version       int             `json:"version"`
+		Name          string          `json:"name,omitempty"`
+		FirstRound    []BoardCategory `json:"firstRound"`
+		SecondRound   []BoardCategory `json:"secondRound"`
+		FinalJeopardy BoardFinal      `json:"finalJeopardy"`
+	}
+
+	BoardCategory struct {
+		Category string      `json:"category"`
+		Comments string      `json:"comments,omitempty"`
+		Clues    []BoardClue `json:"clues"`
+	}
+
+	BoardClue struct {
+		Value        int      `json:"value"`
+		Clue         string   `json:"clue"`
+		Answer       string   `json:"answer"`
+		Alternatives []string `json:"alternatives,omitempty"`
+		DailyDouble  bool     `json:"dailyDouble,omitempty"`
+	}
+
+	BoardFinal struct {
+		Category     string   `json:"category"`
+		Comments     string   `json:"comments,omitempty"`
+		Clue         string   `json:"clue"`
+		Answer       string   `json:"answer"`
+		Alternatives []string `json:"alternatives,omitempty"`
+	}
+)
+
+// ExportBoard returns the board of the player's game once the game is over,
+// or at any time to its host, who sees the answers anyway.
+func ExportBoard(playerId string) (BoardFile, error) {
+	game, err := GetPlayerGame(playerId)
+	if err != nil {
+		return BoardFile{}, err
+	}
+	var board BoardFile
+	err = game.do(func() error {
+		if game.State != PostGame && (game.Host == nil || game.Host.id() != playerId) {
+			return fmt.Errorf("The board can only be exported once the game is over")
+		}
+		board = game.boardFile()
+		return nil
+	})
+	return board, err
+}
+
+// ParseBoardFile reads a board file, pointing at where it is malformed or
+// listing everything that keeps it from being played.
+func ParseBoardFile(data []byte) (BoardFile, error) {
+	var header struct {
+		Version *int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return BoardFile{}, fmt.Errorf("Invalid board file: %s", decodeError(data, err, 0))
+	}
+	if header.Version == nil {
+		return BoardFile{}, fmt.Errorf("Invalid board file: version is required")
+	}
+	if *header.Version != BoardFileVersion {
+		return BoardFile{}, fmt.Errorf("Invalid board file: unsupported version %d, expected %d", *header.Version, BoardFileVersion)
+	}
+
+	var board BoardFile
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&board); err != nil {
+		return BoardFile{}, fmt.Errorf("Invalid board file: %s", decodeError(data, err, dec.InputOffset()))
+	}
+	if problems := boardFileProblems(board); len(problems) > 0 {
+		return BoardFile{}, fmt.Errorf("Invalid board file: %s", strings.Join(problems, "; "))
+	}
+	return board, nil
+}
+
+// decodeError describes a decoding error with the line and column it is on.
+// offset is where the decoder stopped, for errors that don't carry their own.
+// Type errors point just past the value of the wrong type.
+func decodeError(data []byte, err error, offset int64) string {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		// the offset is just past the character that isn't valid
+		return fmt.Sprintf("%s: %s", position(data, syntaxErr.Offset-1), syntaxErr.Error())
+	case errors.As(err, &typeErr):
+		field := jsonPath(typeErr.Field)
+		if field == "" {
+			field = "board"
+		}
+		return fmt.Sprintf("%s: %s must be %s, got: %s", position(data, typeErr.Offset), field, jsonType(typeErr.Type.Kind().String()), typeErr.Value)
+	}
+	return fmt.Sprintf("%s: %s", position(data, offset), strings.TrimPrefix(err.Error(), "json: "))
+}
+
+// jsonPath writes a field path like firstRound.0.clues the way problems
+// with boards are reported, as firstRound[0].clues.
+func jsonPath(field string) string {
+	var sb strings.Builder
+	for i, part := range strings.Split(field, ".") {
+		if _, err := strconv.Atoi(part); err == nil {
+			fmt.Fprintf(&sb, "[%s]", part)
+			continue
+		}
+		if i > 0 {
+			sb.WriteString(".")
+		}
+		sb.WriteString(part)
+	}
+	return sb.String()
+}
+
+func jsonType(kind string) string {
+	switch {
+	case strings.HasPrefix(kind, "int"), strings.HasPrefix(kind, "uint"), strings.HasPrefix(kind, "float"):
+		return "a number"
+	case kind == "slice":
+		return "an array"
+	case kind == "struct", kind == "map":
+		return "an object"
+	case kind == "bool":
+		return "true or false"
+	}
+	return "a " + kind
+}
+
+// position is the line and column of a byte offset into data.
+func position(data []byte, offset int64) string {
+	offset = min(max(offset, 0), int64(len(data)))
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	col := len(before) - bytes.LastIndexByte(before, '\n')
+	return fmt.Sprintf("line %d, column %d", line, col)
+}
+
+// boardFileProblems checks a board file can be played, naming each problem
+// by its path in the file.
+func boardFileProblems(board BoardFile) []string {
+	problems := []string{}
+	if len(board.Name) > maxBoardNameLength {
+		problems = append(problems, fmt.Sprintf("name is longer than %d characters", maxBoardNameLength))
+	}
+	if n := len(board.FirstRound); n < 2 || n > maxCategories {
+		problems = append(problems, fmt.Sprintf("firstRound must have between 2 and %d categories, got: %d", maxCategories, n))
+	}
+	if n := len(board.SecondRound); n != 0 && n != len(board.FirstRound) {
+		problems = append(problems, fmt.Sprintf("secondRound must have no categories or as many as firstRound, got: %d", n))
+	}
+	clues := 0
+	if len(board.FirstRound) > 0 {
+		clues = len(board.FirstRound[0].Clues)
+		if clues < 2 || clues > maxQuestions {
+			problems = append(problems, fmt.Sprintf("firstRound[0].clues must have between 2 and %d clues, got: %d", maxQuestions, clues))
+		}
+	}
+
+	checkText := func(path, field, value string) {
+		if strings.TrimSpace(value) == "" {
+			problems = append(problems, fmt.Sprintf("%s.%s is required", path, field))
+		} else if len(value) > maxClueLength {
+			problems = append(problems, fmt.Sprintf("%s.%s is longer than %d characters", path, field, maxClueLength))
+		}
+	}
+	checkAlternatives := func(path string, alternatives []string) {
+		if len(alternatives) > maxAlternatives {
+			problems = append(problems, fmt.Sprintf("%s.alternatives has more than %d alternatives", path, maxAlternatives))
+		}
+		for i, alternative := range alternatives {
+			if strings.TrimSpace(alternative) == "" {
+				problems = append(problems, fmt.Sprintf("%s.alternatives[%d] is empty", path, i))
+			}
+		}
+	}
+	for round, categories := range [][]BoardCategory{board.FirstRound, board.SecondRound} {
+		roundName := []string{"firstRound", "secondRound"}[round]
+		for i, category := range categories {
+			path := fmt.Sprintf("%s[%d]", roundName, i)
+			checkText(path, "category", category.Category)
+			if (round > 0 || i > 0) && len(category.Clues) != clues {
+				problems = append(problems, fmt.Sprintf("%s.clues has %d clues, expected %d", path, len(category.Clues), clues))
+			}
+			for j, clue := range category.Clues {
+				cluePath := fmt.Sprintf("%s.clues[%d]", path, j)
+				if clue.Value < 1 || clue.Value > maxClueValue {
+					problems = append(problems, fmt.Sprintf("%s.value must be between 1 and %d, got: %d", cluePath, maxClueValue, clue.Value))
+				}
+				checkText(cluePath, "clue", clue.Clue)
+				checkText(cluePath, "answer", clue.Answer)
+				checkAlternatives(cluePath, clue.Alternatives)
+			}
+		}
+	}
+
+	checkText("finalJeopardy", "category", board.FinalJeopardy.Category)
+	checkText("finalJeopardy", "clue", board.FinalJeopardy.Clue)
+	checkText("finalJeopardy", "answer", board.FinalJeopardy.Answer)
+	checkAlternatives("finalJeopardy", board.FinalJeopardy.Alternatives)
+	return problems
+}
+
+// importedBoardRequest shapes a game request to an imported board, which
+// decides the size of the board, its values and its Daily Doubles.
+func importedBoardRequest(req GameRequest) (GameRequest, BoardFile, error) {
+	if req.CustomBoard != "" {
+		return req, BoardFile{}, fmt.Errorf("Games cannot be played on both a custom and an imported board")
+	}
+	if len(req.FirstRoundCategories) > 0 || len(req.SecondRoundCategories) > 0 {
+		return req, BoardFile{}, fmt.Errorf("Games on an imported board cannot pick categories")
+	}
+	if len(req.FirstRoundValues) > 0 || len(req.SecondRoundValues) > 0 || len(req.DailyDoubles) > 0 {
+		return req, BoardFile{}, fmt.Errorf("Games on an imported board take their values and Daily Doubles from the board")
+	}
+	board, err := ParseBoardFile(req.Board)
+	if err != nil {
+		return req, BoardFile{}, err
+	}
+	if req.FullGame && len(board.SecondRound) == 0 {
+		return req, BoardFile{}, fmt.Errorf("The imported board has no second round")
+	}
+	req.Categories = len(board.FirstRound)
+	req.Questions = len(board.FirstRound[0].Clues)
+	return req, board, nil
+}
+
+// withImportedBoard plays a game on an imported board rather than on clues
+// from the database.
+func withImportedBoard(board BoardFile) GameOption {
+	return func(g *Game) {
+		g.importedBoard = &board
+	}
+}
+
+// setImportedBoard lays out the imported board with its own values and
+// Daily Doubles.
+func (g *Game) setImportedBoard() error {
+	questions := []db.Question{}
+	dailyDoubles := []bool{}
+	for round, categories := range [][]BoardCategory{g.importedBoard.FirstRound, g.importedBoard.SecondRound} {
+		for _, category := range categories {
+			for _, clue := range category.Clues {
+				questions = append(questions, db.Question{
+					Round:        round + 1,
+					Value:        clue.Value,
+					Category:     category.Category,
+					Comments:     category.Comments,
+					Clue:         clue.Clue,
+					Answer:       clue.Answer,
+					Alternatives: append([]string{clue.Answer}, clue.Alternatives...),
+					Incorrect:    []string{},
+				})
+				dailyDoubles = append(dailyDoubles, clue.DailyDouble)
+			}
+		}
+	}
+	final := g.importedBoard.FinalJeopardy
+	questions = append(questions, db.Question{
+		Round:        3,
+		Category:     final.Category,
+		Comments:     final.Comments,
+		Clue:         final.Clue,
+		Answer:       final.Answer,
+		Alternatives: append([]string{final.Answer}, final.Alternatives...),
+		Incorrect:    []string{},
+	})
+
+	g.layoutBoard(questions)
+	i := 0
+	for _, round := range [][]Category{g.FirstRound, g.SecondRound} {
+		for _, category := range round {
+			for _, q := range category.Questions {
+				q.DailyDouble = dailyDoubles[i]
+				i++
+			}
+		}
+	}
+	g.setChoices()
+	g.record(GameEvent{Type: EventBoard, Board: g.board()})
+	return nil
+}
+
+// boardFile is the game's current board in the board file format.
+func (g *Game) boardFile() BoardFile {
+	file := func(round []Category) []BoardCategory {
+		categories := []BoardCategory{}
+		for _, category := range round {
+			c := BoardCategory{Category: category.Title, Clues: []BoardClue{}}
+			for _, q := range category.Questions {
+				c.Comments = q.Comments
+				c.Clues = append(c.Clues, BoardClue{
+					Value:        q.Value,
+					Clue:         q.Clue,
+					Answer:       q.Answer,
+					Alternatives: otherAlternatives(q.Question),
+					DailyDouble:  q.DailyDouble,
+				})
+			}
+			categories = append(categories, c)
+		}
+		return categories
+	}
+	return BoardFile{
+		Version:     BoardFileVersion,
+		Name:        g.Name,
+		FirstRound:  file(g.FirstRound),
+		SecondRound: file(g.SecondRound),
+		FinalJeopardy: BoardFinal{
+			Category:     g.FinalQuestion.Category,
+			Comments:     g.FinalQuestion.Comments,
+			Clue:         g.FinalQuestion.Clue,
+			Answer:       g.FinalQuestion.Answer,
+			Alternatives: otherAlternatives(g.FinalQuestion.Question),
+		},
+	}
+}
+
+// otherAlternatives are the accepted responses besides the answer itself,
+// which is always accepted.
+func otherAlternatives(q db.Question) []string {
+	alternatives := []string{}
+	for _, alternative := range q.Alternatives {
+		if alternative != q.Answer {
+			alternatives = append(alternatives, alternative)
+		}
+	}
+	if len(alternatives) == 0 {
+		return nil
+	}
+	return alternatives
+}
@@ -0,0 +1,159 @@
+package jeopardy
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testBoardFile() BoardFile {
+	round := func(n int) []BoardCategory {
+		categories := []BoardCategory{}
+		for _, name := range []string{"RIVERS", "OPERA"} {
+			category := BoardCategory{Category: name, Clues: []BoardClue{}}
+			for i, clue := range []string{"First", "Second", "Third"} {
+				category.Clues = append(category.Clues, BoardClue{Value: 100 * n * (i + 1), Clue: clue + " " + name, Answer: strings.ToLower(clue)})
+			}
+			categories = append(categories, category)
+		}
+		return categories
+	}
+	board := BoardFile{
+		Version:       BoardFileVersion,
+		FirstRound:    round(1),
+		SecondRound:   round(2),
+		FinalJeopardy: BoardFinal{Category: "WORLD LEADERS", Clue: "He was the last president of Czechoslovakia", Answer: "Václav Havel", Alternatives: []string{"Havel"}},
+	}
+	board.FirstRound[1].Clues[2].DailyDouble = true
+	return board
+}
+
+func TestParseBoardFile(t *testing.T) {
+	t.Run("test parsing a valid board file", func(t *testing.T) {
+		data, err := json.Marshal(testBoardFile())
+		assert.NoError(t, err)
+		board, err := ParseBoardFile(data)
+		assert.NoError(t, err)
+		assert.Equal(t, testBoardFile(), board)
+	})
+
+	t.Run("test malformed board files", func(t *testing.T) {
+		for data, msg := range map[string]string{
+			`{"firstRound": []}`: "Invalid board file: version is required",
+			`{"version": 2}`:     "Invalid board file: unsupported version 2, expected 1",
+			`{"version": "1"}`:   "Invalid board file: line 1, column 16: version must be a number, got: string",
+			"{\n  \"version\": 1,\n  \"firstRound\": [,]\n}":                "Invalid board file: line 3, column 18: invalid character ',' looking for beginning of value",
+			"{\"version\": 1,\n\"firstRound\": {}}":                         "Invalid board file: line 2, column 16: firstRound must be an array, got: object",
+			`{"version": 1, "firstRound": [{"clues": [{"value": "200"}]}]}`: "Invalid board file: line 1, column 57: firstRound[0].clues[0].value must be a number, got: string",
+			`{"version": 1, "rounds": []}`:                                  `Invalid board file: line 1, column 29: unknown field "rounds"`,
+			`{"version": 1} {}`:                                             "Invalid board file: line 1, column 16: invalid character '{' after top-level value",
+			`{"version": 1`:                                                 "Invalid board file: line 1, column 13: unexpected end of JSON input",
+		} {
+			_, err := ParseBoardFile([]byte(data))
+			assert.EqualError(t, err, msg, data)
+		}
+	})
+
+	t.Run("test boards that can't be played", func(t *testing.T) {
+		for _, test := range []struct {
+			problem string
+			change  func(b *BoardFile)
+		}{
+			{"firstRound must have between 2 and 8 categories, got: 1", func(b *BoardFile) { b.FirstRound, b.SecondRound = b.FirstRound[:1], nil }},
+			{"secondRound must have no categories or as many as firstRound, got: 1", func(b *BoardFile) { b.SecondRound = b.SecondRound[:1] }},
+			{"secondRound[1].clues has 2 clues, expected 3", func(b *BoardFile) { b.SecondRound[1].Clues = b.SecondRound[1].Clues[:2] }},
+			{"firstRound[1].category is required", func(b *BoardFile) { b.FirstRound[1].Category = " " }},
+			{"firstRound[0].clues[2].answer is required", func(b *BoardFile) { b.FirstRound[0].Clues[2].Answer = "" }},
+			{"secondRound[0].clues[1].value must be between 1 and 100000, got: 0", func(b *BoardFile) { b.SecondRound[0].Clues[1].Value = 0 }},
+			{"firstRound[1].clues[0].alternatives[1] is empty", func(b *BoardFile) { b.FirstRound[1].Clues[0].Alternatives = []string{"one", ""} }},
+			{"finalJeopardy.clue is required", func(b *BoardFile) { b.FinalJeopardy.Clue = "" }},
+		} {
+			board := testBoardFile()
+			test.change(&board)
+			data, err := json.Marshal(board)
+			assert.NoError(t, err)
+			_, err = ParseBoardFile(data)
+			assert.EqualError(t, err, "Invalid board file: "+test.problem)
+		}
+	})
+}
+
+func TestExportImportBoard(t *testing.T) {
+	ctx := context.Background()
+	memoryDB := newTestDB(t)
+	prevDB := newJeopardyDB
+	newJeopardyDB = func(ctx context.Context) (jeopardyDB, error) {
+		return memoryDB, nil
+	}
+	defer func() {
+		newJeopardyDB = prevDB
+	}()
+	req := GameRequest{PlayerName: "alice", FullGame: true, PickConfig: 30, BuzzConfig: 30, AnswerConfig: 30, WagerConfig: 30}
+
+	g, playerId, err, _ := CreatePrivateGame(ctx, req)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer removeGame(g)
+
+	_, err = ExportBoard(playerId)
+	assert.EqualError(t, err, "The board can only be exported once the game is over")
+	assert.NoError(t, g.do(func() error {
+		g.State = PostGame
+		return nil
+	}))
+	exported, err := ExportBoard(playerId)
+	assert.NoError(t, err)
+	assert.Equal(t, BoardFileVersion, exported.Version)
+	assert.Len(t, exported.FirstRound, 6)
+	assert.Len(t, exported.SecondRound, 6)
+
+	t.Run("test playing an exported board", func(t *testing.T) {
+		data, err := json.Marshal(exported)
+		assert.NoError(t, err)
+		req := req
+		req.PlayerName = "bob"
+		req.Board = data
+		imported, _, err, _ := CreatePrivateGame(ctx, req)
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer removeGame(imported)
+
+		assert.NoError(t, imported.do(func() error {
+			board := imported.boardFile()
+			board.Name = exported.Name
+			assert.Equal(t, exported, board)
+			assert.True(t, imported.FirstRound[0].Questions[0].checkAnswer(exported.FirstRound[0].Clues[0].Answer))
+			assert.Equal(t, &exported, imported.snapshot().Board)
+
+			// playing again is on the same board
+			assert.NoError(t, imported.setQuestions(ctx))
+			board = imported.boardFile()
+			board.Name = exported.Name
+			assert.Equal(t, exported, board)
+			return nil
+		}))
+	})
+
+	t.Run("test requests that don't fit an imported board", func(t *testing.T) {
+		data, err := json.Marshal(testBoardFile())
+		assert.NoError(t, err)
+		req := req
+		req.Board = data
+		req.FirstRoundValues = []int{100, 200, 300}
+		_, _, err, _ = CreatePrivateGame(ctx, req)
+		assert.EqualError(t, err, "Games on an imported board take their values and Daily Doubles from the board")
+
+		board := testBoardFile()
+		board.SecondRound = nil
+		data, err = json.Marshal(board)
+		assert.NoError(t, err)
+		req = GameRequest{PlayerName: "carol", FullGame: true, PickConfig: 30, BuzzConfig: 30, AnswerConfig: 30, WagerConfig: 30, Board: data}
+		_, _, err, _ = CreatePrivateGame(ctx, req)
+		assert.EqualError(t, err, "The imported board has no second round")
+	})
+}
@@ -2,16 +2,34 @@ package jeopardy
 
 import (
 	"context"
-	"sort"
+	"math/rand/v2"
 	"time"
 
 	"github.com/rileythomp/jeopardy/be-jeopardy/internal/socket"
 )
 
-type Bot struct {
-	*Player
-	botChan chan Response
-}
+type (
+	Bot struct {
+		*Player
+		// Level is the difficulty the bot plays at.
+		Level    string `json:"level"`
+		strategy BotStrategy
+		botChan  chan *botAction
+		stopped  bool
+		// onAction, when set, takes the bot's actions in place of its
+		// goroutine, so a simulation can send them itself.
+		onAction func(action *botAction)
+		clock    Clock
+		rng      *rand.Rand
+	}
+
+	// botAction is a message a bot has decided to send once its delay passes.
+	botAction struct {
+		msg   Message
+		delay time.Duration
+		buzz  bool
+	}
+)
 
 const (
 	botPickTimeout    = 3 * time.Second
@@ -38,76 +56,119 @@ func NewBot(name string, i int) *Bot {
 	botConfig := botConfigs[i%len(botConfigs)]
 	bot := &Bot{
 		Player:  NewPlayer(botConfig.name, botConfig.imgUrl, ""),
-		botChan: make(chan Response),
+		botChan: make(chan *botAction),
+		clock:   realClock{},
+		rng:     rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
 	}
+	bot.setLevel(MediumBot)
 	bot.Conn = socket.NewSafeConn(nil) // so bot is treated as connected by frontend
 	return bot
 }
 
-func (p *Bot) sendMessage(msg Response) error {
-	p.botChan <- msg
+func (p *Bot) setLevel(level string) {
+	p.Level = level
+	p.strategy = botStrategies[level]
+}
+
+// sendMessage decides how the bot responds to a game update. It runs on
+// the game's event loop, so the bot reads the game's state there and only
+// waits out its delay on its own goroutine.
+func (p *Bot) sendMessage(resp Response) error {
+	if p.stopped {
+		return nil
+	}
+	var action *botAction
+	if resp.Game != nil {
+		action = p.processMessage(resp.Game)
+	}
+	if p.onAction != nil {
+		p.onAction(action)
+		return nil
+	}
+	p.botChan <- action
 	return nil
 }
 
-func (p *Bot) processMessages() {
+// processMessages starts the bot's goroutine. Each new action replaces the
+// one the bot was waiting to send.
+func (p *Bot) processMessages(g *Game) {
 	go func() {
 		ctx, cancel := context.WithCancel(context.Background())
-		for msg := range p.botChan {
+		for action := range p.botChan {
 			cancel()
 			ctx, cancel = context.WithCancel(context.Background())
-			go p.processMessage(ctx, msg)
+			if action != nil {
+				go p.act(ctx, g, action)
+			}
 		}
+		cancel()
 	}()
 }
 
-func sendBuzzAfter(ctx context.Context, g *Game, msg Message, passDelay, buzzDelay time.Duration) {
-	ticker := time.NewTicker(1 * time.Second)
+func (p *Bot) stop() {
+	if !p.stopped {
+		p.stopped = true
+		close(p.botChan)
+	}
+}
+
+func (p *Bot) act(ctx context.Context, g *Game, action *botAction) {
+	if action.buzz {
+		p.sendBuzzAfter(ctx, g, action.msg, botPassTimeout, action.delay)
+	} else {
+		p.sendMessageAfter(ctx, g, action.msg, action.delay)
+	}
+}
+
+func (p *Bot) sendBuzzAfter(ctx context.Context, g *Game, msg Message, passDelay, buzzDelay time.Duration) {
+	tick := p.clock.After(1 * time.Second)
 	secondsSinceHumansPassed := 0
-	passDelayTimeout := time.After(passDelay)
-	buzzDelayTimeout := time.After(buzzDelay)
+	passDelayTimeout := p.clock.After(passDelay)
+	buzzDelayTimeout := p.clock.After(buzzDelay)
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case <-passDelayTimeout:
 			if msg.IsPass {
-				g.msgChan <- msg
+				g.send(msg)
 				return
 			}
 		case <-buzzDelayTimeout:
-			g.msgChan <- msg
+			g.send(msg)
 			return
-		case <-ticker.C:
-			humanPasses := 0
-			for _, player := range g.Players {
-				if !player.isBot() && !player.canBuzz() {
-					humanPasses++
-				}
+		case <-tick:
+			tick = p.clock.After(1 * time.Second)
+			humansPassed := false
+			if err := g.do(func() error {
+				humansPassed = g.humansPassed()
+				return nil
+			}); err != nil {
+				return
 			}
-			if humanPasses == g.numHumans() {
+			if humansPassed {
 				secondsSinceHumansPassed++
 			}
 			if secondsSinceHumansPassed > 3 {
-				g.msgChan <- msg
+				g.send(msg)
 				return
 			}
 		}
 	}
 }
 
-func sendMessageAfter(ctx context.Context, g *Game, msg Message, delay time.Duration) {
+func (p *Bot) sendMessageAfter(ctx context.Context, g *Game, msg Message, delay time.Duration) {
 	select {
 	case <-ctx.Done():
 		return
-	case <-time.After(delay):
-		g.msgChan <- msg
+	case <-p.clock.After(delay):
+		g.send(msg)
 	}
 }
 
-func (p *Bot) processMessage(ctx context.Context, resp Response) {
-	g := resp.Game
+func (p *Bot) processMessage(g *Game) *botAction {
 	if g.Paused {
-		return
+		return nil
 	}
 	msg := Message{
 		Player: p,
@@ -116,9 +177,9 @@ func (p *Bot) processMessage(ctx context.Context, resp Response) {
 	switch g.State {
 	case RecvPick:
 		if !p.canPick() {
-			return
+			return nil
 		}
-		msg.CatIdx, msg.ValIdx = g.nextQuestionInCategory()
+		msg.CatIdx, msg.ValIdx = p.strategy.Pick(g, p.rng)
 		wrongAnswer := false
 		for _, ans := range g.CurQuestion.Answers {
 			if !ans.Correct {
@@ -131,63 +192,55 @@ func (p *Bot) processMessage(ctx context.Context, resp Response) {
 			timeout = 10 * time.Second
 		}
 		timeout = min(timeout, time.Duration(g.PickTimeout-1)*time.Second)
-		sendMessageAfter(ctx, g, msg, timeout)
+		return &botAction{msg: msg, delay: timeout}
 	case RecvBuzz:
 		if !p.canBuzz() {
-			return
+			return nil
+		}
+		msg.IsPass = !p.strategy.Buzz(g, p.rng)
+		delay := botBuzzTimeout
+		if !msg.IsPass {
+			delay = p.strategy.ReactionTime(p.rng)
 		}
-		scores := sortScores(g.Players)
-		msg.IsPass = p.score() != scores[len(scores)-1]
-		buzzTimeout := min(botBuzzTimeout, time.Duration(g.BuzzTimeout-1)*time.Second)
-		sendBuzzAfter(ctx, g, msg, botPassTimeout, buzzTimeout)
+		delay = min(delay, time.Duration(g.BuzzTimeout-1)*time.Second)
+		return &botAction{msg: msg, delay: delay, buzz: true}
 	case RecvAns:
 		if !p.canAnswer() {
-			return
+			return nil
 		}
-		msg.Answer = g.CurQuestion.Answer
+		msg.Answer, msg.Choice = p.strategy.Answer(g, p.rng)
 		timeout := botAnswerTimeout
 		if g.CurQuestion.DailyDouble {
 			timeout = botDDAnsTimeout
 		}
 		timeout = min(timeout, time.Duration(g.AnswerTimeout-1)*time.Second)
-		sendMessageAfter(ctx, g, msg, timeout)
+		return &botAction{msg: msg, delay: timeout}
 	case RecvWager:
 		if !p.canWager() {
-			return
+			return nil
 		}
-		msg.Wager = p.pickWager(g.Players, g.roundMax())
+		msg.Wager = p.pickWager(g)
 		timeout := min(botWagerTimeout, time.Duration(g.WagerTimeout-1)*time.Second)
-		sendMessageAfter(ctx, g, msg, timeout)
+		return &botAction{msg: msg, delay: timeout}
 	case RecvDispute:
 		if !p.canDispute() {
-			return
+			return nil
 		}
 		msg.Dispute = true
-		sendMessageAfter(ctx, g, msg, botDisputeTimeout)
+		return &botAction{msg: msg, delay: botDisputeTimeout}
 	case PostGame:
 		p.setPlayAgain(true)
-	case PreGame, BoardIntro:
-		return
+	case PreGame, BoardIntro, RecvJudgment:
 	}
+	return nil
 }
 
-func (p *Bot) pickWager(players []GamePlayer, roundMax int) int {
-	scores := sortScores(players)
-	if p.score() == scores[0] {
-		return max(p.score()-max(scores[1], 0), roundMax)
-	}
-	return max(min(scores[0]-p.score(), p.score()), roundMax)
-}
-
-func sortScores(players []GamePlayer) []int {
-	scores := []int{}
-	for _, player := range players {
-		scores = append(scores, player.score())
-	}
-	sort.Slice(scores, func(i, j int) bool {
-		return scores[i] > scores[j]
-	})
-	return scores
+// pickWager wagers what the wagering engine advises, within what the game
+// allows.
+func (p *Bot) pickWager(g *Game) int {
+	advice := AdviseWager(g.wagerSituation(p))
+	minWager, maxWager, _ := g.validWager(advice.Wager, p.score())
+	return min(max(advice.Wager, minWager), maxWager)
 }
 
 func (p *Bot) copyState(player GamePlayer) {
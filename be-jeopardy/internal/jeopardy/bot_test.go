@@ -3,35 +3,45 @@ package jeopardy
 import (
 	"context"
 	"fmt"
+	"math/rand/v2"
 	"testing"
+	"time"
 
-	"github.com/rileythomp/jeopardy/be-jeopardy/internal/db"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestPickWager(t *testing.T) {
+	config, err := NewConfig(true, true, 0, 30, 30, 30, 30, nil, nil)
+	assert.NoError(t, err)
+	g := newGame(newTestDB(t), config, WithSeed(1))
+	assert.NoError(t, g.setQuestions(context.Background()))
+	pickQuestion(g, 0, 0)
 
+	// dailyDouble is wagered with the rest of both rounds to play for, and
+	// final once the board is done
 	tests := []struct {
-		score1 int
-		score2 int
-		score3 int
-		want   int
+		score1      int
+		score2      int
+		score3      int
+		dailyDouble int
+		final       int
 	}{
-		{1000, 2000, 5000, 3000},
-		{1000, 4000, 5000, 1000},
-		{0, 100, 400, 1000},
-		{200, 400, 500, 1000},
-		{-1000, -2000, 5000, 5000},
-		{1000, 5000, 5000, 1000},
-		{1000, 5000, 2000, 2000},
-		{1000, 5000, 4000, 1000},
-		{100, 500, 400, 1000},
-		{100, 500, 200, 1000},
-		{5000, 5000, 5000, 1000},
-		{3000, 5000, 2000, 2000},
-		{4000, 5000, 3000, 2000},
-		{200, 500, 100, 1000},
-		{400, 500, 300, 1000},
+		{1000, 2000, 5000, 2999, 999},
+		{1000, 4000, 5000, 999, 3001},
+		{0, 100, 400, 299, 199},
+		{200, 400, 500, 99, 301},
+		{-1000, -2000, 5000, 4999, 4999},
+		{1000, 5000, 5000, 5000, 5000},
+		{1000, 5000, 2000, 2000, 0},
+		{1000, 5000, 4000, 4000, 2000},
+		{100, 500, 400, 1000, 200},
+		{100, 500, 200, 1000, 0},
+		{5000, 5000, 5000, 5000, 5000},
+		{3000, 5000, 2000, 2000, 2000},
+		{4000, 5000, 3000, 3000, 1000},
+		{200, 500, 100, 1000, 0},
+		{400, 500, 300, 1000, 100},
+		{1000, 2000, -100, 1000, 0},
 	}
 	for i, tc := range tests {
 		t.Run(fmt.Sprintf("test %d", i), func(t *testing.T) {
@@ -39,11 +49,25 @@ func TestPickWager(t *testing.T) {
 			p1.addToScore(tc.score1)
 			p2.addToScore(tc.score2)
 			bot.addToScore(tc.score3)
-			players := []GamePlayer{p1, p2, bot}
-			wager := bot.pickWager(players, 1000)
-			assert.Equal(t, tc.want, wager)
+			g.Players = []GamePlayer{p1, p2, bot}
+
+			g.Round = FirstRound
+			assert.Equal(t, tc.dailyDouble, bot.pickWager(g), "daily double")
+			g.Round = FinalRound
+			assert.Equal(t, tc.final, bot.pickWager(g), "final")
 		})
 	}
+
+	t.Run("test the situation the bot wagers in", func(t *testing.T) {
+		p1, p2, bot := NewPlayer("a", "", ""), NewPlayer("b", "", ""), NewBot("c", 0)
+		p1.addToScore(1000)
+		p2.addToScore(2000)
+		bot.addToScore(5000)
+		g.Players = []GamePlayer{p1, p2, bot}
+		g.Round = FirstRound
+		s := g.wagerSituation(bot)
+		assert.Equal(t, WagerSituation{Score: 5000, Opponents: []int{1000, 2000}, RoundMax: 1000, BoardLeft: 18000 - 200 + 36000}, s)
+	})
 }
 
 func pickQuestion(g *Game, catIdx, valIdx int) {
@@ -54,10 +78,7 @@ func pickQuestion(g *Game, catIdx, valIdx int) {
 func TestPickQuestion(t *testing.T) {
 	t.Run("test pick question", func(t *testing.T) {
 		ctx := context.Background()
-		questionDB, err := db.NewJeopardyDB(ctx)
-		if err != nil {
-			t.Fatalf("Failed to create questionDB: %s", err)
-		}
+		questionDB := newTestDB(t)
 		config, err := NewConfig(true, true, 0, 30, 30, 30, 30, nil, nil)
 		if err != nil {
 			t.Fatalf("Failed to create config: %s", err)
@@ -94,3 +115,111 @@ func TestPickQuestion(t *testing.T) {
 		}
 	})
 }
+
+func TestBotStrategies(t *testing.T) {
+	ctx := context.Background()
+	newBotGame := func(t *testing.T, multipleChoice bool, botLevels []string) *Game {
+		config := GameConfig{FullGame: true, Penalty: true, MultipleChoice: multipleChoice, PickTimeout: 30, BuzzTimeout: 30, AnswerTimeout: 30, WagerTimeout: 30, BotLevels: botLevels}
+		err := config.Validate()
+		if err != nil {
+			t.Fatalf("Failed to create config: %s", err)
+		}
+		game, err := NewGame(ctx, newTestDB(t), config, WithSeed(1))
+		if err != nil {
+			t.Fatalf("Failed to create game: %s", err)
+		}
+		return game
+	}
+
+	t.Run("test harder levels buzz more, react quicker and answer better", func(t *testing.T) {
+		game := newBotGame(t, false, nil)
+		game.CurQuestion = game.FirstRound[0].Questions[0]
+		rng := rand.New(rand.NewPCG(1, 2))
+		prevBuzzes, prevCorrect, prevReaction := 0, 0, time.Hour
+		for _, level := range []string{EasyBot, MediumBot, HardBot, ChampionBot} {
+			strategy := botStrategies[level]
+			buzzes, correct, reaction := 0, 0, time.Duration(0)
+			for i := 0; i < 2000; i++ {
+				if strategy.Buzz(game, rng) {
+					buzzes++
+				}
+				if answer, _ := strategy.Answer(game, rng); game.CurQuestion.checkAnswer(answer) {
+					correct++
+				}
+				r := strategy.ReactionTime(rng)
+				assert.GreaterOrEqual(t, r, minBotReaction)
+				reaction += r / 2000
+			}
+			assert.Greater(t, buzzes, prevBuzzes, level)
+			assert.Greater(t, correct, prevCorrect, level)
+			assert.Less(t, reaction, prevReaction, level)
+			prevBuzzes, prevCorrect, prevReaction = buzzes, correct, reaction
+		}
+	})
+
+	t.Run("test wrong answers are plausible", func(t *testing.T) {
+		wrong := levelStrategy{accuracy: 0}
+		rng := rand.New(rand.NewPCG(1, 2))
+
+		game := newBotGame(t, false, nil)
+		q := game.FirstRound[0].Questions[0]
+		game.CurQuestion = q
+		q.Incorrect = []string{"Franz Kafka", q.Answer}
+		answer, _ := wrong.Answer(game, rng)
+		assert.Equal(t, "Franz Kafka", answer)
+
+		q.Incorrect = []string{}
+		others := []string{}
+		for _, other := range game.FirstRound[0].Questions[1:] {
+			others = append(others, other.Answer)
+		}
+		answer, _ = wrong.Answer(game, rng)
+		assert.Contains(t, others, answer)
+
+		game = newBotGame(t, true, nil)
+		game.CurQuestion = game.FirstRound[0].Questions[0]
+		answer, choice := wrong.Answer(game, rng)
+		assert.NotEqual(t, game.CurQuestion.correctChoice(), choice)
+		assert.Equal(t, game.CurQuestion.Choices[choice], answer)
+	})
+
+	t.Run("test each level picks clues its own way", func(t *testing.T) {
+		game := newBotGame(t, false, nil)
+		rng := rand.New(rand.NewPCG(1, 2))
+		for _, category := range game.FirstRound {
+			for _, q := range category.Questions {
+				q.DailyDouble = false
+			}
+		}
+		pickQuestion(game, 1, 4)
+
+		catIdx, valIdx := botStrategies[EasyBot].Pick(game, rng)
+		assert.True(t, game.FirstRound[catIdx].Questions[valIdx].CanChoose)
+		// medium bots go down the category that was just picked from
+		catIdx, valIdx = botStrategies[MediumBot].Pick(game, rng)
+		assert.Equal(t, []int{1, 0}, []int{catIdx, valIdx})
+		catIdx, valIdx = botStrategies[HardBot].Pick(game, rng)
+		assert.Equal(t, []int{0, 4}, []int{catIdx, valIdx})
+		// the fourth row has had the most Daily Doubles
+		catIdx, valIdx = botStrategies[ChampionBot].Pick(game, rng)
+		assert.Equal(t, []int{0, 3}, []int{catIdx, valIdx})
+
+		game.FirstRound[2].Questions[3].DailyDouble = true
+		pickQuestion(game, 2, 3)
+		catIdx, valIdx = botStrategies[ChampionBot].Pick(game, rng)
+		assert.Equal(t, []int{0, 4}, []int{catIdx, valIdx})
+	})
+
+	t.Run("test bots play at their configured level", func(t *testing.T) {
+		config := GameConfig{FullGame: true, Penalty: true, Bots: 2, BotLevels: []string{"expert"}, PickTimeout: 30, BuzzTimeout: 30, AnswerTimeout: 30, WagerTimeout: 30}
+		assert.EqualError(t, config.Validate(), "Bot level must be easy, medium, hard, champion or contestant, got: expert")
+
+		game := newBotGame(t, false, []string{ChampionBot, ""})
+		champion, medium := game.addBot(0), game.addBot(1)
+		assert.Equal(t, ChampionBot, champion.Level)
+		assert.Equal(t, MediumBot, medium.Level)
+
+		restored := game.restorePlayer(snapshotPlayer(champion)).(*Bot)
+		assert.Equal(t, ChampionBot, restored.Level)
+	})
+}
@@ -0,0 +1,242 @@
+package jeopardy
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// BotStrategy decides how a bot plays: which clues it picks, when it buzzes
+// in, how quickly and what it answers. Strategies read the game on its
+// event loop and draw from the bot's random number generator.
+type BotStrategy interface {
+	// Pick chooses the next clue of the current round.
+	Pick(g *Game, rng *rand.Rand) (catIdx, valIdx int)
+	// Buzz is whether the bot buzzes in on the current clue.
+	Buzz(g *Game, rng *rand.Rand) bool
+	// ReactionTime is how long the bot takes to buzz in.
+	ReactionTime(rng *rand.Rand) time.Duration
+	// Answer is the bot's response to the current clue, with the index of
+	// its choice in a multiple choice game.
+	Answer(g *Game, rng *rand.Rand) (string, int)
+}
+
+const (
+	EasyBot     = "easy"
+	MediumBot   = "medium"
+	HardBot     = "hard"
+	ChampionBot = "champion"
+
+	minBotReaction = 500 * time.Millisecond
+)
+
+// levelStrategy plays at one of the difficulty levels, which differ in how
+// often the bot buzzes in, how often it is right, how quickly it reacts and
+// how it picks clues.
+type levelStrategy struct {
+	buzzChance     float64
+	accuracy       float64
+	reactionMean   time.Duration
+	reactionSpread time.Duration
+	pick           func(g *Game, rng *rand.Rand) (int, int)
+}
+
+var botStrategies = map[string]BotStrategy{
+	EasyBot: levelStrategy{
+		buzzChance:     0.3,
+		accuracy:       0.5,
+		reactionMean:   6 * time.Second,
+		reactionSpread: 2 * time.Second,
+		pick:           pickRandom,
+	},
+	MediumBot: levelStrategy{
+		buzzChance:     0.5,
+		accuracy:       0.7,
+		reactionMean:   4 * time.Second,
+		reactionSpread: 1500 * time.Millisecond,
+		pick:           pickInCategory,
+	},
+	HardBot: levelStrategy{
+		buzzChance:     0.7,
+		accuracy:       0.85,
+		reactionMean:   2500 * time.Millisecond,
+		reactionSpread: time.Second,
+		pick:           pickHighestValue,
+	},
+	ChampionBot: levelStrategy{
+		buzzChance:     0.85,
+		accuracy:       0.95,
+		reactionMean:   1500 * time.Millisecond,
+		reactionSpread: 500 * time.Millisecond,
+		pick:           pickDailyDoubles,
+	},
+	ContestantBot: contestantStrategy{},
+}
+
+func validBotLevel(level string) bool {
+	_, ok := botStrategies[level]
+	return ok
+}
+
+// botLevel is the level of the i-th bot in the game, medium for bots the
+// game wasn't configured with.
+func (g *Game) botLevel(i int) string {
+	if i < len(g.BotLevels) && g.BotLevels[i] != "" {
+		return g.BotLevels[i]
+	}
+	return MediumBot
+}
+
+func (s levelStrategy) Pick(g *Game, rng *rand.Rand) (int, int) {
+	return s.pick(g, rng)
+}
+
+func (s levelStrategy) Buzz(g *Game, rng *rand.Rand) bool {
+	return rng.Float64() < s.buzzChance
+}
+
+// ReactionTime is normally distributed around the level's mean, but never
+// quicker than a person could react.
+func (s levelStrategy) ReactionTime(rng *rand.Rand) time.Duration {
+	reaction := s.reactionMean + time.Duration(rng.NormFloat64()*float64(s.reactionSpread))
+	return max(reaction, minBotReaction)
+}
+
+func (s levelStrategy) Answer(g *Game, rng *rand.Rand) (string, int) {
+	q := g.CurQuestion
+	if rng.Float64() < s.accuracy {
+		return q.Answer, q.correctChoice()
+	}
+	return wrongAnswer(g, q, rng)
+}
+
+// wrongAnswer is a plausible wrong response: a wrong choice in a multiple
+// choice game, otherwise a response someone has given to the clue before,
+// or the answer to another clue in its category.
+func wrongAnswer(g *Game, q *Question, rng *rand.Rand) (string, int) {
+	if g.MultipleChoice {
+		wrong := []int{}
+		for i, choice := range q.Choices {
+			if choice != q.Answer {
+				wrong = append(wrong, i)
+			}
+		}
+		if len(wrong) == 0 {
+			return q.Answer, q.correctChoice()
+		}
+		i := wrong[rng.IntN(len(wrong))]
+		return q.Choices[i], i
+	}
+
+	candidates := []string{}
+	for _, incorrect := range q.Incorrect {
+		if !q.checkAnswer(incorrect) {
+			candidates = append(candidates, incorrect)
+		}
+	}
+	if len(candidates) == 0 {
+		for _, round := range [][]Category{g.FirstRound, g.SecondRound} {
+			for _, category := range round {
+				if category.Title != q.Category {
+					continue
+				}
+				for _, other := range category.Questions {
+					if !q.checkAnswer(other.Answer) {
+						candidates = append(candidates, other.Answer)
+					}
+				}
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return "", -1
+	}
+	return candidates[rng.IntN(len(candidates))], -1
+}
+
+// availableQuestions are the positions of the clues left in the round.
+func (g *Game) availableQuestions() [][2]int {
+	available := [][2]int{}
+	for catIdx, category := range g.curRound() {
+		for valIdx, q := range category.Questions {
+			if q.CanChoose {
+				available = append(available, [2]int{catIdx, valIdx})
+			}
+		}
+	}
+	return available
+}
+
+func (g *Game) curRound() []Category {
+	if g.Round == SecondRound {
+		return g.SecondRound
+	}
+	return g.FirstRound
+}
+
+func pickRandom(g *Game, rng *rand.Rand) (int, int) {
+	available := g.availableQuestions()
+	if len(available) == 0 {
+		return g.firstAvailableQuestion()
+	}
+	pick := available[rng.IntN(len(available))]
+	return pick[0], pick[1]
+}
+
+func pickInCategory(g *Game, rng *rand.Rand) (int, int) {
+	return g.nextQuestionInCategory()
+}
+
+// pickHighestValue goes for the most valuable clue left.
+func pickHighestValue(g *Game, rng *rand.Rand) (int, int) {
+	round := g.curRound()
+	best, bestCat, bestVal := -1, -1, -1
+	for _, pos := range g.availableQuestions() {
+		if value := round[pos[0]].Questions[pos[1]].Value; value > best {
+			best, bestCat, bestVal = value, pos[0], pos[1]
+		}
+	}
+	if bestCat == -1 {
+		return g.firstAvailableQuestion()
+	}
+	return bestCat, bestVal
+}
+
+// pickDailyDoubles hunts for the round's Daily Doubles in the rows they are
+// most often found in, then goes for the most valuable clues once they have
+// all been played.
+func pickDailyDoubles(g *Game, rng *rand.Rand) (int, int) {
+	round, roundIdx := g.curRound(), 0
+	if g.Round == SecondRound {
+		roundIdx = 1
+	}
+	dailyDoubles := g.DailyDoubles
+	if dailyDoubles == nil {
+		dailyDoubles = defaultDailyDoubles
+	}
+	played := 0
+	for _, category := range round {
+		for _, q := range category.Questions {
+			if q.DailyDouble && !q.CanChoose {
+				played++
+			}
+		}
+	}
+	if played >= dailyDoubles[roundIdx] {
+		return pickHighestValue(g, rng)
+	}
+
+	rowWeights := make([]int, g.Questions)
+	for row, weight := range dailyDoubleWeights[roundIdx] {
+		rowWeights[dailyDoubleRow(row, g.Questions)] += weight
+	}
+	best, bestCat, bestVal := -1, -1, -1
+	for _, pos := range g.availableQuestions() {
+		if weight := rowWeights[pos[1]]; weight > best {
+			best, bestCat, bestVal = weight, pos[0], pos[1]
+		}
+	}
+	if bestCat == -1 {
+		return g.firstAvailableQuestion()
+	}
+	return bestCat, bestVal
+}
@@ -21,30 +21,32 @@ func JoinGameChat(playerId string, conn SafeConn) error {
 		return err
 	}
 
-	player, err := game.getPlayerById(playerId)
-	if err != nil {
-		return err
-	}
-	if player.chatConn() != nil {
-		return fmt.Errorf("Player already in chat")
-	}
-	player.setChatConn(conn)
+	return game.do(func() error {
+		player, err := game.getMemberById(playerId)
+		if err != nil {
+			return err
+		}
+		if player.chatConn() != nil {
+			return fmt.Errorf("Player already in chat")
+		}
+		player.setChatConn(conn)
 
-	player.sendChatPings()
-	player.processChatMessages(game.chatChan)
+		player.sendChatPings()
+		player.processChatMessages(game.chatChan, game.done)
 
-	return nil
+		return nil
+	})
 }
 
-func (p *Player) processChatMessages(chatChan chan ChatMessage) {
+func (p *Player) processChatMessages(chatChan chan ChatMessage, done <-chan struct{}) {
 	go func() {
-		log.Infof("Starting to process chat messages for player %s", p.Name)
+		log.Infof("Starting to process chat messages for player %s", p.name())
 		for {
 			message, err := p.readChatMessage()
 			if err != nil {
-				log.Errorf("Error reading chat message from player %s: %s", p.Name, err.Error())
+				log.Errorf("Error reading chat message from player %s: %s", p.name(), err.Error())
 				if websocket.IsCloseError(err, 1001) {
-					log.Infof("Player %s closed chat connection", p.Name)
+					log.Infof("Player %s closed chat connection", p.name())
 				}
 				return
 			}
@@ -52,19 +54,24 @@ func (p *Player) processChatMessages(chatChan chan ChatMessage) {
 			if err := json.Unmarshal(message, &msg); err != nil {
 				log.Errorf("Error parsing chat message: %s", err.Error())
 			}
-			msg.PlayerName = p.Name
+			msg.PlayerName = p.name()
 			msg.TimeStamp = time.Now().Unix()
-			chatChan <- msg
+			select {
+			case chatChan <- msg:
+			case <-done:
+				return
+			}
 		}
 	}()
 }
 
 func (p *Player) readChatMessage() ([]byte, error) {
-	if p.ChatConn == nil {
-		log.Infof("Skipping reading chat message from player %s because connection is nil", p.Name)
-		return nil, fmt.Errorf("Player %s has no chat connection", p.Name)
+	conn := p.chatConn()
+	if conn == nil {
+		log.Infof("Skipping reading chat message from player %s because connection is nil", p.name())
+		return nil, fmt.Errorf("Player %s has no chat connection", p.name())
 	}
-	_, msg, err := p.ChatConn.ReadMessage()
+	_, msg, err := conn.ReadMessage()
 	if err != nil {
 		return nil, err
 	}
@@ -72,11 +79,12 @@ func (p *Player) readChatMessage() ([]byte, error) {
 }
 
 func (p *Player) sendChatMessage(msg ChatMessage) error {
-	if p.ChatConn == nil {
+	conn := p.chatConn()
+	if conn == nil {
 		return fmt.Errorf("player has no chat connection")
 	}
-	if err := p.ChatConn.WriteJSON(msg); err != nil {
-		log.Errorf("Error sending chat message to player %s: %s", p.Name, err.Error())
+	if err := conn.WriteJSON(msg); err != nil {
+		log.Errorf("Error sending chat message to player %s: %s", p.name(), err.Error())
 		return fmt.Errorf("error sending chat message to player")
 	}
 	return nil
@@ -84,7 +92,7 @@ func (p *Player) sendChatMessage(msg ChatMessage) error {
 
 func (p *Player) sendChatPings() {
 	go func() {
-		log.Infof("Starting to send chat pings to player %s", p.Name)
+		log.Infof("Starting to send chat pings to player %s", p.name())
 		pingErrors := 0
 		for {
 			select {
@@ -94,14 +102,15 @@ func (p *Player) sendChatPings() {
 					Message:    ping,
 					TimeStamp:  time.Now().Unix(),
 				}); err != nil {
-					if p.ChatConn == nil {
-						log.Infof("Stopping sending chat pings to player %s because connection is nil", p.Name)
+					conn := p.chatConn()
+					if conn == nil {
+						log.Infof("Stopping sending chat pings to player %s because connection is nil", p.name())
 						return
 					}
 					pingErrors++
 					if pingErrors >= 3 {
-						log.Infof("Too many chat ping errors, closing connection to player %s", p.Name)
-						if err := p.ChatConn.Close(); err != nil {
+						log.Infof("Too many chat ping errors, closing connection to player %s", p.name())
+						if err := conn.Close(); err != nil {
 							log.Errorf("Error closing connection: %s", err.Error())
 						}
 						return
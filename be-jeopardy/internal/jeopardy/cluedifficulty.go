@@ -0,0 +1,162 @@
+package jeopardy
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/db"
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/log"
+)
+
+type (
+	clueDifficultyReader interface {
+		GetClueDifficulty(ctx context.Context) ([]db.ClueDifficulty, error)
+	}
+
+	// clueRates are how often people answered the clues in a row of the
+	// board, and how often they were right when they did.
+	clueRates struct {
+		attempt  float64
+		accuracy float64
+	}
+
+	// contestantStrategy plays the way people have: it buzzes in and is
+	// right as often as people were on clues of the same round and row,
+	// and less often on clues people have gotten wrong before.
+	contestantStrategy struct{}
+)
+
+const (
+	ContestantBot = "contestant"
+
+	// minDifficultySamples is how many clues a row needs to have been shown,
+	// and answered, before its rates are read from analytics.
+	minDifficultySamples = 50
+
+	// incorrectWeight is how many answers the rates of a row count for
+	// against the wrong responses recorded for a clue. A clue with as many
+	// wrong responses as this is right half as often as its row.
+	incorrectWeight = 10
+
+	// finalJeopardyAccuracy is how often Final Jeopardy is answered right,
+	// which analytics doesn't record.
+	finalJeopardyAccuracy = 0.5
+)
+
+var (
+	clueDifficultyDB clueDifficultyReader
+
+	// clueDifficulty are the rates for each row of a standard board in each
+	// round until LoadClueDifficulty reads them from analytics. Higher rows
+	// are answered less often and less accurately.
+	clueDifficulty = [2][numQuestions]clueRates{
+		{{0.9, 0.92}, {0.85, 0.89}, {0.8, 0.86}, {0.72, 0.82}, {0.62, 0.77}},
+		{{0.85, 0.89}, {0.78, 0.85}, {0.7, 0.81}, {0.6, 0.76}, {0.5, 0.7}},
+	}
+
+	contestantReaction = levelStrategy{reactionMean: 3 * time.Second, reactionSpread: time.Second}
+)
+
+// LoadClueDifficulty reads how often people answered and were right on the
+// clues of each row from the games saved to analytics. Rows without enough
+// answers keep the built in rates.
+func LoadClueDifficulty(ctx context.Context) error {
+	if clueDifficultyDB == nil {
+		return nil
+	}
+	difficulty, err := clueDifficultyDB.GetClueDifficulty(ctx)
+	if err != nil {
+		return err
+	}
+	rates, loaded := clueDifficultyRates(clueDifficulty, difficulty)
+	if loaded == 0 {
+		log.Infof("Not enough answers in analytics, using the default clue difficulty")
+	}
+	clueDifficulty = rates
+	return nil
+}
+
+// clueDifficultyRates counts the answers to each row of a standard board,
+// where the clues of row i in round r are worth 200*r*(i+1), and replaces
+// the rates of rows with enough of them. It returns how many rates it
+// replaced.
+func clueDifficultyRates(rates [2][numQuestions]clueRates, difficulty []db.ClueDifficulty) ([2][numQuestions]clueRates, int) {
+	counts := [2][numQuestions]db.ClueDifficulty{}
+	for _, d := range difficulty {
+		if d.Round != 1 && d.Round != 2 {
+			continue
+		}
+		step := 200 * d.Round
+		if d.Value%step != 0 || d.Value/step < 1 || d.Value/step > numQuestions {
+			continue
+		}
+		c := &counts[d.Round-1][d.Value/step-1]
+		c.Shown += d.Shown
+		c.Answered += d.Answered
+		c.Answers += d.Answers
+		c.Correct += d.Correct
+	}
+	loaded := 0
+	for round := range counts {
+		for row, c := range counts[round] {
+			if c.Shown >= minDifficultySamples {
+				rates[round][row].attempt = float64(c.Answered) / float64(c.Shown)
+				loaded++
+			}
+			if c.Answers >= minDifficultySamples {
+				rates[round][row].accuracy = float64(c.Correct) / float64(c.Answers)
+				loaded++
+			}
+		}
+	}
+	return rates, loaded
+}
+
+// curClueRates are the rates for the current clue, with its accuracy
+// lowered by the wrong responses it has had.
+func (g *Game) curClueRates() clueRates {
+	q := g.CurQuestion
+	rates := clueRates{attempt: 1, accuracy: finalJeopardyAccuracy}
+	if g.Round != FinalRound {
+		roundIdx := 0
+		if g.Round == SecondRound {
+			roundIdx = 1
+		}
+		rates = clueDifficulty[roundIdx][g.standardRow(q)]
+	}
+	rates.accuracy *= incorrectWeight / float64(incorrectWeight+len(q.Incorrect))
+	return rates
+}
+
+// standardRow is the row of a standard five clue category a clue is in,
+// so the rates of standard boards apply to boards of any size.
+func (g *Game) standardRow(q *Question) int {
+	for _, category := range g.curRound() {
+		for valIdx, other := range category.Questions {
+			if other == q {
+				return valIdx * numQuestions / len(category.Questions)
+			}
+		}
+	}
+	return 0
+}
+
+func (contestantStrategy) Pick(g *Game, rng *rand.Rand) (int, int) {
+	return pickInCategory(g, rng)
+}
+
+func (contestantStrategy) Buzz(g *Game, rng *rand.Rand) bool {
+	return rng.Float64() < g.curClueRates().attempt
+}
+
+func (contestantStrategy) ReactionTime(rng *rand.Rand) time.Duration {
+	return contestantReaction.ReactionTime(rng)
+}
+
+func (contestantStrategy) Answer(g *Game, rng *rand.Rand) (string, int) {
+	if rng.Float64() < g.curClueRates().accuracy {
+		return g.CurQuestion.Answer, g.CurQuestion.correctChoice()
+	}
+	return wrongAnswer(g, g.CurQuestion, rng)
+}
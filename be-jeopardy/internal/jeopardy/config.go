@@ -9,7 +9,15 @@ import (
 type GameConfig struct {
 	FullGame bool `json:"fullGame"`
 	Penalty  bool `json:"penalty"`
-	Bots     int  `json:"bots"`
+	HostMode bool `json:"hostMode"`
+	TeamMode bool `json:"teamMode"`
+	// MultipleChoice offers each clue's correct response among three
+	// incorrect ones, and a wrong choice costs part of the clue's value.
+	MultipleChoice bool `json:"multipleChoice"`
+	Bots           int  `json:"bots"`
+	// BotLevels are the difficulties of the game's bots in the order they
+	// join, medium for any bot without one.
+	BotLevels []string `json:"botLevels"`
 
 	PickTimeout        int `json:"pickTimeout"`
 	BuzzTimeout        int `json:"buzzTimeout"`
@@ -19,8 +27,29 @@ type GameConfig struct {
 	FinalAnswerTimeout int `json:"finalAnswerTimeout"`
 	DisputeTimeout     int `json:"disputeTimeout"`
 
+	// Categories and Questions are the number of categories in each round
+	// and clues in each category. The value ladders replace the values of
+	// the clues when they are set.
+	Categories        int   `json:"categories"`
+	Questions         int   `json:"questions"`
+	FirstRoundValues  []int `json:"firstRoundValues"`
+	SecondRoundValues []int `json:"secondRoundValues"`
+
+	// DailyDoubles is the number of Daily Doubles in each round, one in the
+	// first and two in the second when it isn't set.
+	DailyDoubles         []int  `json:"dailyDoubles"`
+	DailyDoublePlacement string `json:"dailyDoublePlacement"`
+
 	FirstRoundCategories  []db.Category `json:"firstRoundCategories"`
 	SecondRoundCategories []db.Category `json:"secondRoundCategories"`
+
+	// CustomBoard is the id of the custom board the game is played on in
+	// place of clues from the database.
+	CustomBoard string `json:"customBoard"`
+
+	// DailyChallenge is the day whose board the game is played on, which
+	// is the same for everyone.
+	DailyChallenge string `json:"dailyChallenge"`
 }
 
 func NewConfig(
@@ -28,28 +57,7 @@ func NewConfig(
 	pickTimeout, buzzTimeout, answerTimeout, wagerTimeout int,
 	firstRoundCategories, secondRoundCategories []db.Category,
 ) (GameConfig, error) {
-	if bots < 0 || bots > maxPlayers-1 {
-		return GameConfig{}, fmt.Errorf("Bots must be between 0 and %d, got: %d", maxPlayers-1, bots)
-	}
-	if pickTimeout < 3 || pickTimeout > 60 {
-		return GameConfig{}, fmt.Errorf("Pick timeout must be between 3 and 60 seconds, got: %d", pickTimeout)
-	}
-	if buzzTimeout < 3 || buzzTimeout > 60 {
-		return GameConfig{}, fmt.Errorf("Buzz timeout must be between 3 and 60 seconds, got: %d", buzzTimeout)
-	}
-	if answerTimeout < 3 || answerTimeout > 60 {
-		return GameConfig{}, fmt.Errorf("Answer timeout must be between 3 and 60 seconds, got: %d", answerTimeout)
-	}
-	if wagerTimeout < 3 || wagerTimeout > 60 {
-		return GameConfig{}, fmt.Errorf("Wager timeout must be between 3 and 60 seconds, got: %d", wagerTimeout)
-	}
-	if len(firstRoundCategories) > 6 {
-		return GameConfig{}, fmt.Errorf("First round cannot have more than 6 categories, got: %d", len(firstRoundCategories))
-	}
-	if len(secondRoundCategories) > 6 {
-		return GameConfig{}, fmt.Errorf("Second round cannot have more than 6 categories, got: %d", len(secondRoundCategories))
-	}
-	return GameConfig{
+	config := GameConfig{
 		FullGame:              fullGame,
 		Penalty:               penalty,
 		Bots:                  bots,
@@ -57,10 +65,110 @@ func NewConfig(
 		BuzzTimeout:           buzzTimeout,
 		AnswerTimeout:         answerTimeout,
 		WagerTimeout:          wagerTimeout,
-		FinalWagerTimeout:     30,
-		FinalAnswerTimeout:    30,
-		DisputeTimeout:        60,
 		FirstRoundCategories:  firstRoundCategories,
 		SecondRoundCategories: secondRoundCategories,
-	}, nil
+	}
+	if err := config.Validate(); err != nil {
+		return GameConfig{}, err
+	}
+	return config, nil
+}
+
+// Validate checks the config can be played and fills in the defaults for
+// the board size, Daily Double placement and Final Jeopardy and dispute
+// timeouts when they aren't set.
+func (c *GameConfig) Validate() error {
+	if c.Bots < 0 || c.Bots > maxPlayers-1 {
+		return fmt.Errorf("Bots must be between 0 and %d, got: %d", maxPlayers-1, c.Bots)
+	}
+	if len(c.BotLevels) > maxPlayers-1 {
+		return fmt.Errorf("Bot levels cannot be given for more than %d bots, got: %d", maxPlayers-1, len(c.BotLevels))
+	}
+	for _, level := range c.BotLevels {
+		if level != "" && !validBotLevel(level) {
+			return fmt.Errorf("Bot level must be %s, %s, %s, %s or %s, got: %s", EasyBot, MediumBot, HardBot, ChampionBot, ContestantBot, level)
+		}
+	}
+	if c.PickTimeout < 3 || c.PickTimeout > 60 {
+		return fmt.Errorf("Pick timeout must be between 3 and 60 seconds, got: %d", c.PickTimeout)
+	}
+	if c.BuzzTimeout < 3 || c.BuzzTimeout > 60 {
+		return fmt.Errorf("Buzz timeout must be between 3 and 60 seconds, got: %d", c.BuzzTimeout)
+	}
+	if c.AnswerTimeout < 3 || c.AnswerTimeout > 60 {
+		return fmt.Errorf("Answer timeout must be between 3 and 60 seconds, got: %d", c.AnswerTimeout)
+	}
+	if c.WagerTimeout < 3 || c.WagerTimeout > 60 {
+		return fmt.Errorf("Wager timeout must be between 3 and 60 seconds, got: %d", c.WagerTimeout)
+	}
+	if c.Categories == 0 {
+		c.Categories = numCategories
+	}
+	if c.Questions == 0 {
+		c.Questions = numQuestions
+	}
+	if c.Categories < 2 || c.Categories > maxCategories {
+		return fmt.Errorf("Categories must be between 2 and %d, got: %d", maxCategories, c.Categories)
+	}
+	if c.Questions < 2 || c.Questions > maxQuestions {
+		return fmt.Errorf("Questions must be between 2 and %d, got: %d", maxQuestions, c.Questions)
+	}
+	if len(c.FirstRoundCategories) > c.Categories {
+		return fmt.Errorf("First round cannot have more than %d categories, got: %d", c.Categories, len(c.FirstRoundCategories))
+	}
+	if len(c.SecondRoundCategories) > c.Categories {
+		return fmt.Errorf("Second round cannot have more than %d categories, got: %d", c.Categories, len(c.SecondRoundCategories))
+	}
+	if err := validateValues(c.FirstRoundValues, c.Questions); err != nil {
+		return fmt.Errorf("Invalid first round values: %w", err)
+	}
+	if err := validateValues(c.SecondRoundValues, c.Questions); err != nil {
+		return fmt.Errorf("Invalid second round values: %w", err)
+	}
+	if c.DailyDoubles != nil {
+		if len(c.DailyDoubles) != 2 {
+			return fmt.Errorf("Daily Doubles must be given for 2 rounds, got: %d", len(c.DailyDoubles))
+		}
+		for _, n := range c.DailyDoubles {
+			if n < 0 || n > c.Categories*c.Questions {
+				return fmt.Errorf("Daily Doubles must be between 0 and %d, got: %d", c.Categories*c.Questions, n)
+			}
+		}
+	}
+	if c.DailyDoublePlacement == "" {
+		c.DailyDoublePlacement = HistoricalPlacement
+	}
+	if c.DailyDoublePlacement != HistoricalPlacement && c.DailyDoublePlacement != RandomPlacement {
+		return fmt.Errorf("Daily Double placement must be %s or %s, got: %s", HistoricalPlacement, RandomPlacement, c.DailyDoublePlacement)
+	}
+	if c.FinalWagerTimeout == 0 {
+		c.FinalWagerTimeout = 30
+	}
+	if c.FinalAnswerTimeout == 0 {
+		c.FinalAnswerTimeout = 30
+	}
+	if c.DisputeTimeout == 0 {
+		c.DisputeTimeout = 60
+	}
+	return nil
+}
+
+// validateValues checks a value ladder has a positive value for each clue,
+// from lowest to highest. An empty ladder keeps the values of the clues.
+func validateValues(values []int, questions int) error {
+	if len(values) == 0 {
+		return nil
+	}
+	if len(values) != questions {
+		return fmt.Errorf("expected %d values, got: %d", questions, len(values))
+	}
+	for i, v := range values {
+		if v <= 0 || v > 100000 {
+			return fmt.Errorf("values must be between 1 and 100000, got: %d", v)
+		}
+		if i > 0 && v < values[i-1] {
+			return fmt.Errorf("values must be in increasing order")
+		}
+	}
+	return nil
 }
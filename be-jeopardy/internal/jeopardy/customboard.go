@@ -0,0 +1,305 @@
+package jeopardy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/db"
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/log"
+)
+
+type (
+	customBoardStore interface {
+		AddCustomBoard(ctx context.Context, b db.CustomBoard) error
+		UpdateCustomBoard(ctx context.Context, b db.CustomBoard) (db.CustomBoard, error)
+		GetCustomBoard(ctx context.Context, id string) (db.CustomBoard, error)
+		GetCustomBoards(ctx context.Context, owner string) ([]db.CustomBoard, error)
+		DeleteCustomBoard(ctx context.Context, id, owner string) error
+	}
+
+	// customBoardGetter is what games load their custom board from.
+	customBoardGetter interface {
+		GetCustomBoard(ctx context.Context, id string) (db.CustomBoard, error)
+	}
+
+	// CustomBoardRequest is a board along with the email of the signed in
+	// user writing it.
+	CustomBoardRequest struct {
+		Email string `json:"-"`
+		db.CustomBoard
+	}
+
+	CustomBoardValidation struct {
+		Valid    bool     `json:"valid"`
+		Problems []string `json:"problems"`
+	}
+)
+
+const (
+	maxBoardNameLength = 100
+	maxClueLength      = 500
+	maxAlternatives    = 10
+)
+
+var (
+	customBoardDB    customBoardStore
+	customBoardClock Clock = realClock{}
+)
+
+func CreateCustomBoard(ctx context.Context, req CustomBoardRequest) (db.CustomBoard, error) {
+	board, err := customBoard(req)
+	if err != nil {
+		return db.CustomBoard{}, err
+	}
+	board.Id = uuid.NewString()
+	board.CreatedAt = customBoardClock.Now()
+	board.UpdatedAt = board.CreatedAt
+	if err := customBoardDB.AddCustomBoard(ctx, board); err != nil {
+		log.Errorf("Error adding custom board: %s", err.Error())
+		return db.CustomBoard{}, fmt.Errorf("Error saving board")
+	}
+	return board, nil
+}
+
+func UpdateCustomBoard(ctx context.Context, id string, req CustomBoardRequest) (db.CustomBoard, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return db.CustomBoard{}, fmt.Errorf("No board with id %s", id)
+	}
+	board, err := customBoard(req)
+	if err != nil {
+		return db.CustomBoard{}, err
+	}
+	board.Id = id
+	board.UpdatedAt = customBoardClock.Now()
+	board, err = customBoardDB.UpdateCustomBoard(ctx, board)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.CustomBoard{}, fmt.Errorf("No board with id %s", id)
+	}
+	if err != nil {
+		log.Errorf("Error updating custom board: %s", err.Error())
+		return db.CustomBoard{}, fmt.Errorf("Error saving board")
+	}
+	return board, nil
+}
+
+// GetCustomBoard returns a board to share. Only its owner sees the answers.
+func GetCustomBoard(ctx context.Context, id, email string) (db.CustomBoard, error) {
+	board, err := getCustomBoard(ctx, customBoardDB, id)
+	if err != nil {
+		return db.CustomBoard{}, err
+	}
+	if email == "" || email != board.Owner {
+		board = withoutAnswers(board)
+	}
+	return board, nil
+}
+
+func GetCustomBoards(ctx context.Context, email string) ([]db.CustomBoard, error) {
+	if email == "" {
+		return nil, fmt.Errorf("Email is required")
+	}
+	boards, err := customBoardDB.GetCustomBoards(ctx, email)
+	if err != nil {
+		log.Errorf("Error getting custom boards: %s", err.Error())
+		return nil, fmt.Errorf("Error getting boards")
+	}
+	return boards, nil
+}
+
+func DeleteCustomBoard(ctx context.Context, id, email string) error {
+	if email == "" {
+		return fmt.Errorf("Email is required")
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return fmt.Errorf("No board with id %s", id)
+	}
+	err := customBoardDB.DeleteCustomBoard(ctx, id, email)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("No board with id %s", id)
+	}
+	if err != nil {
+		log.Errorf("Error deleting custom board: %s", err.Error())
+		return fmt.Errorf("Error deleting board")
+	}
+	return nil
+}
+
+// ValidateCustomBoard lists what needs fixing before a board can be saved.
+func ValidateCustomBoard(board db.CustomBoard) CustomBoardValidation {
+	problems := boardProblems(board)
+	return CustomBoardValidation{Valid: len(problems) == 0, Problems: problems}
+}
+
+func customBoard(req CustomBoardRequest) (db.CustomBoard, error) {
+	if req.Email == "" {
+		return db.CustomBoard{}, fmt.Errorf("Email is required")
+	}
+	if problems := boardProblems(req.CustomBoard); len(problems) > 0 {
+		return db.CustomBoard{}, fmt.Errorf("Invalid board: %s", strings.Join(problems, "; "))
+	}
+	board := req.CustomBoard
+	board.Owner = req.Email
+	return board, nil
+}
+
+func getCustomBoard(ctx context.Context, store customBoardGetter, id string) (db.CustomBoard, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return db.CustomBoard{}, fmt.Errorf("No board with id %s", id)
+	}
+	board, err := store.GetCustomBoard(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.CustomBoard{}, fmt.Errorf("No board with id %s", id)
+	}
+	if err != nil {
+		log.Errorf("Error getting custom board: %s", err.Error())
+		return db.CustomBoard{}, fmt.Errorf("Error getting board")
+	}
+	return board, nil
+}
+
+// boardProblems checks a board can be played: both rounds have the same
+// number of categories with the same number of clues, or there is only a
+// first round, and there is a Final Jeopardy clue.
+func boardProblems(board db.CustomBoard) []string {
+	problems := []string{}
+	if name := strings.TrimSpace(board.Name); name == "" || len(name) > maxBoardNameLength {
+		problems = append(problems, fmt.Sprintf("Name must be between 1 and %d characters", maxBoardNameLength))
+	}
+
+	if n := len(board.FirstRound); n < 2 || n > maxCategories {
+		problems = append(problems, fmt.Sprintf("First round must have between 2 and %d categories, got: %d", maxCategories, n))
+	}
+	if n := len(board.SecondRound); n != 0 && n != len(board.FirstRound) {
+		problems = append(problems, fmt.Sprintf("Second round must have no categories or as many as the first round, got: %d", n))
+	}
+	clues := 0
+	if len(board.FirstRound) > 0 {
+		clues = len(board.FirstRound[0].Clues)
+	}
+	if clues < 2 || clues > maxQuestions {
+		problems = append(problems, fmt.Sprintf("Categories must have between 2 and %d clues, got: %d", maxQuestions, clues))
+	}
+
+	seen := map[string]bool{}
+	checkClue := func(where string, clue db.CustomClue) {
+		switch {
+		case strings.TrimSpace(clue.Clue) == "":
+			problems = append(problems, fmt.Sprintf("%s is missing its clue", where))
+		case len(clue.Clue) > maxClueLength:
+			problems = append(problems, fmt.Sprintf("%s is longer than %d characters", where, maxClueLength))
+		case seen[strings.ToLower(clue.Clue)]:
+			problems = append(problems, fmt.Sprintf("%s is already on the board", where))
+		}
+		seen[strings.ToLower(clue.Clue)] = true
+		if strings.TrimSpace(clue.Answer) == "" {
+			problems = append(problems, fmt.Sprintf("%s is missing its answer", where))
+		}
+		if len(clue.Alternatives) > maxAlternatives {
+			problems = append(problems, fmt.Sprintf("%s has more than %d alternatives", where, maxAlternatives))
+		}
+	}
+	for round, categories := range [][]db.CustomCategory{board.FirstRound, board.SecondRound} {
+		roundName := []string{"first round", "second round"}[round]
+		names := map[string]bool{}
+		for i, category := range categories {
+			name := strings.TrimSpace(category.Name)
+			if name == "" {
+				problems = append(problems, fmt.Sprintf("Category %d of the %s is missing its name", i+1, roundName))
+				name = fmt.Sprintf("category %d of the %s", i+1, roundName)
+			} else if names[strings.ToLower(name)] {
+				problems = append(problems, fmt.Sprintf("%s is in the %s more than once", name, roundName))
+			}
+			names[strings.ToLower(name)] = true
+			if len(category.Clues) != clues {
+				problems = append(problems, fmt.Sprintf("%s has %d clues instead of %d", name, len(category.Clues), clues))
+			}
+			for j, clue := range category.Clues {
+				checkClue(fmt.Sprintf("Clue %d in %s", j+1, name), clue)
+			}
+		}
+	}
+
+	if strings.TrimSpace(board.FinalJeopardy.Name) == "" {
+		problems = append(problems, "Final Jeopardy is missing its category")
+	}
+	if len(board.FinalJeopardy.Clues) != 1 {
+		problems = append(problems, fmt.Sprintf("Final Jeopardy must have 1 clue, got: %d", len(board.FinalJeopardy.Clues)))
+	} else {
+		checkClue("Final Jeopardy", board.FinalJeopardy.Clues[0])
+	}
+	return problems
+}
+
+func withoutAnswers(board db.CustomBoard) db.CustomBoard {
+	hide := func(categories []db.CustomCategory) []db.CustomCategory {
+		hidden := []db.CustomCategory{}
+		for _, category := range categories {
+			clues := []db.CustomClue{}
+			for _, clue := range category.Clues {
+				clues = append(clues, db.CustomClue{Clue: clue.Clue})
+			}
+			hidden = append(hidden, db.CustomCategory{Name: category.Name, Clues: clues})
+		}
+		return hidden
+	}
+	board.FirstRound = hide(board.FirstRound)
+	board.SecondRound = hide(board.SecondRound)
+	board.FinalJeopardy = hide([]db.CustomCategory{board.FinalJeopardy})[0]
+	return board
+}
+
+// customBoardRequest shapes a game request to a custom board, which decides
+// how many categories and clues each round has.
+func customBoardRequest(ctx context.Context, req GameRequest) (GameRequest, error) {
+	if len(req.FirstRoundCategories) > 0 || len(req.SecondRoundCategories) > 0 {
+		return req, fmt.Errorf("Games on a custom board cannot pick categories")
+	}
+	board, err := getCustomBoard(ctx, customBoardDB, req.CustomBoard)
+	if err != nil {
+		return req, err
+	}
+	if req.FullGame && len(board.SecondRound) == 0 {
+		return req, fmt.Errorf("%s has no second round", board.Name)
+	}
+	req.Categories = len(board.FirstRound)
+	req.Questions = len(board.FirstRound[0].Clues)
+	return req, nil
+}
+
+// customBoardQuestions lays out a custom board in the order setQuestions
+// expects, with the values of a standard board.
+func (g *Game) customBoardQuestions(ctx context.Context) ([]db.Question, error) {
+	board, err := getCustomBoard(ctx, g.jeopardyDB, g.CustomBoard)
+	if err != nil {
+		return nil, err
+	}
+	if len(board.FirstRound) != g.Categories || len(board.FirstRound[0].Clues) != g.Questions {
+		return nil, fmt.Errorf("%s no longer has %d categories of %d clues", board.Name, g.Categories, g.Questions)
+	}
+	questions := []db.Question{}
+	for round, categories := range [][]db.CustomCategory{board.FirstRound, board.SecondRound} {
+		for _, category := range categories {
+			for i, clue := range category.Clues {
+				questions = append(questions, customQuestion(round+1, 200*(round+1)*(i+1), category.Name, clue))
+			}
+		}
+	}
+	questions = append(questions, customQuestion(3, 0, board.FinalJeopardy.Name, board.FinalJeopardy.Clues[0]))
+	return questions, nil
+}
+
+func customQuestion(round, value int, category string, clue db.CustomClue) db.Question {
+	return db.Question{
+		Round:        round,
+		Value:        value,
+		Category:     category,
+		Clue:         clue.Clue,
+		Answer:       clue.Answer,
+		Alternatives: append([]string{clue.Answer}, clue.Alternatives...),
+		Incorrect:    []string{},
+	}
+}
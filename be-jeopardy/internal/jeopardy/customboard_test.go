@@ -0,0 +1,165 @@
+package jeopardy
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func testCustomBoard(categories, clues int, secondRound bool) db.CustomBoard {
+	round := func(n int) []db.CustomCategory {
+		round := []db.CustomCategory{}
+		for i := 0; i < categories; i++ {
+			category := db.CustomCategory{Name: fmt.Sprintf("ROUND %d CATEGORY %d", n, i+1)}
+			for j := 0; j < clues; j++ {
+				category.Clues = append(category.Clues, db.CustomClue{
+					Clue:         fmt.Sprintf("Round %d clue %d-%d", n, i+1, j+1),
+					Answer:       fmt.Sprintf("answer %d-%d-%d", n, i+1, j+1),
+					Alternatives: []string{fmt.Sprintf("alternative %d-%d-%d", n, i+1, j+1)},
+				})
+			}
+			round = append(round, category)
+		}
+		return round
+	}
+	board := db.CustomBoard{
+		Name:          "Birthday Quiz",
+		FirstRound:    round(1),
+		FinalJeopardy: db.CustomCategory{Name: "THE BIRTHDAY GIRL", Clues: []db.CustomClue{{Clue: "She turns 30 today", Answer: "Alice"}}},
+	}
+	if secondRound {
+		board.SecondRound = round(2)
+	}
+	return board
+}
+
+func TestValidateCustomBoard(t *testing.T) {
+	t.Run("test a valid board", func(t *testing.T) {
+		assert.Equal(t, CustomBoardValidation{Valid: true, Problems: []string{}}, ValidateCustomBoard(testCustomBoard(6, 5, true)))
+		assert.True(t, ValidateCustomBoard(testCustomBoard(2, 2, false)).Valid)
+	})
+
+	t.Run("test invalid boards", func(t *testing.T) {
+		for _, test := range []struct {
+			problem string
+			change  func(b *db.CustomBoard)
+		}{
+			{"Name must be between 1 and 100 characters", func(b *db.CustomBoard) { b.Name = " " }},
+			{"First round must have between 2 and 8 categories, got: 1", func(b *db.CustomBoard) { b.FirstRound, b.SecondRound = b.FirstRound[:1], nil }},
+			{"Second round must have no categories or as many as the first round, got: 2", func(b *db.CustomBoard) { b.SecondRound = b.SecondRound[:2] }},
+			{"ROUND 2 CATEGORY 3 has 4 clues instead of 5", func(b *db.CustomBoard) { b.SecondRound[2].Clues = b.SecondRound[2].Clues[:4] }},
+			{"Category 2 of the first round is missing its name", func(b *db.CustomBoard) { b.FirstRound[1].Name = "" }},
+			{"round 1 category 1 is in the first round more than once", func(b *db.CustomBoard) { b.FirstRound[1].Name = "round 1 category 1" }},
+			{"Clue 3 in ROUND 1 CATEGORY 2 is missing its answer", func(b *db.CustomBoard) { b.FirstRound[1].Clues[2].Answer = "" }},
+			{"Clue 1 in ROUND 2 CATEGORY 1 is already on the board", func(b *db.CustomBoard) { b.SecondRound[0].Clues[0].Clue = "round 1 clue 1-1" }},
+			{"Final Jeopardy must have 1 clue, got: 0", func(b *db.CustomBoard) { b.FinalJeopardy.Clues = nil }},
+			{"Final Jeopardy is missing its clue", func(b *db.CustomBoard) { b.FinalJeopardy.Clues[0].Clue = "" }},
+		} {
+			board := testCustomBoard(6, 5, true)
+			test.change(&board)
+			validation := ValidateCustomBoard(board)
+			assert.False(t, validation.Valid)
+			assert.Equal(t, []string{test.problem}, validation.Problems)
+		}
+	})
+}
+
+func TestCustomBoards(t *testing.T) {
+	ctx := context.Background()
+	memoryDB := newTestDB(t)
+	prevDB, prevBoardDB := newJeopardyDB, customBoardDB
+	newJeopardyDB = func(ctx context.Context) (jeopardyDB, error) {
+		return memoryDB, nil
+	}
+	customBoardDB = memoryDB
+	defer func() {
+		newJeopardyDB, customBoardDB = prevDB, prevBoardDB
+	}()
+
+	owner, other := "alice@example.com", "bob@example.com"
+	board, err := CreateCustomBoard(ctx, CustomBoardRequest{Email: owner, CustomBoard: testCustomBoard(3, 4, false)})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	t.Run("test creating and sharing boards", func(t *testing.T) {
+		assert.NotEmpty(t, board.Id)
+		assert.Equal(t, owner, board.Owner)
+
+		_, err := CreateCustomBoard(ctx, CustomBoardRequest{CustomBoard: testCustomBoard(3, 4, false)})
+		assert.EqualError(t, err, "Email is required")
+		_, err = CreateCustomBoard(ctx, CustomBoardRequest{Email: owner, CustomBoard: testCustomBoard(1, 4, false)})
+		assert.EqualError(t, err, "Invalid board: First round must have between 2 and 8 categories, got: 1")
+
+		mine, err := GetCustomBoard(ctx, board.Id, owner)
+		assert.NoError(t, err)
+		assert.Equal(t, "answer 1-1-1", mine.FirstRound[0].Clues[0].Answer)
+
+		shared, err := GetCustomBoard(ctx, board.Id, other)
+		assert.NoError(t, err)
+		assert.Equal(t, db.CustomClue{Clue: "Round 1 clue 1-1"}, shared.FirstRound[0].Clues[0])
+		assert.Equal(t, db.CustomClue{Clue: "She turns 30 today"}, shared.FinalJeopardy.Clues[0])
+
+		boards, err := GetCustomBoards(ctx, owner)
+		assert.NoError(t, err)
+		assert.Len(t, boards, 1)
+		boards, err = GetCustomBoards(ctx, other)
+		assert.NoError(t, err)
+		assert.Empty(t, boards)
+
+		_, err = GetCustomBoard(ctx, "not-a-board", owner)
+		assert.EqualError(t, err, "No board with id not-a-board")
+	})
+
+	t.Run("test only the owner changes a board", func(t *testing.T) {
+		edit := testCustomBoard(3, 4, false)
+		edit.Name = "Onboarding Quiz"
+		_, err := UpdateCustomBoard(ctx, board.Id, CustomBoardRequest{Email: other, CustomBoard: edit})
+		assert.EqualError(t, err, fmt.Sprintf("No board with id %s", board.Id))
+
+		updated, err := UpdateCustomBoard(ctx, board.Id, CustomBoardRequest{Email: owner, CustomBoard: edit})
+		assert.NoError(t, err)
+		assert.Equal(t, "Onboarding Quiz", updated.Name)
+		assert.Equal(t, board.CreatedAt, updated.CreatedAt)
+
+		assert.EqualError(t, DeleteCustomBoard(ctx, board.Id, other), fmt.Sprintf("No board with id %s", board.Id))
+	})
+
+	t.Run("test playing a game on a custom board", func(t *testing.T) {
+		_, _, err, _ := CreatePrivateGame(ctx, GameRequest{PlayerName: "alice", FullGame: true, PickConfig: 30, BuzzConfig: 30, AnswerConfig: 30, WagerConfig: 30, CustomBoard: board.Id})
+		assert.EqualError(t, err, "Onboarding Quiz has no second round")
+
+		g, _, err, _ := CreatePrivateGame(ctx, GameRequest{PlayerName: "alice", PickConfig: 30, BuzzConfig: 30, AnswerConfig: 30, WagerConfig: 30, CustomBoard: board.Id})
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer removeGame(g)
+
+		assert.NoError(t, g.do(func() error {
+			assert.Equal(t, 3, g.Categories)
+			assert.Equal(t, 4, g.Questions)
+			assert.Len(t, g.FirstRound, 3)
+			assert.Empty(t, g.SecondRound)
+			for i, category := range g.FirstRound {
+				assert.Equal(t, fmt.Sprintf("ROUND 1 CATEGORY %d", i+1), category.Title)
+				for j, q := range category.Questions {
+					assert.Equal(t, 200*(j+1), q.Value)
+					assert.Zero(t, q.Id)
+					assert.True(t, q.checkAnswer(fmt.Sprintf("alternative 1-%d-%d", i+1, j+1)))
+				}
+			}
+			assert.Equal(t, "THE BIRTHDAY GIRL", g.FinalQuestion.Category)
+			assert.Equal(t, "Alice", g.FinalQuestion.Answer)
+			return nil
+		}))
+	})
+
+	t.Run("test deleting a board", func(t *testing.T) {
+		assert.NoError(t, DeleteCustomBoard(ctx, board.Id, owner))
+		_, err := GetCustomBoard(ctx, board.Id, owner)
+		assert.EqualError(t, err, fmt.Sprintf("No board with id %s", board.Id))
+	})
+}
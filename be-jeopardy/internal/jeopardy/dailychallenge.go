@@ -0,0 +1,241 @@
+package jeopardy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"time"
+
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/db"
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/log"
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/socket"
+)
+
+type (
+	dailyResultStore interface {
+		StartDailyResult(ctx context.Context, r db.DailyResult) (bool, error)
+		SaveDailyResult(ctx context.Context, r db.DailyResult) error
+		GetDailyResult(ctx context.Context, day, email string) (*db.DailyResult, error)
+		GetDailyLeaderboard(ctx context.Context, day string, limit int) ([]db.DailyResult, error)
+		AddDailyBoard(ctx context.Context, day string, board []byte) ([]byte, error)
+		GetDailyBoard(ctx context.Context, day string) ([]byte, error)
+	}
+
+	// DailyChallenge is a day's challenge as seen by a player: their result,
+	// and the board once the day is over.
+	DailyChallenge struct {
+		Day    string          `json:"day"`
+		Board  *boardSnapshot  `json:"board,omitempty"`
+		Result *db.DailyResult `json:"result"`
+	}
+)
+
+const dailyLeaderboardSize = 100
+
+var (
+	dailyDB    dailyResultStore
+	dailyClock Clock = realClock{}
+)
+
+// today is the day of the current daily challenge, which changes at
+// midnight UTC.
+func today() string {
+	return dailyClock.Now().UTC().Format(time.DateOnly)
+}
+
+// dailyRng is seeded by the day, for the parts of the day's board that are
+// decided at random.
+func dailyRng(day string) *rand.Rand {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(day))
+	return rand.New(rand.NewPCG(h.Sum64(), h.Sum64()))
+}
+
+// setDailyBoard sets the day's board. The board is stored the first time
+// the day is played, and every later game, and the day's board once it's
+// over, come from that copy, so adding or fixing clues doesn't change it.
+func (g *Game) setDailyBoard(ctx context.Context) error {
+	stored, err := dailyDB.GetDailyBoard(ctx, g.DailyChallenge)
+	if err != nil {
+		return err
+	}
+	if stored == nil {
+		questions, err := g.jeopardyDB.GetDailyQuestions(ctx, g.DailyChallenge, g.Categories, g.Categories, g.Questions)
+		if err != nil {
+			return err
+		}
+		g.arrangeBoard(questions)
+		board, err := json.Marshal(g.board())
+		if err != nil {
+			return err
+		}
+		// the first game of the day to store its board decides the board
+		if stored, err = dailyDB.AddDailyBoard(ctx, g.DailyChallenge, board); err != nil {
+			return err
+		}
+	}
+	var board boardSnapshot
+	if err := json.Unmarshal(stored, &board); err != nil {
+		return fmt.Errorf("error reading the board of %s: %w", g.DailyChallenge, err)
+	}
+	g.restoreBoard(&board)
+	return nil
+}
+
+func dailyChallengeConfig(day string) (GameConfig, error) {
+	config := GameConfig{
+		FullGame:       true,
+		Penalty:        true,
+		PickTimeout:    30,
+		BuzzTimeout:    30,
+		AnswerTimeout:  30,
+		WagerTimeout:   30,
+		DailyChallenge: day,
+	}
+	return config, config.Validate()
+}
+
+// PlayDailyChallenge creates a solo game on today's board. Players have to
+// be logged in, and get one game a day: the attempt is recorded as the game
+// is created, so leaving it or asking for a second game doesn't give them
+// another try.
+func PlayDailyChallenge(ctx context.Context, req GameRequest) (*Game, string, error, int) {
+	if req.PlayerEmail == "" {
+		return &Game{}, "", fmt.Errorf("Log in to play the daily challenge"), socket.BadRequest
+	}
+	day := today()
+	result, err := dailyDB.GetDailyResult(ctx, day, req.PlayerEmail)
+	if err != nil {
+		return &Game{}, "", err, socket.ServerError
+	}
+	if result != nil {
+		return &Game{}, "", alreadyPlayed(result), socket.BadRequest
+	}
+
+	config, err := dailyChallengeConfig(day)
+	if err != nil {
+		return &Game{}, "", err, socket.ServerError
+	}
+	jeopardyDB, err := newJeopardyDB(ctx)
+	if err != nil {
+		return &Game{}, "", err, socket.ServerError
+	}
+	game, err := NewGame(ctx, jeopardyDB, config)
+	if err != nil {
+		jeopardyDB.Close()
+		return &Game{}, "", err, socket.ServerError
+	}
+
+	var player *Player
+	err = game.do(func() error {
+		if err := game.validateName(req.PlayerName); err != nil {
+			return err
+		}
+		player = game.addPlayer(req)
+		return nil
+	})
+	if err != nil {
+		game.discard()
+		return &Game{}, "", err, socket.BadRequest
+	}
+
+	// only one of two requests at once can start the day's attempt
+	started, err := dailyDB.StartDailyResult(ctx, db.DailyResult{Day: day, Email: req.PlayerEmail, Name: req.PlayerName})
+	if err != nil {
+		game.discard()
+		return &Game{}, "", err, socket.ServerError
+	}
+	if !started {
+		game.discard()
+		return &Game{}, "", fmt.Errorf("You already started today's challenge"), socket.BadRequest
+	}
+	games.addPrivateGame(game)
+	games.addPlayer(player.Id, game)
+
+	return game, player.Id, nil, 0
+}
+
+func alreadyPlayed(result *db.DailyResult) error {
+	if result.Finished {
+		return fmt.Errorf("You already played today's challenge")
+	}
+	return fmt.Errorf("You already started today's challenge")
+}
+
+// saveDailyResult fills in the score of a finished daily challenge and how
+// long it took.
+func (g *Game) saveDailyResult(ctx context.Context) {
+	if dailyDB == nil || len(g.Players) == 0 || g.Players[0].email() == "" {
+		return
+	}
+	player := g.Players[0]
+	err := dailyDB.SaveDailyResult(ctx, db.DailyResult{
+		Day:     g.DailyChallenge,
+		Email:   player.email(),
+		Name:    player.name(),
+		Score:   player.score(),
+		Seconds: int(g.clock.Now().Sub(g.StartedAt).Seconds()),
+	})
+	if err != nil {
+		log.Errorf("Error saving daily challenge result: %s", err.Error())
+	}
+}
+
+func parseDay(day string) (string, error) {
+	if day == "" {
+		return today(), nil
+	}
+	if _, err := time.Parse(time.DateOnly, day); err != nil {
+		return "", fmt.Errorf("Invalid day %s, expected a date like 2024-01-31", day)
+	}
+	return day, nil
+}
+
+// GetDailyLeaderboard returns the best results of the day, today if no day
+// is given.
+func GetDailyLeaderboard(ctx context.Context, day string) ([]db.DailyResult, error) {
+	day, err := parseDay(day)
+	if err != nil {
+		return nil, err
+	}
+	return dailyDB.GetDailyLeaderboard(ctx, day, dailyLeaderboardSize)
+}
+
+// GetDailyChallenge returns the player's result on the day's challenge,
+// along with the day's stored board if the day is over.
+func GetDailyChallenge(ctx context.Context, day, email string) (DailyChallenge, error) {
+	day, err := parseDay(day)
+	if err != nil {
+		return DailyChallenge{}, err
+	}
+	if day > today() {
+		return DailyChallenge{}, fmt.Errorf("There is no daily challenge for %s yet", day)
+	}
+	challenge := DailyChallenge{Day: day}
+	if email != "" {
+		if challenge.Result, err = dailyDB.GetDailyResult(ctx, day, email); err != nil {
+			return DailyChallenge{}, err
+		}
+	}
+	if day == today() {
+		return challenge, nil
+	}
+
+	config, err := dailyChallengeConfig(day)
+	if err != nil {
+		return DailyChallenge{}, err
+	}
+	jeopardyDB, err := newJeopardyDB(ctx)
+	if err != nil {
+		return DailyChallenge{}, err
+	}
+	defer jeopardyDB.Close()
+	g := newGame(jeopardyDB, config)
+	if err := g.setQuestions(ctx); err != nil {
+		return DailyChallenge{}, err
+	}
+	challenge.Board = g.board()
+	return challenge, nil
+}
@@ -0,0 +1,180 @@
+package jeopardy
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDailyChallenge(t *testing.T) {
+	ctx := context.Background()
+	memoryDB := newTestDB(t)
+	clock := NewManualClock(time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC))
+	prevDB, prevDailyDB, prevClock := newJeopardyDB, dailyDB, dailyClock
+	newJeopardyDB = func(ctx context.Context) (jeopardyDB, error) {
+		return memoryDB, nil
+	}
+	dailyDB, dailyClock = memoryDB, clock
+	defer func() {
+		newJeopardyDB, dailyDB, dailyClock = prevDB, prevDailyDB, prevClock
+	}()
+
+	play := func(t *testing.T, name, email string, score int) *Game {
+		t.Helper()
+		g, playerId, err, _ := PlayDailyChallenge(ctx, GameRequest{PlayerName: name, PlayerEmail: email})
+		if !assert.NoError(t, err) {
+			return nil
+		}
+		defer removeGame(g)
+		assert.NotEmpty(t, playerId)
+		assert.NoError(t, g.do(func() error {
+			g.Players[0].addToScore(score)
+			g.setState(PostGame, &Player{})
+			return nil
+		}))
+		return g
+	}
+
+	t.Run("test everyone gets the same board each day", func(t *testing.T) {
+		first := play(t, "alice", "alice@example.com", 3000)
+		second := play(t, "bob", "bob@example.com", 5000)
+		want, _ := json.Marshal(first.board())
+		got, _ := json.Marshal(second.board())
+		assert.JSONEq(t, string(want), string(got))
+
+		config, err := dailyChallengeConfig("2024-01-03")
+		assert.NoError(t, err)
+		g := newGame(memoryDB, config)
+		assert.NoError(t, g.setQuestions(ctx))
+		other, _ := json.Marshal(g.board())
+		assert.NotEqual(t, string(want), string(other))
+	})
+
+	t.Run("test each player plays once a day", func(t *testing.T) {
+		_, _, err, _ := PlayDailyChallenge(ctx, GameRequest{PlayerName: "alice", PlayerEmail: "alice@example.com"})
+		assert.EqualError(t, err, "You already played today's challenge")
+
+		_, _, err, _ = PlayDailyChallenge(ctx, GameRequest{PlayerName: "guest"})
+		assert.EqualError(t, err, "Log in to play the daily challenge")
+	})
+
+	t.Run("test results are ranked on the day's leaderboard", func(t *testing.T) {
+		leaderboard, err := GetDailyLeaderboard(ctx, "")
+		assert.NoError(t, err)
+		assert.Len(t, leaderboard, 2)
+		assert.Equal(t, db.DailyResult{Day: "2024-01-02", Email: "bob@example.com", Name: "bob", Score: 5000, Seconds: leaderboard[0].Seconds, Finished: true}, leaderboard[0])
+		assert.Equal(t, "alice", leaderboard[1].Name)
+
+		leaderboard, err = GetDailyLeaderboard(ctx, "2024-01-01")
+		assert.NoError(t, err)
+		assert.Empty(t, leaderboard)
+
+		_, err = GetDailyLeaderboard(ctx, "yesterday")
+		assert.Error(t, err)
+	})
+
+	t.Run("test past boards are shown with the player's result", func(t *testing.T) {
+		challenge, err := GetDailyChallenge(ctx, "", "alice@example.com")
+		assert.NoError(t, err)
+		assert.Nil(t, challenge.Board)
+		assert.Equal(t, 3000, challenge.Result.Score)
+
+		clock.Advance(24 * time.Hour)
+		challenge, err = GetDailyChallenge(ctx, "2024-01-02", "alice@example.com")
+		assert.NoError(t, err)
+		assert.Equal(t, 3000, challenge.Result.Score)
+		if assert.NotNil(t, challenge.Board) {
+			assert.Len(t, challenge.Board.FirstRound, numCategories)
+		}
+
+		challenge, err = GetDailyChallenge(ctx, "2024-01-02", "carol@example.com")
+		assert.NoError(t, err)
+		assert.Nil(t, challenge.Result)
+
+		_, err = GetDailyChallenge(ctx, "2024-01-04", "")
+		assert.Error(t, err)
+	})
+
+	t.Run("test past boards come from the board stored on the day", func(t *testing.T) {
+		stored, err := memoryDB.GetDailyBoard(ctx, "2024-01-02")
+		assert.NoError(t, err)
+		challenge, err := GetDailyChallenge(ctx, "2024-01-02", "")
+		assert.NoError(t, err)
+		got, _ := json.Marshal(challenge.Board)
+		assert.JSONEq(t, string(stored), string(got))
+
+		// a stored board is kept even if the clues it came from change
+		board, _ := json.Marshal(boardSnapshot{
+			FirstRound: []categorySnapshot{{Title: "STORED", Questions: []questionSnapshot{
+				{Round: 1, Value: 200, Category: "STORED", Clue: "It was stored", Answer: "stored", CanChoose: true},
+			}}},
+			FinalQuestion: &questionSnapshot{Round: 3, Category: "FINAL", Clue: "It was final", Answer: "final"},
+		})
+		_, err = memoryDB.AddDailyBoard(ctx, "2024-01-01", board)
+		assert.NoError(t, err)
+		challenge, err = GetDailyChallenge(ctx, "2024-01-01", "")
+		assert.NoError(t, err)
+		if assert.NotNil(t, challenge.Board) && assert.Len(t, challenge.Board.FirstRound, 1) {
+			assert.Equal(t, "It was stored", challenge.Board.FirstRound[0].Questions[0].Clue)
+			assert.Equal(t, "It was final", challenge.Board.FinalQuestion.Clue)
+		}
+	})
+
+	t.Run("test the attempt is recorded when the game starts", func(t *testing.T) {
+		g, _, err, _ := PlayDailyChallenge(ctx, GameRequest{PlayerName: "dave", PlayerEmail: "dave@example.com"})
+		if !assert.NoError(t, err) {
+			return
+		}
+		_, _, err, _ = PlayDailyChallenge(ctx, GameRequest{PlayerName: "dave", PlayerEmail: "dave@example.com"})
+		assert.EqualError(t, err, "You already started today's challenge")
+
+		challenge, err := GetDailyChallenge(ctx, "", "dave@example.com")
+		assert.NoError(t, err)
+		assert.Equal(t, &db.DailyResult{Day: today(), Email: "dave@example.com", Name: "dave"}, challenge.Result)
+		leaderboard, err := GetDailyLeaderboard(ctx, "")
+		assert.NoError(t, err)
+		assert.Empty(t, leaderboard)
+
+		assert.NoError(t, g.do(func() error {
+			g.Players[0].addToScore(1000)
+			g.setState(PostGame, &Player{})
+			removeGame(g)
+			return nil
+		}))
+		challenge, err = GetDailyChallenge(ctx, "", "dave@example.com")
+		assert.NoError(t, err)
+		assert.True(t, challenge.Result.Finished)
+		assert.Equal(t, 1000, challenge.Result.Score)
+		_, _, err, _ = PlayDailyChallenge(ctx, GameRequest{PlayerName: "dave", PlayerEmail: "dave@example.com"})
+		assert.EqualError(t, err, "You already played today's challenge")
+	})
+
+	t.Run("test only one of many games at once is created", func(t *testing.T) {
+		created := atomic.Int32{}
+		wg := sync.WaitGroup{}
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				g, _, err, _ := PlayDailyChallenge(ctx, GameRequest{PlayerName: "erin", PlayerEmail: "erin@example.com"})
+				if err != nil {
+					assert.Contains(t, []string{"You already started today's challenge", "You already played today's challenge"}, err.Error())
+					return
+				}
+				created.Add(1)
+				_ = g.do(func() error {
+					removeGame(g)
+					return nil
+				})
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(1), created.Load())
+	})
+}
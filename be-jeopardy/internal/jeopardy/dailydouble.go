@@ -0,0 +1,176 @@
+package jeopardy
+
+import (
+	"context"
+
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/db"
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/log"
+)
+
+type dailyDoubleReader interface {
+	GetDailyDoubleOccurrences(ctx context.Context) ([]db.DailyDoubleOccurrence, error)
+}
+
+const (
+	// HistoricalPlacement puts Daily Doubles in the rows they have been
+	// found in on the show, and in different categories where it can.
+	HistoricalPlacement = "historical"
+	// RandomPlacement puts Daily Doubles behind any clue with equal odds.
+	RandomPlacement = "random"
+)
+
+var (
+	dailyDoubleDB dailyDoubleReader
+
+	// dailyDoubleWeights are how often each row of a standard board has
+	// had a Daily Double in each round, out of 10000. These are the bounds
+	// from daily_double_occurrence_bounds.sql until LoadDailyDoubles reads
+	// them from the clue database.
+	dailyDoubleWeights = [2][]int{
+		{15, 1135, 2766, 3493, 2591},
+		{15, 1509, 3158, 3538, 1780},
+	}
+
+	defaultDailyDoubles = []int{1, 2}
+)
+
+// LoadDailyDoubles computes where Daily Doubles are placed from the Daily
+// Doubles in the clue database. It keeps the built in weights if the
+// database has no Daily Doubles.
+func LoadDailyDoubles(ctx context.Context) error {
+	if dailyDoubleDB == nil {
+		return nil
+	}
+	occurrences, err := dailyDoubleDB.GetDailyDoubleOccurrences(ctx)
+	if err != nil {
+		return err
+	}
+	weights, ok := dailyDoubleRowWeights(occurrences)
+	if !ok {
+		log.Infof("No Daily Doubles found in the clue database, using the default placement")
+		return nil
+	}
+	dailyDoubleWeights = weights
+	return nil
+}
+
+// dailyDoubleRowWeights counts the Daily Doubles in each row of a standard
+// board, where the clues of row i in round r are worth 200*r*(i+1). Values
+// that aren't on the board, like those from before values were doubled,
+// are left out.
+func dailyDoubleRowWeights(occurrences []db.DailyDoubleOccurrence) ([2][]int, bool) {
+	weights := [2][]int{make([]int, numQuestions), make([]int, numQuestions)}
+	counts := [2]int{}
+	for _, o := range occurrences {
+		if o.Round != 1 && o.Round != 2 {
+			continue
+		}
+		step := 200 * o.Round
+		if o.Value%step != 0 || o.Value/step < 1 || o.Value/step > numQuestions {
+			continue
+		}
+		weights[o.Round-1][o.Value/step-1] += o.Count
+		counts[o.Round-1] += o.Count
+	}
+	return weights, counts[0] > 0 && counts[1] > 0
+}
+
+func (g *Game) setDailyDoubles() {
+	dailyDoubles := g.DailyDoubles
+	if dailyDoubles == nil {
+		dailyDoubles = defaultDailyDoubles
+	}
+	for i := 0; i < dailyDoubles[0]; i++ {
+		g.setDailyDouble(g.FirstRound, dailyDoubleWeights[0])
+	}
+	for i := 0; i < dailyDoubles[1]; i++ {
+		g.setDailyDouble(g.SecondRound, dailyDoubleWeights[1])
+	}
+}
+
+// setDailyDouble hides a Daily Double behind a clue of the round that
+// doesn't already have one.
+func (g *Game) setDailyDouble(round []Category, weights []int) {
+	if g.DailyDoublePlacement == RandomPlacement {
+		free := []*Question{}
+		for _, category := range round {
+			for _, q := range category.Questions {
+				if !q.DailyDouble {
+					free = append(free, q)
+				}
+			}
+		}
+		if len(free) > 0 {
+			free[g.rng.IntN(len(free))].DailyDouble = true
+		}
+		return
+	}
+
+	categories := []Category{}
+	for _, category := range round {
+		if !hasDailyDouble(category) {
+			categories = append(categories, category)
+		}
+	}
+	if len(categories) == 0 {
+		for _, category := range round {
+			if !allDailyDoubles(category) {
+				categories = append(categories, category)
+			}
+		}
+	}
+	if len(categories) == 0 {
+		return
+	}
+	questions := categories[g.rng.IntN(len(categories))].Questions
+
+	rowWeights := make([]int, len(questions))
+	total := 0
+	for row, weight := range weights {
+		qIdx := dailyDoubleRow(row, len(questions))
+		if !questions[qIdx].DailyDouble {
+			rowWeights[qIdx] += weight
+			total += weight
+		}
+	}
+	if total == 0 {
+		for qIdx, q := range questions {
+			if !q.DailyDouble {
+				rowWeights[qIdx] = 1
+				total++
+			}
+		}
+	}
+	num := g.rng.IntN(total)
+	for qIdx, weight := range rowWeights {
+		if num < weight {
+			questions[qIdx].DailyDouble = true
+			return
+		}
+		num -= weight
+	}
+}
+
+// dailyDoubleRow maps a row of a standard five clue category, which the
+// weights are based on, onto a category with the given number of clues.
+func dailyDoubleRow(row, questions int) int {
+	return row * questions / numQuestions
+}
+
+func hasDailyDouble(category Category) bool {
+	for _, q := range category.Questions {
+		if q.DailyDouble {
+			return true
+		}
+	}
+	return false
+}
+
+func allDailyDoubles(category Category) bool {
+	for _, q := range category.Questions {
+		if !q.DailyDouble {
+			return false
+		}
+	}
+	return true
+}
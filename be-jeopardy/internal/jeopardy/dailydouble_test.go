@@ -0,0 +1,100 @@
+package jeopardy
+
+import (
+	"context"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDailyDoubles(t *testing.T) {
+	newBoardGame := func(t *testing.T, dailyDoubles []int, placement string) *Game {
+		config := GameConfig{FullGame: true, Penalty: true, PickTimeout: 30, BuzzTimeout: 30, AnswerTimeout: 30, WagerTimeout: 30, DailyDoubles: dailyDoubles, DailyDoublePlacement: placement}
+		err := config.Validate()
+		assert.NoError(t, err)
+		g := &Game{GameConfig: config, jeopardyDB: newTestDB(t), clock: realClock{}, rng: rand.New(rand.NewPCG(5, 5))}
+		assert.NoError(t, g.setQuestions(context.Background()))
+		return g
+	}
+	countDailyDoubles := func(round []Category) (int, int) {
+		dailyDoubles, categories := 0, 0
+		for _, category := range round {
+			if hasDailyDouble(category) {
+				categories++
+			}
+			for _, q := range category.Questions {
+				if q.DailyDouble {
+					dailyDoubles++
+				}
+			}
+		}
+		return dailyDoubles, categories
+	}
+
+	t.Run("test configured number of daily doubles", func(t *testing.T) {
+		g := newBoardGame(t, []int{0, 5}, "")
+		dailyDoubles, _ := countDailyDoubles(g.FirstRound)
+		assert.Equal(t, 0, dailyDoubles)
+		dailyDoubles, categories := countDailyDoubles(g.SecondRound)
+		assert.Equal(t, 5, dailyDoubles)
+		assert.Equal(t, 5, categories)
+
+		g = newBoardGame(t, nil, "")
+		dailyDoubles, _ = countDailyDoubles(g.FirstRound)
+		assert.Equal(t, 1, dailyDoubles)
+		dailyDoubles, _ = countDailyDoubles(g.SecondRound)
+		assert.Equal(t, 2, dailyDoubles)
+	})
+
+	t.Run("test random placement can fill the board", func(t *testing.T) {
+		g := newBoardGame(t, []int{30, 0}, RandomPlacement)
+		dailyDoubles, _ := countDailyDoubles(g.FirstRound)
+		assert.Equal(t, 30, dailyDoubles)
+
+		for _, config := range []GameConfig{
+			{FullGame: true, Penalty: true, PickTimeout: 30, BuzzTimeout: 30, AnswerTimeout: 30, WagerTimeout: 30, DailyDoubles: []int{31, 0}, DailyDoublePlacement: RandomPlacement},
+			{FullGame: true, Penalty: true, PickTimeout: 30, BuzzTimeout: 30, AnswerTimeout: 30, WagerTimeout: 30, DailyDoubles: []int{1}},
+			{FullGame: true, Penalty: true, PickTimeout: 30, BuzzTimeout: 30, AnswerTimeout: 30, WagerTimeout: 30, DailyDoublePlacement: "anywhere"},
+		} {
+			assert.Error(t, config.Validate())
+		}
+	})
+
+	t.Run("test placement is loaded from the clue database", func(t *testing.T) {
+		defaultWeights := dailyDoubleWeights
+		defer func() {
+			dailyDoubleWeights = defaultWeights
+			dailyDoubleDB = nil
+		}()
+
+		dailyDoubleDB = db.NewMemoryDB([]db.Clue{})
+		assert.NoError(t, LoadDailyDoubles(context.Background()))
+		assert.Equal(t, defaultWeights, dailyDoubleWeights)
+
+		dailyDoubleDB = db.NewMemoryDB([]db.Clue{
+			{Round: 1, Value: 600, DailyDoubleValue: 1000},
+			{Round: 1, Value: 600, DailyDoubleValue: 500},
+			{Round: 1, Value: 1000, DailyDoubleValue: 2000},
+			{Round: 1, Value: 300, DailyDoubleValue: 300},
+			{Round: 2, Value: 1200, DailyDoubleValue: 3000},
+			{Round: 2, Value: 1000, DailyDoubleValue: 3000},
+			{Round: 2, Value: 400, DailyDoubleValue: 0},
+		})
+		assert.NoError(t, LoadDailyDoubles(context.Background()))
+		assert.Equal(t, [2][]int{{0, 0, 2, 0, 1}, {0, 0, 1, 0, 0}}, dailyDoubleWeights)
+
+		g := newBoardGame(t, []int{1, 1}, "")
+		for _, category := range g.FirstRound {
+			for i, q := range category.Questions {
+				assert.False(t, q.DailyDouble && i != 2 && i != 4)
+			}
+		}
+		for _, category := range g.SecondRound {
+			for i, q := range category.Questions {
+				assert.False(t, q.DailyDouble && i != 2)
+			}
+		}
+	})
+}
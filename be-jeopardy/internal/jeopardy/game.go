@@ -2,9 +2,11 @@ package jeopardy
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math/rand/v2"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -21,7 +23,26 @@ type (
 		GameTimeouts
 
 		jeopardyDB jeopardyDB
+		clock      Clock
+		rng        *rand.Rand
+		seed       uint64
+		public     bool
+		// lastSnapshot is the last state saved by persist, without its
+		// SavedAt time.
+		lastSnapshot []byte
 
+		eventSeq      int
+		pendingEvents []GameEvent
+		// replaying is set on games rebuilt by Replay, which take their
+		// boards from replayBoards and never touch shared state.
+		replaying    bool
+		replayBoards []*boardSnapshot
+		// tournament is set on games played as a match of a tournament.
+		tournament *Tournament
+		// importedBoard is set on games played on a board file.
+		importedBoard *BoardFile
+
+		Id             string       `json:"id"`
 		Name           string       `json:"name"`
 		Code           string       `json:"code"`
 		State          GameState    `json:"state"`
@@ -32,6 +53,8 @@ type (
 		CurQuestion    *Question    `json:"curQuestion"`
 		OfficialAnswer string       `json:"officialAnswer"`
 		Players        []GamePlayer `json:"players"`
+		Spectators     []*Player    `json:"spectators"`
+		Host           *Player      `json:"host"`
 		LastToPick     GamePlayer   `json:"lastToPick"`
 		AnsCorrectness bool         `json:"ansCorrectness"`
 		GuessedWrong   []string     `json:"guessedWrong"`
@@ -43,10 +66,14 @@ type (
 		Paused         bool         `json:"paused"`
 		PausedState    GameState    `json:"pausedState"`
 		PausedAt       time.Time    `json:"pausedAt"`
+		StartedAt      time.Time    `json:"startedAt"`
 		DisputePicker  GamePlayer   `json:"disputePicker"`
 		Disputers      int          `json:"disputes"`
 		NonDisputers   int          `json:"nonDisputes"`
 		imgOffset      int
+		// disputeVotes are the names of the players who voted to accept
+		// the current dispute, starting with whoever raised it.
+		disputeVotes []string
 
 		StartFinalAnswerCountdown bool `json:"startFinalAnswerCountdown"`
 		StartFinalWagerCountdown  bool `json:"startFinalWagerCountdown"`
@@ -55,24 +82,38 @@ type (
 	GameChannels struct {
 		msgChan        chan Message
 		disconnectChan chan GamePlayer
-		restartChan    chan bool
 		chatChan       chan ChatMessage
 		reactChan      chan Reaction
+		cmdChan        chan command
+		done           chan struct{}
+		stopOnce       *sync.Once
+	}
+
+	// command is a function run on the game's event loop, used by
+	// timeouts and requests that read or change the game's state.
+	command struct {
+		fn     func() error
+		result chan error
 	}
 
+	// GameOption customizes a game when it is created.
+	GameOption func(*Game)
+
 	jeopardyDB interface {
-		GetQuestions(ctx context.Context, frCategories, srCategories int) ([]db.Question, error)
+		GetQuestions(ctx context.Context, frCategories, srCategories, clues int) ([]db.Question, error)
+		GetDailyQuestions(ctx context.Context, day string, frCategories, srCategories, clues int) ([]db.Question, error)
 		GetCategoryQuestions(ctx context.Context, category db.Category) ([]db.Question, error)
-		AddAlternative(ctx context.Context, alternative, answer string) error
-		AddIncorrect(ctx context.Context, incorrect, clue string) error
+		GetCustomBoard(ctx context.Context, id string) (db.CustomBoard, error)
+		AddPendingAlternative(ctx context.Context, alt db.PendingAlternative) error
+		AddIncorrect(ctx context.Context, clueId int, incorrect string) error
 		SaveGameAnalytics(ctx context.Context, gameID uuid.UUID, createdAt int64, fr db.AnalyticsRound, sr db.AnalyticsRound) error
 		IncrementPlayerGames(ctx context.Context, email string, wins, points, answers, correct int) error
 		Close()
 	}
 
 	Message struct {
-		Player GamePlayer
-		State  GameState `json:"state"`
+		Player GamePlayer `json:"-"`
+		State  GameState  `json:"state"`
 
 		CatIdx     int    `json:"catIdx"`
 		ValIdx     int    `json:"valIdx"`
@@ -81,10 +122,18 @@ type (
 		Confirm    bool   `json:"confirm"`
 		Wager      int    `json:"wager"`
 		ProtestFor string `json:"protestFor"`
+		// Choice is the index of the choice picked in a multiple choice game.
+		Choice int `json:"choice"`
 
 		Pause       int  `json:"pause"` // 1 is pause, -1 is resume
 		InitDispute bool `json:"initDispute"`
 		Dispute     bool `json:"dispute"`
+
+		// sent by the host of a game in host mode
+		Correct  bool   `json:"correct"`
+		Skip     bool   `json:"skip"`
+		ScoreFor string `json:"scoreFor"`
+		Score    int    `json:"score"`
 	}
 
 	Response struct {
@@ -107,6 +156,7 @@ const (
 	RecvAns
 	RecvDispute
 	PostGame
+	RecvJudgment
 )
 
 type RoundState int
@@ -119,85 +169,180 @@ const (
 
 var maxPlayers = 6
 
-func NewGame(ctx context.Context, db jeopardyDB, config GameConfig) (*Game, error) {
+// WithClock drives the game's timeouts and bots with the given clock.
+func WithClock(clock Clock) GameOption {
+	return func(g *Game) {
+		g.clock = clock
+	}
+}
+
+// WithSeed seeds the game's random number generator, which decides the
+// game's name and code, player images and Daily Double placement.
+func WithSeed(seed uint64) GameOption {
+	return func(g *Game) {
+		g.seed = seed
+	}
+}
+
+func NewGame(ctx context.Context, db jeopardyDB, config GameConfig, opts ...GameOption) (*Game, error) {
+	game := newGame(db, config, opts...)
+	game.Id = uuid.New().String()
+	game.Name = genGameName(game.rng)
+	game.Code = genGameCode(game.rng)
+	game.imgOffset = game.rng.IntN(6)
+	game.record(GameEvent{Type: EventCreated, Game: game.created()})
+	if err := game.setQuestions(ctx); err != nil {
+		return nil, err
+	}
+	game.processMessages()
+	return game, nil
+}
+
+// newGame returns a game in its initial state, without a board and
+// without starting its event loop.
+func newGame(db jeopardyDB, config GameConfig, opts ...GameOption) *Game {
 	game := &Game{
 		GameConfig: config,
 		GameChannels: GameChannels{
 			msgChan:        make(chan Message),
 			disconnectChan: make(chan GamePlayer),
-			restartChan:    make(chan bool),
 			chatChan:       make(chan ChatMessage),
 			reactChan:      make(chan Reaction),
+			cmdChan:        make(chan command),
+			done:           make(chan struct{}),
+			stopOnce:       &sync.Once{},
 		},
 		GameTimeouts: GameTimeouts{
 			cancelBoardIntroTimeout: func() {},
 			cancelPickTimeout:       func() {},
 			cancelBuzzTimeout:       func() {},
 			cancelDisputeTimeout:    func() {},
+			running:                 map[string]*runningTimeout{},
+			restoredTimeouts:        map[string]time.Duration{},
 		},
 		jeopardyDB: db,
+		clock:      realClock{},
+		seed:       rand.Uint64(),
 		State:      PreGame,
 		Players:    []GamePlayer{},
 		Round:      FirstRound,
-		Name:       genGameName(),
-		Code:       genGameCode(),
 		LastToPick: &Player{},
-		imgOffset:  rand.IntN(6),
 	}
-	if err := game.setQuestions(ctx); err != nil {
-		return nil, err
+	// games saved before boards could be resized have no board dimensions
+	if game.Categories == 0 {
+		game.Categories = numCategories
 	}
-	game.processMessages()
-	game.processChatMessages()
-	game.processReactions()
-	return game, nil
+	if game.Questions == 0 {
+		game.Questions = numQuestions
+	}
+	for _, opt := range opts {
+		opt(game)
+	}
+	game.rng = rand.New(rand.NewPCG(game.seed, game.seed))
+	return game
 }
 
+// processMessages runs the game's event loop. Every change to the game's
+// state happens on this goroutine, whether it comes from a player's socket,
+// a timeout or an HTTP request.
 func (g *Game) processMessages() {
 	go func() {
+		ctx := context.Background()
 		for {
 			select {
 			case msg := <-g.msgChan:
-				if err := g.processMsg(context.Background(), msg); err != nil {
+				if err := g.processMsg(ctx, msg); err != nil {
 					log.Errorf("Error processing message: %s", err.Error())
 				}
+				g.persist(ctx)
+				g.flushEvents(ctx)
 			case player := <-g.disconnectChan:
 				g.disconnectPlayer(player)
-			case <-g.restartChan:
-				g.restartGame(context.Background())
-			}
-		}
-	}()
-}
-
-func (g *Game) processChatMessages() {
-	go func() {
-		for {
-			select {
+				g.persist(ctx)
+				g.flushEvents(ctx)
 			case msg := <-g.chatChan:
 				for _, p := range g.Players {
 					_ = p.sendChatMessage(msg)
 				}
-			}
-		}
-	}()
-}
-
-func (g *Game) processReactions() {
-	go func() {
-		for {
-			select {
+				for _, s := range g.Spectators {
+					_ = s.sendChatMessage(msg)
+				}
+				if g.Host != nil {
+					_ = g.Host.sendChatMessage(msg)
+				}
 			case msg := <-g.reactChan:
 				for _, p := range g.Players {
 					_ = p.sendReaction(msg)
 				}
+				for _, s := range g.Spectators {
+					_ = s.sendReaction(msg)
+				}
+				if g.Host != nil {
+					_ = g.Host.sendReaction(msg)
+				}
+			case cmd := <-g.cmdChan:
+				err := cmd.fn()
+				g.persist(ctx)
+				g.flushEvents(ctx)
+				cmd.result <- err
+			case <-g.done:
+				return
 			}
 		}
 	}()
 }
 
+// do runs fn on the game's event loop and waits for it to finish.
+func (g *Game) do(fn func() error) error {
+	cmd := command{fn: fn, result: make(chan error, 1)}
+	select {
+	case g.cmdChan <- cmd:
+	case <-g.done:
+		return fmt.Errorf("Game %s has ended", g.Name)
+	}
+	return <-cmd.result
+}
+
+// send queues a message for the game's event loop without waiting for it
+// to be processed.
+func (g *Game) send(msg Message) {
+	select {
+	case g.msgChan <- msg:
+	case <-g.done:
+	}
+}
+
+// stop ends the game's event loop.
+func (g *Game) stop() {
+	g.stopOnce.Do(func() {
+		close(g.done)
+	})
+}
+
+// discard ends a game that was never registered, closing its database.
+func (g *Game) discard() {
+	g.stop()
+	g.jeopardyDB.Close()
+}
+
+// Snapshot returns the game as JSON, read on the game's event loop. Like
+// the view spectators get, it leaves out the answer to the question in play.
+func (g *Game) Snapshot() (json.RawMessage, error) {
+	var snapshot []byte
+	err := g.do(func() error {
+		var err error
+		snapshot, err = g.spectatorView()
+		return err
+	})
+	return snapshot, err
+}
+
 func (g *Game) processMsg(ctx context.Context, msg Message) error {
 	player := msg.Player
+	if g.isSpectator(player) {
+		return fmt.Errorf("spectators cannot play")
+	}
+	g.record(GameEvent{Type: EventMessage, PlayerId: player.id(), Message: &msg})
 	if g.State != msg.State {
 		return nil
 	}
@@ -214,6 +359,15 @@ func (g *Game) processMsg(ctx context.Context, msg Message) error {
 		g.messageAllPlayers("Player %s paused the game", player.name())
 		return nil
 	}
+	if g.isHost(player) {
+		return g.processHostMsg(ctx, msg)
+	}
+	if team := g.teamOf(player); team != nil {
+		if err := g.checkTeamTurn(team, player, msg); err != nil {
+			return err
+		}
+		player = team
+	}
 	var err error
 	switch g.State {
 	case RecvPick:
@@ -225,13 +379,18 @@ func (g *Game) processMsg(ctx context.Context, msg Message) error {
 	case RecvBuzz:
 		err = g.processBuzz(ctx, player, msg.IsPass)
 	case RecvAns:
-		err = g.processAnswer(ctx, player, msg.Answer)
+		var answer string
+		if answer, err = g.chosenAnswer(msg); err == nil {
+			err = g.processAnswer(ctx, player, answer)
+		}
 	case RecvWager:
 		err = g.processWager(player, msg.Wager)
 	case RecvDispute:
 		err = g.processDispute(ctx, player, msg.Dispute)
 	case PostGame:
 		err = g.processProtest(player, msg.ProtestFor)
+	case RecvJudgment:
+		err = fmt.Errorf("waiting for the host to judge the answer")
 	case PreGame:
 		err = fmt.Errorf("received unexpected message")
 	}
@@ -242,18 +401,18 @@ func (g *Game) processPick(player GamePlayer, catIdx, valIdx int) error {
 	if !player.canPick() {
 		return fmt.Errorf("player cannot pick")
 	}
-	if catIdx < 0 || valIdx < 0 || catIdx >= numCategories || valIdx >= numQuestions {
-		return fmt.Errorf("invalid question pick")
-	}
-	g.cancelPickTimeout()
 	curRound := g.FirstRound
 	if g.Round == SecondRound {
 		curRound = g.SecondRound
 	}
+	if catIdx < 0 || valIdx < 0 || catIdx >= len(curRound) || valIdx >= len(curRound[catIdx].Questions) {
+		return fmt.Errorf("invalid question pick")
+	}
 	curQuestion := curRound[catIdx].Questions[valIdx]
 	if !curQuestion.CanChoose {
 		return fmt.Errorf("question cannot be chosen")
 	}
+	g.cancelPickTimeout()
 	g.LastToPick = player
 	g.CurQuestion = curQuestion
 	g.OfficialAnswer = g.CurQuestion.Answer
@@ -298,40 +457,66 @@ func (g *Game) processAnswer(ctx context.Context, player GamePlayer, answer stri
 	if g.Round == FinalRound {
 		return g.processFinalRoundAns(ctx, player, isCorrect, answer)
 	}
-	g.AnsCorrectness = isCorrect
 	g.CurQuestion.CurAns = &Answer{
-		Player:  player,
-		Answer:  answer,
-		Correct: isCorrect,
-		Bot:     player.isBot(),
+		Player: player,
+		Answer: answer,
+		Bot:    player.isBot(),
 	}
 	g.CurQuestion.Answers = append(g.CurQuestion.Answers, g.CurQuestion.CurAns)
-	if !isCorrect {
-		if err := g.jeopardyDB.AddIncorrect(ctx, g.CurQuestion.CurAns.Answer, g.CurQuestion.Clue); err != nil {
+	if g.HostMode {
+		g.setState(RecvJudgment, player)
+		g.messageAllPlayers("Waiting for the host to judge the answer")
+		return nil
+	}
+	g.judgeAnswer(ctx, isCorrect)
+	return nil
+}
+
+// judgeAnswer scores the current answer and moves on to what comes next.
+func (g *Game) judgeAnswer(ctx context.Context, isCorrect bool) {
+	g.AnsCorrectness = isCorrect
+	// bots' wrong answers aren't a clue's history, and would feed back into
+	// how often contestant bots are right
+	if !isCorrect && !g.MultipleChoice && !g.playerBoard() && !g.CurQuestion.CurAns.Bot {
+		if err := g.jeopardyDB.AddIncorrect(ctx, g.CurQuestion.Id, g.CurQuestion.CurAns.Answer); err != nil {
 			log.Errorf("Error adding incorrect: %s", err.Error())
 		}
 	}
 	g.CurQuestion.CurAns.Correct = isCorrect
 	g.nextQuestion(ctx, g.CurQuestion.CurAns.Player, isCorrect)
-	return nil
 }
 
 func (g *Game) processDispute(ctx context.Context, player GamePlayer, dispute bool) error {
+	if g.isHost(player) {
+		if dispute {
+			g.disputeVotes = append(g.disputeVotes, player.name())
+		}
+		g.resolveDispute(ctx, dispute)
+		return nil
+	}
 	if !player.canDispute() {
 		return fmt.Errorf("player cannot dispute")
 	}
 	player.setCanDispute(false)
 	if dispute {
 		g.Disputers++
+		g.disputeVotes = append(g.disputeVotes, player.name())
 	} else {
 		g.NonDisputers++
 	}
 	if g.Disputers < g.acceptMajority() && g.NonDisputers < g.declineMajority() {
 		return nil
 	}
+	g.resolveDispute(ctx, g.Disputers >= g.acceptMajority())
+	return nil
+}
+
+// resolveDispute overturns the disputed answer if the dispute was accepted
+// and hands the board back to whoever picks next.
+func (g *Game) resolveDispute(ctx context.Context, accepted bool) {
 	g.cancelDisputeTimeout()
 	nextPicker := g.DisputePicker
-	if g.Disputers >= g.acceptMajority() {
+	if accepted {
 		g.CurQuestion.CurDisputed.Overturned = true
 		g.CurQuestion.CurDisputed.Correct = true
 		for i, ans := range g.CurQuestion.Answers {
@@ -358,8 +543,20 @@ func (g *Game) processDispute(ctx context.Context, player GamePlayer, dispute bo
 				break
 			}
 		}
-		if err := g.jeopardyDB.AddAlternative(ctx, g.CurQuestion.CurDisputed.Answer, g.CurQuestion.Answer); err != nil {
-			log.Errorf("Error adding alternative: %s", err.Error())
+		// the response only becomes an alternative once an admin approves it
+		if !g.playerBoard() {
+			err := g.jeopardyDB.AddPendingAlternative(ctx, db.PendingAlternative{
+				ClueId:      g.CurQuestion.Id,
+				Alternative: g.CurQuestion.CurDisputed.Answer,
+				Answer:      g.CurQuestion.Answer,
+				Clue:        g.CurQuestion.Clue,
+				GameId:      g.Id,
+				AnsweredBy:  g.CurQuestion.CurDisputed.Player.name(),
+				DisputedBy:  g.disputeVotes,
+			})
+			if err != nil {
+				log.Errorf("Error adding pending alternative: %s", err.Error())
+			}
 		}
 		nextPicker = g.CurQuestion.CurDisputed.Player
 	}
@@ -367,7 +564,6 @@ func (g *Game) processDispute(ctx context.Context, player GamePlayer, dispute bo
 	g.NonDisputers = 0
 	g.setState(RecvPick, nextPicker)
 	g.messageAllPlayers("Dispute resolved")
-	return nil
 }
 
 func (g *Game) processWager(player GamePlayer, wager int) error {
@@ -417,18 +613,23 @@ func (g *Game) processProtest(protestByPlayer GamePlayer, protestFor string) err
 	if err != nil {
 		return err
 	}
-	if _, ok := protestForPlayer.finalProtestors()[protestByPlayer.id()]; ok {
-		return nil
+	if g.HostMode && !g.isHost(protestByPlayer) {
+		return fmt.Errorf("only the host can change results")
 	}
-	protestForPlayer.addFinalProtestor(protestByPlayer.id())
-	if len(protestForPlayer.finalProtestors()) < g.acceptMajority() {
-		_ = protestByPlayer.sendMessage(Response{
-			Code:      socket.Ok,
-			Message:   "You protested for " + protestForPlayer.name(),
-			Game:      g,
-			CurPlayer: protestByPlayer,
-		})
-		return nil
+	if !g.isHost(protestByPlayer) {
+		if _, ok := protestForPlayer.finalProtestors()[protestByPlayer.id()]; ok {
+			return nil
+		}
+		protestForPlayer.addFinalProtestor(protestByPlayer.id())
+		if len(protestForPlayer.finalProtestors()) < g.acceptMajority() {
+			_ = protestByPlayer.sendMessage(Response{
+				Code:      socket.Ok,
+				Message:   "You protested for " + protestForPlayer.name(),
+				Game:      g,
+				CurPlayer: protestByPlayer,
+			})
+			return nil
+		}
 	}
 	adjustment := protestForPlayer.finalWager()
 	if protestForPlayer.finalCorrect() {
@@ -467,6 +668,7 @@ func (g *Game) processFinalRoundAns(ctx context.Context, player GamePlayer, isCo
 }
 
 func (g *Game) setState(state GameState, player GamePlayer) {
+	g.record(GameEvent{Type: EventState, State: state, Round: g.Round, PlayerId: player.id()})
 	switch state {
 	case BoardIntro:
 		for _, p := range g.Players {
@@ -514,15 +716,21 @@ func (g *Game) setState(state GameState, player GamePlayer) {
 	case RecvDispute:
 		for _, p := range g.Players {
 			p.updateActions(false, false, false, false)
-			p.setCanDispute(p.id() != player.id())
+			p.setCanDispute(!g.HostMode && p.id() != player.id())
 		}
 		g.startDisputeTimeout()
-	case PreGame, PostGame:
+	case PreGame, PostGame, RecvJudgment:
 		for _, p := range g.Players {
 			p.updateActions(false, false, false, false)
 		}
 	}
 	g.State = state
+	if state == PostGame && g.tournament != nil {
+		g.tournament.reportMatch(g)
+	}
+	if state == PostGame && g.DailyChallenge != "" && !g.replaying {
+		g.saveDailyResult(context.Background())
+	}
 }
 
 func (g *Game) messageAllPlayers(msg string, args ...any) {
@@ -534,6 +742,8 @@ func (g *Game) messageAllPlayers(msg string, args ...any) {
 			CurPlayer: p,
 		})
 	}
+	g.messageSpectators(fmt.Sprintf(msg, args...))
+	g.messageHost(fmt.Sprintf(msg, args...))
 }
 
 func (g *Game) startRound(player GamePlayer) {
@@ -565,7 +775,7 @@ func (g *Game) startFinalRound(ctx context.Context) {
 }
 
 func (g *Game) pauseGame() {
-	g.PausedAt = time.Now()
+	g.PausedAt = g.clock.Now()
 	g.Paused = true
 	g.PausedState = g.State
 	g.cancelBoardIntroTimeout()
@@ -609,9 +819,23 @@ func (g *Game) resumeGame() {
 		player = &Player{}
 	}
 	g.setState(state, player)
+	g.restoredTimeouts = map[string]time.Duration{}
 }
 
 func (g *Game) disconnectPlayer(player GamePlayer) {
+	if g.isSpectator(player) {
+		g.removeSpectator(player)
+		return
+	}
+	g.record(GameEvent{Type: EventDisconnected, PlayerId: player.id()})
+	if team := g.teamOf(player); team != nil {
+		player.endConnections()
+		if team.conn() != nil {
+			g.messageAllPlayers("Player %s disconnected from the game", player.name())
+			return
+		}
+		player = team
+	}
 	g.Disconnected = true
 	g.pauseGame()
 	if g.State != PostGame {
@@ -620,13 +844,13 @@ func (g *Game) disconnectPlayer(player GamePlayer) {
 	player.endConnections()
 	player.setPlayAgain(false)
 	g.messageAllPlayers("Player %s disconnected from the game", player.name())
-	endGame := true
+	endGame := g.Host == nil || g.Host.conn() == nil
 	for _, p := range g.Players {
 		if p.conn() != nil {
 			endGame = false
 		}
 	}
-	if endGame {
+	if endGame && !g.replaying {
 		log.Infof("All players disconnected, removing game %s", g.Name)
 		removeGame(g)
 	}
@@ -646,6 +870,7 @@ func (g *Game) restartGame(ctx context.Context) {
 	g.NumFinalWagers = 0
 	g.FinalWagers = []string{}
 	g.FinalAnswers = []string{}
+	g.StartedAt = g.clock.Now()
 	g.setQuestions(ctx)
 	for _, p := range g.Players {
 		p.resetPlayer()
@@ -655,7 +880,8 @@ func (g *Game) restartGame(ctx context.Context) {
 }
 
 func (g *Game) nextQuestion(ctx context.Context, player GamePlayer, isCorrect bool) {
-	player.updateScore(g.CurQuestion.Value, isCorrect, g.Penalty, g.Round)
+	value, penalty := g.scoreValue(isCorrect)
+	player.updateScore(value, isCorrect, penalty, g.Round)
 	if !isCorrect {
 		g.GuessedWrong = append(g.GuessedWrong, player.id())
 	}
@@ -698,6 +924,9 @@ func (g *Game) skipQuestion(ctx context.Context) {
 }
 
 func (g *Game) initDispute(player GamePlayer) error {
+	if g.MultipleChoice {
+		return fmt.Errorf("answers cannot be disputed in multiple choice games")
+	}
 	ans, canDispute := g.getIncorrectAns(player)
 	if !canDispute {
 		return fmt.Errorf("player cannot initiate dispute")
@@ -709,6 +938,7 @@ func (g *Game) initDispute(player GamePlayer) error {
 		}
 	}
 	g.Disputers = 1
+	g.disputeVotes = []string{player.name()}
 	ans.HasDisputed = true
 	g.CurQuestion.CurDisputed = ans
 	g.setState(RecvDispute, player)
@@ -810,14 +1040,23 @@ func (g *Game) numFinalWagers() int {
 	return numWagers
 }
 
+// roundMax is the top value on the board this round, the most a player
+// without that much can wager on a Daily Double.
 func (g *Game) roundMax() int {
+	values := g.FirstRoundValues
+	base := 200
 	switch g.Round {
 	case FirstRound:
-		return 1000
 	case SecondRound:
-		return 2000
+		values = g.SecondRoundValues
+		base = 400
+	default:
+		return 0
 	}
-	return 0
+	if len(values) > 0 {
+		return values[len(values)-1]
+	}
+	return base * g.Questions
 }
 
 func (g *Game) validWager(wager, score int) (int, int, bool) {
@@ -828,6 +1067,16 @@ func (g *Game) validWager(wager, score int) (int, int, bool) {
 	return minWager, max(score, g.roundMax()), wager >= minWager && wager <= max(score, g.roundMax())
 }
 
+func (g *Game) humansPassed() bool {
+	humanPasses := 0
+	for _, player := range g.Players {
+		if !player.isBot() && !player.canBuzz() {
+			humanPasses++
+		}
+	}
+	return humanPasses == g.numHumans()
+}
+
 func (g *Game) numBots() int {
 	bots := 0
 	for _, p := range g.Players {
@@ -860,6 +1109,14 @@ func (g *Game) declineMajority() int {
 	return (g.numPlayers() + 1) / 2
 }
 
+func (g *Game) newBot() *Bot {
+	bot := NewBot(genBotName(g.rng), g.numBots())
+	bot.setLevel(g.botLevel(g.numBots()))
+	bot.clock = g.clock
+	bot.rng = rand.New(rand.NewPCG(g.rng.Uint64(), g.rng.Uint64()))
+	return bot
+}
+
 func (g *Game) nextImg() string {
 	return playerImgs[(len(g.Players)-g.numBots()+g.imgOffset)%len(playerImgs)]
 }
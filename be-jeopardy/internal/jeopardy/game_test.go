@@ -2,7 +2,11 @@ package jeopardy
 
 import (
 	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/rileythomp/jeopardy/be-jeopardy/internal/db"
 	"github.com/stretchr/testify/assert"
@@ -11,12 +15,13 @@ import (
 func TestSetQuestions(t *testing.T) {
 	t.Run("test setting questions", func(t *testing.T) {
 		ctx := context.Background()
-		db, err := db.NewJeopardyDB(ctx)
-		if err != nil {
-			t.Fatalf("Error connecting to database: %s", err.Error())
+		g := Game{
+			GameConfig: GameConfig{Categories: numCategories, Questions: numQuestions},
+			jeopardyDB: newTestDB(t),
+			clock:      realClock{},
+			rng:        rand.New(rand.NewPCG(1, 1)),
 		}
-		g := Game{jeopardyDB: db}
-		err = g.setQuestions(ctx)
+		err := g.setQuestions(ctx)
 		assert.NoError(t, err)
 		assert.Len(t, g.FirstRound, 6)
 		for _, category := range g.FirstRound {
@@ -35,3 +40,289 @@ func TestSetQuestions(t *testing.T) {
 		assert.Zero(t, g.FinalQuestion.Value)
 	})
 }
+
+func TestBoardDimensions(t *testing.T) {
+	t.Run("test quick game board with a custom value ladder", func(t *testing.T) {
+		config := GameConfig{Penalty: true, PickTimeout: 30, BuzzTimeout: 30, AnswerTimeout: 30, WagerTimeout: 30, Categories: 4, Questions: 4, FirstRoundValues: []int{100, 300, 500, 1500}}
+		err := config.Validate()
+		assert.NoError(t, err)
+		g, err := NewGame(context.Background(), newTestDB(t), config, WithSeed(3))
+		assert.NoError(t, err)
+		defer g.stop()
+
+		assert.NoError(t, g.do(func() error {
+			assert.Len(t, g.FirstRound, 4)
+			assert.Len(t, g.SecondRound, 4)
+			dailyDoubles := 0
+			for _, category := range g.FirstRound {
+				assert.Len(t, category.Questions, 4)
+				for i, q := range category.Questions {
+					assert.Equal(t, []int{100, 300, 500, 1500}[i], q.Value)
+					if q.DailyDouble {
+						dailyDoubles++
+					}
+				}
+			}
+			assert.Equal(t, 1, dailyDoubles)
+			for _, category := range g.SecondRound {
+				for i, q := range category.Questions {
+					assert.Equal(t, 400*(i+1), q.Value)
+				}
+			}
+			assert.Equal(t, 1500, g.roundMax())
+			g.Round = SecondRound
+			assert.Equal(t, 1600, g.roundMax())
+			assert.Error(t, g.processPick(&Player{CanPick: true}, 0, 4))
+			return nil
+		}))
+	})
+
+	t.Run("test invalid board configs", func(t *testing.T) {
+		for _, config := range []GameConfig{
+			{Penalty: true, PickTimeout: 30, BuzzTimeout: 30, AnswerTimeout: 30, WagerTimeout: 30, Categories: 9, Questions: 5},
+			{Penalty: true, PickTimeout: 30, BuzzTimeout: 30, AnswerTimeout: 30, WagerTimeout: 30, Categories: 6, Questions: 6},
+			{Penalty: true, PickTimeout: 30, BuzzTimeout: 30, AnswerTimeout: 30, WagerTimeout: 30, Categories: 6, Questions: 3, FirstRoundValues: []int{100, 200}},
+			{Penalty: true, PickTimeout: 30, BuzzTimeout: 30, AnswerTimeout: 30, WagerTimeout: 30, Categories: 6, Questions: 3, SecondRoundValues: []int{300, 200, 100}},
+		} {
+			assert.Error(t, config.Validate())
+		}
+		config, err := NewConfig(false, true, 0, 30, 30, 30, 30, nil, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 6, config.Categories)
+		assert.Equal(t, 5, config.Questions)
+	})
+}
+
+func newTestDB(t *testing.T) *db.MemoryDB {
+	t.Helper()
+	memoryDB, err := db.NewMemoryDBFromFile("../db/testdata/clues.json")
+	if err != nil {
+		t.Fatalf("Error loading test clues: %s", err.Error())
+	}
+	return memoryDB
+}
+
+type testConn struct {
+	mu        sync.Mutex
+	responses []Response
+	spectated []spectatorResponse
+	chats     []ChatMessage
+}
+
+// ReadMessage blocks forever, like a client that never sends anything.
+func (c *testConn) ReadMessage() (int, []byte, error) {
+	select {}
+}
+
+func (c *testConn) WriteJSON(v interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch resp := v.(type) {
+	case Response:
+		c.responses = append(c.responses, resp)
+	case spectatorResponse:
+		c.spectated = append(c.spectated, resp)
+	case ChatMessage:
+		c.chats = append(c.chats, resp)
+	}
+	return nil
+}
+
+func (c *testConn) Close() error {
+	return nil
+}
+
+func newTestGame(t *testing.T, seed uint64, clock *ManualClock, numPlayers int) *Game {
+	t.Helper()
+	questionDB := newTestDB(t)
+	questionDB.SetSeed(seed)
+	config, err := NewConfig(true, true, 0, 30, 30, 30, 30, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create config: %s", err)
+	}
+	game, err := NewGame(context.Background(), questionDB, config, WithClock(clock), WithSeed(seed))
+	if err != nil {
+		t.Fatalf("Failed to create game: %s", err)
+	}
+	err = game.do(func() error {
+		for i := 0; i < numPlayers; i++ {
+			player := game.addPlayer(GameRequest{PlayerName: fmt.Sprintf("player%d", i)})
+			game.connect(player, &testConn{})
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to add players: %s", err)
+	}
+	return game
+}
+
+type gameResult struct {
+	name         string
+	scores       []int
+	dailyDoubles []string
+}
+
+// playScriptedGame plays a full game with playScript and reports its outcome.
+func playScriptedGame(t *testing.T, seed uint64) gameResult {
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	g := newTestGame(t, seed, clock, 2)
+	playScript(t, g, clock)
+
+	g.stop()
+	result := gameResult{name: g.Name}
+	for _, p := range g.Players {
+		result.scores = append(result.scores, p.score())
+	}
+	for _, round := range [][]Category{g.FirstRound, g.SecondRound} {
+		for _, category := range round {
+			for _, q := range category.Questions {
+				if q.DailyDouble {
+					result.dailyDoubles = append(result.dailyDoubles, q.Clue)
+				}
+			}
+		}
+	}
+	return result
+}
+
+// playScript plays a game to the end where the players follow a fixed
+// script and every timeout is driven by the manual clock.
+func playScript(t *testing.T, g *Game, clock *ManualClock) {
+	ctx := context.Background()
+	assert.NoError(t, g.do(func() error {
+		g.start()
+		return nil
+	}))
+	assert.Equal(t, BoardIntro, g.State)
+	clock.Advance(boardIntroTimeout * time.Second)
+
+	for turn := 0; g.State != PostGame; turn++ {
+		if turn > 500 {
+			t.Fatalf("Game did not end, stuck in state %d", g.State)
+		}
+		for _, p := range g.Players {
+			msg := Message{Player: p, State: g.State}
+			switch {
+			case g.State == RecvPick && p.canPick():
+				msg.CatIdx, msg.ValIdx = g.firstAvailableQuestion()
+			case g.State == RecvWager && p.canWager():
+				msg.Wager = max(5, p.score()/2)
+			case g.State == RecvBuzz && p.canBuzz() && turn%5 != 4:
+				msg.IsPass = p.id() != g.Players[turn%2].id()
+			case g.State == RecvAns && p.canAnswer():
+				msg.Answer = g.CurQuestion.Answer
+				if turn%3 == 1 {
+					msg.Answer = "wrong"
+				}
+			default:
+				continue
+			}
+			assert.NoError(t, g.do(func() error {
+				return g.processMsg(ctx, msg)
+			}))
+		}
+		if g.State == RecvBuzz && turn%5 == 4 {
+			clock.Advance(time.Duration(g.BuzzTimeout) * time.Second)
+		}
+		if g.State == BoardIntro {
+			clock.Advance(boardIntroTimeout * time.Second)
+		}
+	}
+}
+
+func TestDeterministicGame(t *testing.T) {
+	t.Run("test same seed plays the same game", func(t *testing.T) {
+		first := playScriptedGame(t, 42)
+		second := playScriptedGame(t, 42)
+		assert.Equal(t, first, second)
+		assert.Len(t, first.dailyDoubles, 3)
+		assert.NotEqual(t, []int{0, 0}, first.scores)
+	})
+}
+
+func TestManualClock(t *testing.T) {
+	t.Run("test timers fire in deadline order", func(t *testing.T) {
+		clock := NewManualClock(time.Time{})
+		fired := []int{}
+		clock.AfterFunc(3*time.Second, func() { fired = append(fired, 3) })
+		stop := clock.AfterFunc(2*time.Second, func() { fired = append(fired, 2) })
+		clock.AfterFunc(1*time.Second, func() {
+			fired = append(fired, 1)
+			clock.AfterFunc(1*time.Second, func() { fired = append(fired, 4) })
+		})
+		stop()
+		clock.Advance(2 * time.Second)
+		assert.Equal(t, []int{1, 4}, fired)
+		assert.Equal(t, 1, clock.Pending())
+		clock.Advance(time.Second)
+		assert.Equal(t, []int{1, 4, 3}, fired)
+		assert.Equal(t, time.Time{}.Add(3*time.Second), clock.Now())
+	})
+}
+
+func TestConcurrentGame(t *testing.T) {
+	t.Run("test game with bots and concurrent requests", func(t *testing.T) {
+		clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		g := newTestGame(t, 7, clock, 0)
+		var human *Player
+		assert.NoError(t, g.do(func() error {
+			human = g.addPlayer(GameRequest{PlayerName: "human"})
+			human.setConn(&testConn{})
+			for i := 0; i < 2; i++ {
+				bot := g.newBot()
+				g.Players = append(g.Players, bot)
+				bot.processMessages(g)
+			}
+			return nil
+		}))
+		games.addPrivateGame(g)
+		games.addPlayer(human.Id, g)
+		defer func() {
+			_ = g.do(func() error {
+				removeGame(g)
+				return nil
+			})
+		}()
+
+		assert.NoError(t, StartGame(human.Id))
+
+		stopRequests := make(chan struct{})
+		requestsDone := make(chan struct{})
+		go func() {
+			defer close(requestsDone)
+			for {
+				select {
+				case <-stopRequests:
+					return
+				default:
+				}
+				_ = GetPrivateGames()
+				_ = GetPlayerGames()
+				_, _ = g.Snapshot()
+				_ = AddBot(human.Id)
+				select {
+				case g.chatChan <- ChatMessage{PlayerName: "human", Message: "hi"}:
+				case <-g.done:
+				}
+				CleanUpGames()
+			}
+		}()
+
+		state := PreGame
+		for i := 0; i < 20000 && state != PostGame; i++ {
+			clock.Advance(time.Second)
+			assert.NoError(t, g.do(func() error {
+				state = g.State
+				return nil
+			}))
+		}
+		close(stopRequests)
+		<-requestsDone
+		assert.Equal(t, PostGame, state)
+		assert.NoError(t, g.do(func() error {
+			assert.Len(t, g.Players, maxPlayers)
+			return nil
+		}))
+	})
+}
@@ -0,0 +1,86 @@
+package jeopardy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/socket"
+)
+
+// addHost makes the player creating a game in host mode its host. The host
+// runs the board and judges answers without taking a seat in the game.
+func (g *Game) addHost(req GameRequest) *Player {
+	imgUrl := req.PlayerImg
+	if imgUrl == "" {
+		imgUrl = g.nextImg()
+	}
+	g.Host = NewPlayer(req.PlayerName, imgUrl, req.PlayerEmail)
+	g.record(GameEvent{Type: EventHosted, Player: loggedPlayer(g.Host)})
+	return g.Host
+}
+
+func (g *Game) isHost(player GamePlayer) bool {
+	return g.Host != nil && g.Host.id() == player.id()
+}
+
+// processHostMsg handles a message from the host, who can judge answers and
+// disputes, change Final Jeopardy results, override scores and skip clues.
+func (g *Game) processHostMsg(ctx context.Context, msg Message) error {
+	if msg.ScoreFor != "" {
+		return g.overrideScore(msg.ScoreFor, msg.Score)
+	}
+	if msg.Skip {
+		return g.skipClue(ctx)
+	}
+	switch g.State {
+	case RecvJudgment:
+		g.judgeAnswer(ctx, msg.Correct)
+		return nil
+	case RecvDispute:
+		return g.processDispute(ctx, g.Host, msg.Dispute)
+	case PostGame:
+		return g.processProtest(g.Host, msg.ProtestFor)
+	}
+	return fmt.Errorf("host cannot act in this state")
+}
+
+func (g *Game) overrideScore(playerId string, score int) error {
+	player, err := g.getPlayerById(playerId)
+	if err != nil {
+		return err
+	}
+	player.addToScore(score - player.score())
+	g.messageAllPlayers("Host set %s's score to %d", player.name(), score)
+	return nil
+}
+
+// skipClue takes the clue in play off the board without anyone scoring.
+func (g *Game) skipClue(ctx context.Context) error {
+	if g.Round == FinalRound {
+		return fmt.Errorf("host cannot skip the final question")
+	}
+	switch g.State {
+	case RecvBuzz, RecvWager, RecvAns, RecvJudgment:
+	default:
+		return fmt.Errorf("no clue to skip")
+	}
+	g.cancelBuzzTimeout()
+	for _, p := range g.Players {
+		p.cancelAnswerTimeout()
+		p.cancelWagerTimeout()
+	}
+	g.skipQuestion(ctx)
+	return nil
+}
+
+func (g *Game) messageHost(msg string) {
+	if g.Host == nil || g.Host.conn() == nil {
+		return
+	}
+	_ = g.Host.sendMessage(Response{
+		Code:      socket.Ok,
+		Message:   msg,
+		Game:      g,
+		CurPlayer: g.Host,
+	})
+}
@@ -0,0 +1,105 @@
+package jeopardy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHostMode(t *testing.T) {
+	t.Run("test host judges answers, overrides scores and skips clues", func(t *testing.T) {
+		ctx := context.Background()
+		eventStore = newTestDB(t)
+		defer func() { eventStore = nil }()
+
+		clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		questionDB := newTestDB(t)
+		questionDB.SetSeed(13)
+		config := GameConfig{FullGame: true, Penalty: true, HostMode: true, PickTimeout: 30, BuzzTimeout: 30, AnswerTimeout: 30, WagerTimeout: 30}
+		err := config.Validate()
+		assert.NoError(t, err)
+		g, err := NewGame(ctx, questionDB, config, WithClock(clock), WithSeed(13))
+		assert.NoError(t, err)
+		defer g.stop()
+
+		hostConn := &testConn{}
+		var host *Player
+		assert.NoError(t, g.do(func() error {
+			host = g.addHost(GameRequest{PlayerName: "host"})
+			g.connect(host, hostConn)
+			for i := 0; i < 2; i++ {
+				player := g.addPlayer(GameRequest{PlayerName: fmt.Sprintf("player%d", i)})
+				g.connect(player, &testConn{})
+			}
+			assert.Error(t, g.validateName("host"))
+			assert.Len(t, g.Players, 2)
+			g.start()
+			return nil
+		}))
+		clock.Advance(boardIntroTimeout * time.Second)
+
+		picker, buzzer := g.Players[0], g.Players[1]
+		catIdx := 0
+		for g.FirstRound[catIdx].Questions[0].DailyDouble {
+			catIdx++
+		}
+		assert.NoError(t, g.do(func() error {
+			assert.NoError(t, g.processMsg(ctx, Message{Player: picker, State: RecvPick, CatIdx: catIdx, ValIdx: 0}))
+			assert.NoError(t, g.processMsg(ctx, Message{Player: buzzer, State: RecvBuzz}))
+			assert.NoError(t, g.processMsg(ctx, Message{Player: buzzer, State: RecvAns, Answer: "not even close"}))
+			assert.Equal(t, RecvJudgment, g.State)
+			assert.Equal(t, 0, buzzer.score())
+			assert.Error(t, g.processMsg(ctx, Message{Player: picker, State: RecvJudgment}))
+			return g.processMsg(ctx, Message{Player: host, State: RecvJudgment, Correct: true})
+		}))
+
+		assert.NoError(t, g.do(func() error {
+			assert.Equal(t, RecvPick, g.State)
+			assert.True(t, buzzer.canPick())
+			assert.Equal(t, 200, buzzer.score())
+			assert.True(t, g.CurQuestion.CurAns.Correct)
+			assert.False(t, g.CurQuestion.CanChoose)
+
+			assert.NoError(t, g.processMsg(ctx, Message{Player: host, State: RecvPick, ScoreFor: picker.id(), Score: 1234}))
+			assert.Equal(t, 1234, picker.score())
+			assert.Error(t, g.processMsg(ctx, Message{Player: host, State: RecvPick, Skip: true}))
+
+			assert.NoError(t, g.processMsg(ctx, Message{Player: buzzer, State: RecvPick, CatIdx: catIdx, ValIdx: 1}))
+			return g.processMsg(ctx, Message{Player: host, State: g.State, Skip: true})
+		}))
+
+		assert.NoError(t, g.do(func() error {
+			assert.Equal(t, RecvPick, g.State)
+			assert.True(t, buzzer.canPick())
+			assert.False(t, g.FirstRound[catIdx].Questions[1].CanChoose)
+			assert.Equal(t, 200, buzzer.score())
+			assert.Equal(t, 1234, picker.score())
+			return nil
+		}))
+		g.stop()
+
+		hostConn.mu.Lock()
+		judged := false
+		for _, resp := range hostConn.responses {
+			if resp.Message == "Waiting for the host to judge the answer" {
+				judged = true
+			}
+		}
+		hostConn.mu.Unlock()
+		assert.True(t, judged)
+
+		events, err := GetGameReplay(ctx, g.Id)
+		assert.NoError(t, err)
+		r, err := Replay(events)
+		if !assert.NoError(t, err) {
+			return
+		}
+		want, _ := json.Marshal(g.snapshot())
+		got, _ := json.Marshal(r.snapshot())
+		assert.JSONEq(t, string(want), string(got))
+	})
+}
@@ -2,6 +2,7 @@ package jeopardy
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -17,38 +18,80 @@ type GameRequest struct {
 	PlayerImg             string        `json:"imgUrl"`
 	PlayerEmail           string        `json:"email"`
 	Bots                  int           `json:"bots"`
+	BotLevels             []string      `json:"botLevels"`
 	FullGame              bool          `json:"fullGame"`
 	Penalty               bool          `json:"penalty"`
+	HostMode              bool          `json:"hostMode"`
+	TeamMode              bool          `json:"teamMode"`
+	MultipleChoice        bool          `json:"multipleChoice"`
+	Team                  string        `json:"team"`
 	PickConfig            int           `json:"pickConfig"`
 	BuzzConfig            int           `json:"buzzConfig"`
 	AnswerConfig          int           `json:"answerConfig"`
 	WagerConfig           int           `json:"wagerConfig"`
+	Categories            int           `json:"categories"`
+	Questions             int           `json:"questions"`
 	FirstRoundCategories  []db.Category `json:"firstRoundCategories"`
 	SecondRoundCategories []db.Category `json:"secondRoundCategories"`
+	FirstRoundValues      []int         `json:"firstRoundValues"`
+	SecondRoundValues     []int         `json:"secondRoundValues"`
+	DailyDoubles          []int         `json:"dailyDoubles"`
+	DailyDoublePlacement  string        `json:"dailyDoublePlacement"`
+	CustomBoard           string        `json:"customBoard"`
+	// Board is a board file to play on, which is parsed on its own to
+	// point at exactly what is wrong with it.
+	Board json.RawMessage `json:"board"`
 }
 
 var GameFull = fmt.Errorf("Game is full")
 
-var (
-	privateGames = map[string]*Game{}
-	publicGames  = map[string]*Game{}
-	playerGames  = map[string]*Game{}
-)
+// gameConfig is the config the request asks for, yet to be validated.
+func (req GameRequest) gameConfig() GameConfig {
+	return GameConfig{
+		FullGame:              req.FullGame,
+		Penalty:               req.Penalty,
+		HostMode:              req.HostMode,
+		TeamMode:              req.TeamMode,
+		MultipleChoice:        req.MultipleChoice,
+		Bots:                  req.Bots,
+		BotLevels:             req.BotLevels,
+		PickTimeout:           req.PickConfig,
+		BuzzTimeout:           req.BuzzConfig,
+		AnswerTimeout:         req.AnswerConfig,
+		WagerTimeout:          req.WagerConfig,
+		Categories:            req.Categories,
+		Questions:             req.Questions,
+		FirstRoundValues:      req.FirstRoundValues,
+		SecondRoundValues:     req.SecondRoundValues,
+		DailyDoubles:          req.DailyDoubles,
+		DailyDoublePlacement:  req.DailyDoublePlacement,
+		FirstRoundCategories:  req.FirstRoundCategories,
+		SecondRoundCategories: req.SecondRoundCategories,
+	}
+}
 
-func GetPublicGames() map[string]*Game {
-	return publicGames
+func GetPublicGames() map[string]json.RawMessage {
+	return snapshots(games.public())
 }
 
-func GetPrivateGames() map[string]*Game {
-	return privateGames
+func GetPrivateGames() map[string]json.RawMessage {
+	return snapshots(games.private())
 }
 
-func GetPlayerGames() map[string]string {
-	playerGameNames := map[string]string{}
-	for playerId, game := range playerGames {
-		playerGameNames[playerId] = game.Name
+func snapshots(gs []*Game) map[string]json.RawMessage {
+	snapshots := map[string]json.RawMessage{}
+	for _, g := range gs {
+		snapshot, err := g.Snapshot()
+		if err != nil {
+			continue
+		}
+		snapshots[g.Name] = snapshot
 	}
-	return playerGameNames
+	return snapshots
+}
+
+func GetPlayerGames() map[string]string {
+	return games.playerGameNames()
 }
 
 func (g *Game) validateName(name string) error {
@@ -60,149 +103,237 @@ func (g *Game) validateName(name string) error {
 			return fmt.Errorf("Sorry, %s is already taken", name)
 		}
 	}
+	for _, s := range g.Spectators {
+		if s.name() == name {
+			return fmt.Errorf("Sorry, %s is already taken", name)
+		}
+	}
+	if g.Host != nil && g.Host.name() == name {
+		return fmt.Errorf("Sorry, %s is already taken", name)
+	}
+	for _, p := range g.Players {
+		if t, ok := p.(*Team); ok {
+			for _, m := range t.Members {
+				if m.name() == name && m.conn() != nil {
+					return fmt.Errorf("Sorry, %s is already taken", name)
+				}
+			}
+		}
+	}
 	return nil
 }
 
 func CreatePrivateGame(ctx context.Context, req GameRequest) (*Game, string, error, int) {
-	jeopardyDB, err := db.NewJeopardyDB(ctx)
-	if err != nil {
-		return &Game{}, "", err, socket.ServerError
+	opts := []GameOption{}
+	if len(req.Board) > 0 && string(req.Board) != "null" {
+		var board BoardFile
+		var err error
+		if req, board, err = importedBoardRequest(req); err != nil {
+			return &Game{}, "", err, socket.BadRequest
+		}
+		opts = append(opts, withImportedBoard(board))
 	}
-	config, err := NewConfig(
-		req.FullGame, req.Penalty, req.Bots,
-		req.PickConfig, req.BuzzConfig, req.AnswerConfig, req.WagerConfig,
-		req.FirstRoundCategories, req.SecondRoundCategories,
-	)
-	if err != nil {
+	if req.CustomBoard != "" {
+		var err error
+		if req, err = customBoardRequest(ctx, req); err != nil {
+			return &Game{}, "", err, socket.BadRequest
+		}
+	}
+	config := req.gameConfig()
+	config.CustomBoard = req.CustomBoard
+	if err := config.Validate(); err != nil {
 		return &Game{}, "", err, socket.BadRequest
 	}
-	game, err := NewGame(ctx, jeopardyDB, config)
+	jeopardyDB, err := newJeopardyDB(ctx)
 	if err != nil {
 		return &Game{}, "", err, socket.ServerError
 	}
-	privateGames[game.Name] = game
-
-	if err := game.validateName(req.PlayerName); err != nil {
-		return &Game{}, "", err, socket.BadRequest
-	}
-
-	imgUrl := req.PlayerImg
-	if imgUrl == "" {
-		imgUrl = game.nextImg()
+	game, err := NewGame(ctx, jeopardyDB, config, opts...)
+	if err != nil {
+		jeopardyDB.Close()
+		return &Game{}, "", err, socket.ServerError
 	}
-	player := NewPlayer(req.PlayerName, imgUrl, req.PlayerEmail)
-	game.Players = append(game.Players, player)
-	playerGames[player.Id] = game
 
-	for i := 0; i < game.Bots; i++ {
-		bot := NewBot(genBotName(), i)
-		game.Players = append(game.Players, bot)
-		bot.processMessages()
+	var player *Player
+	err = game.do(func() error {
+		if err := game.validateName(req.PlayerName); err != nil {
+			return err
+		}
+		if game.HostMode {
+			player = game.addHost(req)
+		} else if game.TeamMode {
+			var err error
+			if player, err = game.joinTeam(req); err != nil {
+				return err
+			}
+		} else {
+			player = game.addPlayer(req)
+		}
+		for i := 0; i < game.Bots; i++ {
+			game.addBot(len(game.Players)).processMessages(game)
+		}
+		return nil
+	})
+	if err != nil {
+		game.discard()
+		return &Game{}, "", err, socket.BadRequest
 	}
+	games.addPrivateGame(game)
+	games.addPlayer(player.Id, game)
 
 	return game, player.Id, nil, 0
 }
 
 func JoinPublicGame(ctx context.Context, req GameRequest) (*Game, string, error, int) {
-	var game *Game
-	for _, g := range publicGames {
-		if len(g.Players) < maxPlayers && g.validateName(req.PlayerName) == nil {
-			game = g
-			break
-		}
-	}
-	if game == nil {
-		jeopardyDB, err := db.NewJeopardyDB(ctx)
-		if err != nil {
-			return &Game{}, "", err, socket.ServerError
-		}
-		config, err := NewConfig(
-			req.FullGame, req.Penalty, req.Bots,
-			req.PickConfig, req.BuzzConfig, req.AnswerConfig, req.WagerConfig,
-			req.FirstRoundCategories, req.SecondRoundCategories,
-		)
-		if err != nil {
-			return &Game{}, "", err, socket.BadRequest
-		}
-		game, err = NewGame(ctx, jeopardyDB, config)
-		if err != nil {
-			return &Game{}, "", err, socket.ServerError
+	if req.HostMode {
+		return &Game{}, "", fmt.Errorf("Public games cannot have a host"), socket.BadRequest
+	}
+	if req.TeamMode {
+		return &Game{}, "", fmt.Errorf("Public games cannot have teams"), socket.BadRequest
+	}
+	// joins that find no open seat would each start their own game
+	games.joinMu.Lock()
+	defer games.joinMu.Unlock()
+	var player *Player
+	for _, g := range games.public() {
+		err := g.do(func() error {
+			if len(g.Players) >= maxPlayers {
+				return GameFull
+			}
+			if err := g.validateName(req.PlayerName); err != nil {
+				return err
+			}
+			player = g.addPlayer(req)
+			return nil
+		})
+		if err == nil {
+			games.addPlayer(player.Id, g)
+			return g, player.Id, nil, socket.Ok
 		}
-		publicGames[game.Name] = game
 	}
 
-	if err := game.validateName(req.PlayerName); err != nil {
+	config := req.gameConfig()
+	config.MultipleChoice = false
+	if err := config.Validate(); err != nil {
 		return &Game{}, "", err, socket.BadRequest
 	}
-	imgUrl := req.PlayerImg
-	if imgUrl == "" {
-		imgUrl = game.nextImg()
+	jeopardyDB, err := newJeopardyDB(ctx)
+	if err != nil {
+		return &Game{}, "", err, socket.ServerError
 	}
-	player := NewPlayer(req.PlayerName, imgUrl, req.PlayerEmail)
-	game.Players = append(game.Players, player)
-	playerGames[player.Id] = game
+	game, err := NewGame(ctx, jeopardyDB, config)
+	if err != nil {
+		jeopardyDB.Close()
+		return &Game{}, "", err, socket.ServerError
+	}
+	err = game.do(func() error {
+		if err := game.validateName(req.PlayerName); err != nil {
+			return err
+		}
+		game.public = true
+		player = game.addPlayer(req)
+		return nil
+	})
+	if err != nil {
+		game.discard()
+		return &Game{}, "", err, socket.BadRequest
+	}
+	games.addPublicGame(game)
+	games.addPlayer(player.Id, game)
 
 	return game, player.Id, nil, socket.Ok
 }
 
-func findGame(joinCode string) *Game {
-	for _, g := range publicGames {
-		if strings.EqualFold(g.Name, joinCode) || strings.EqualFold(g.Code, joinCode) {
-			return g
-		}
+// addPlayer adds a new player to the game for the given request.
+func (g *Game) addPlayer(req GameRequest) *Player {
+	imgUrl := req.PlayerImg
+	if imgUrl == "" {
+		imgUrl = g.nextImg()
 	}
-	for _, g := range privateGames {
-		if strings.EqualFold(g.Name, joinCode) || strings.EqualFold(g.Code, joinCode) {
-			return g
-		}
+	player := NewPlayer(req.PlayerName, imgUrl, req.PlayerEmail)
+	g.Players = append(g.Players, player)
+	g.record(GameEvent{Type: EventJoined, Player: loggedPlayer(player)})
+	return player
+}
+
+// addBot puts a new bot in the given slot, taking over the state of the
+// player it replaces, or after the last player.
+func (g *Game) addBot(slot int) *Bot {
+	bot := g.newBot()
+	if slot == len(g.Players) {
+		g.Players = append(g.Players, bot)
+	} else {
+		bot.copyState(g.Players[slot])
+		g.Players[slot] = bot
 	}
-	return nil
+	g.record(GameEvent{Type: EventBotAdded, Slot: slot, Player: loggedPlayer(bot)})
+	return bot
+}
+
+// rejoin hands the slot of a disconnected player to someone joining the game.
+func (g *Game) rejoin(player GamePlayer, slot int, id, name, imgUrl string) {
+	player.setId(id)
+	player.setName(name)
+	player.setImg(imgUrl)
+	g.record(GameEvent{Type: EventRejoined, Slot: slot, Player: loggedPlayer(player)})
+}
+
+func (g *Game) connect(player GamePlayer, conn SafeConn) {
+	player.setConn(conn)
+	g.record(GameEvent{Type: EventConnected, PlayerId: player.id()})
 }
 
 func JoinGameByCode(req GameRequest, joinCode string) (*Game, string, error) {
-	game := findGame(joinCode)
+	game := games.find(joinCode)
 	if game == nil {
 		return &Game{}, "", fmt.Errorf("Game not found")
 	}
-
-	if err := game.validateName(req.PlayerName); err != nil {
-		return &Game{}, "", err
+	if game.tournament != nil {
+		return &Game{}, "", fmt.Errorf("Tournament games cannot be joined")
+	}
+	if game.DailyChallenge != "" {
+		return &Game{}, "", fmt.Errorf("Daily challenges are played solo")
 	}
 
 	var player GamePlayer
-	for _, p := range game.Players {
-		if p.conn() == nil {
-			delete(playerGames, p.id())
-			player = p
-			player.setId(uuid.New().String())
-			player.setName(req.PlayerName)
-			imgUrl := req.PlayerImg
-			if imgUrl == "" {
-				imgUrl = game.nextImg()
+	err := game.do(func() error {
+		if err := game.validateName(req.PlayerName); err != nil {
+			return err
+		}
+		if game.TeamMode {
+			var err error
+			player, err = game.joinTeam(req)
+			return err
+		}
+		for i, p := range game.Players {
+			if p.conn() == nil {
+				games.removePlayer(p.id())
+				player = p
+				imgUrl := req.PlayerImg
+				if imgUrl == "" {
+					imgUrl = game.nextImg()
+				}
+				game.rejoin(player, i, uuid.New().String(), req.PlayerName, imgUrl)
+				return nil
 			}
-			player.setImg(imgUrl)
-			break
 		}
-	}
-	if player == nil {
 		if len(game.Players) >= maxPlayers {
-			return &Game{}, "", GameFull
-		}
-		imgUrl := req.PlayerImg
-		if imgUrl == "" {
-			imgUrl = game.nextImg()
+			return GameFull
 		}
-		player = NewPlayer(req.PlayerName, imgUrl, req.PlayerEmail)
-		game.Players = append(game.Players, player)
+		player = game.addPlayer(req)
+		return nil
+	})
+	if err != nil {
+		return &Game{}, "", err
 	}
 
-	playerGames[player.id()] = game
+	games.addPlayer(player.id(), game)
 
 	return game, player.id(), nil
 }
 
 func GetPlayerGame(playerId string) (*Game, error) {
-	game, ok := playerGames[playerId]
+	game, ok := games.playerGame(playerId)
 	if !ok {
 		return nil, fmt.Errorf("No game found for player")
 	}
@@ -214,30 +345,35 @@ func AddBot(playerId string) error {
 	if err != nil {
 		return err
 	}
+	if game.tournament != nil {
+		return fmt.Errorf("Bots cannot join tournament games")
+	}
+	if game.DailyChallenge != "" {
+		return fmt.Errorf("Daily challenges are played solo")
+	}
 
-	var bot *Bot
-	for i, p := range game.Players {
-		if p.conn() == nil {
-			delete(playerGames, p.id())
-			bot = NewBot(genBotName(), game.numBots())
-			bot.copyState(p)
-			game.Players[i] = bot
-			break
+	return game.do(func() error {
+		var bot *Bot
+		for i, p := range game.Players {
+			if p.conn() == nil {
+				games.removePlayer(p.id())
+				bot = game.addBot(i)
+				break
+			}
 		}
-	}
-	if bot == nil {
-		if len(game.Players) >= maxPlayers {
-			return GameFull
+		if bot == nil {
+			if len(game.Players) >= maxPlayers {
+				return GameFull
+			}
+			bot = game.addBot(len(game.Players))
 		}
-		bot = NewBot(genBotName(), game.numBots())
-		game.Players = append(game.Players, bot)
-	}
 
-	bot.processMessages()
+		bot.processMessages(game)
 
-	game.messageAllPlayers("Waiting to start")
+		game.messageAllPlayers("Waiting to start")
 
-	return nil
+		return nil
+	})
 }
 
 func PlayGame(playerId, gameName string, conn SafeConn) error {
@@ -251,20 +387,25 @@ func PlayGame(playerId, gameName string, conn SafeConn) error {
 		return fmt.Errorf("Game names do not match")
 	}
 
-	player, err := game.getPlayerById(playerId)
-	if err != nil {
-		return err
-	}
-	if player.conn() != nil {
-		return fmt.Errorf("Player already playing")
-	}
-	player.setConn(conn)
-	player.sendPings()
-	player.readMessages(game.msgChan, game.disconnectChan)
+	return game.do(func() error {
+		player, err := game.getMemberById(playerId)
+		if err != nil {
+			return err
+		}
+		if player.conn() != nil {
+			return fmt.Errorf("Player already playing")
+		}
+		if game.isSpectator(player) {
+			return game.watch(player, conn)
+		}
+		game.connect(player, conn)
+		player.sendPings()
+		player.readMessages(game.msgChan, game.disconnectChan, game.done)
 
-	game.messageAllPlayers("Waiting to start")
+		game.messageAllPlayers("Waiting to start")
 
-	return nil
+		return nil
+	})
 }
 
 func StartGame(playerId string) error {
@@ -273,17 +414,29 @@ func StartGame(playerId string) error {
 		return err
 	}
 
-	if game.Disconnected {
-		game.Disconnected = false
+	return game.do(func() error {
+		if len(game.Players) == 0 {
+			return fmt.Errorf("Game has no players")
+		}
+		game.start()
+		return nil
+	})
+}
+
+func (g *Game) start() {
+	g.record(GameEvent{Type: EventStarted})
+	if g.StartedAt.IsZero() {
+		g.StartedAt = g.clock.Now()
 	}
-	if game.Paused {
-		game.resumeGame()
+	if g.Disconnected {
+		g.Disconnected = false
+	}
+	if g.Paused {
+		g.resumeGame()
 	} else {
-		game.startRound(game.Players[0])
+		g.startRound(g.Players[0])
 	}
-	game.messageAllPlayers("We are ready to play")
-
-	return nil
+	g.messageAllPlayers("We are ready to play")
 }
 
 func LeaveGame(playerId string) error {
@@ -292,14 +445,16 @@ func LeaveGame(playerId string) error {
 		return err
 	}
 
-	player, err := game.getPlayerById(playerId)
-	if err != nil {
-		return err
-	}
+	return game.do(func() error {
+		player, err := game.getMemberById(playerId)
+		if err != nil {
+			return err
+		}
 
-	game.disconnectChan <- player
+		game.disconnectPlayer(player)
 
-	return nil
+		return nil
+	})
 }
 
 func PlayAgain(playerId string) error {
@@ -307,26 +462,43 @@ func PlayAgain(playerId string) error {
 	if err != nil {
 		return err
 	}
-
-	player, err := game.getPlayerById(playerId)
-	if err != nil {
-		return err
+	if game.tournament != nil {
+		return fmt.Errorf("Tournament games cannot be played again")
 	}
+	if game.DailyChallenge != "" {
+		return fmt.Errorf("The daily challenge can only be played once a day")
+	}
+
+	return game.do(func() error {
+		player, err := game.getMemberById(playerId)
+		if err != nil {
+			return err
+		}
+		if team := game.teamOf(player); team != nil {
+			player = team
+		}
 
+		game.playAgain(context.Background(), player)
+		return nil
+	})
+}
+
+func (g *Game) playAgain(ctx context.Context, player GamePlayer) {
+	g.record(GameEvent{Type: EventPlayAgain, PlayerId: player.id()})
 	player.setPlayAgain(true)
 
 	restartGame := true
-	for _, p := range game.Players {
+	for _, p := range g.Players {
 		if !p.playAgain() || p.conn() == nil {
 			restartGame = false
 		}
 	}
 	if restartGame {
-		game.restartChan <- true
-		return nil
+		g.restartGame(ctx)
+		return
 	}
 
-	for _, p := range game.Players {
+	for _, p := range g.Players {
 		msg := fmt.Sprintf("%s wants to play again", player.name())
 		if p.id() == player.id() {
 			msg = "Waiting for all other players to play again"
@@ -334,32 +506,77 @@ func PlayAgain(playerId string) error {
 		_ = p.sendMessage(Response{
 			Code:      socket.Info,
 			Message:   msg,
-			Game:      game,
+			Game:      g,
 			CurPlayer: p,
 		})
 	}
-	return nil
 }
 
-var searchDB *db.JeopardyDB
-var analyticsDB *db.JeopardyDB
-var supabase *db.SupabaseDB
+type (
+	categorySearcher interface {
+		SearchCategories(ctx context.Context, query, start string, secondRound int) ([]db.Category, error)
+	}
 
-func init() {
-	ctx := context.Background()
-	var err error
-	searchDB, err = db.NewJeopardyDB(ctx)
-	if err != nil {
-		log.Fatalf("Error connecting to database: %s", err.Error())
+	analyticsReader interface {
+		GetAnalytics(ctx context.Context) (any, error)
+		GetPlayerAnalytics(ctx context.Context, email string) (db.PlayerAnalytics, error)
+		GetLeaderboard(ctx context.Context, leaderboardType string) ([]*db.LeaderboardUser, error)
 	}
-	analyticsDB, err = db.NewJeopardyDB(ctx)
-	if err != nil {
-		log.Fatalf("Error connecting to database: %s", err.Error())
+
+	userReader interface {
+		GetUserByEmail(ctx context.Context, email string) (db.User, error)
 	}
-	supabase, err = db.NewSupabaseDB(ctx)
+)
+
+var (
+	newJeopardyDB func(ctx context.Context) (jeopardyDB, error)
+	searchDB      categorySearcher
+	analyticsDB   analyticsReader
+	supabase      userReader
+)
+
+// UsePostgresDB connects the package to the Postgres database at
+// DATABASE_URL and reads users from the given Supabase database. Each game
+// gets its own connection pool.
+func UsePostgresDB(ctx context.Context, supabaseDB *db.SupabaseDB) error {
+	postgresDB, err := db.NewJeopardyDB(ctx)
 	if err != nil {
-		log.Fatalf("Error connecting to database: %s", err.Error())
+		return err
 	}
+	newJeopardyDB = func(ctx context.Context) (jeopardyDB, error) {
+		return db.NewJeopardyDB(ctx)
+	}
+	searchDB = postgresDB
+	dailyDoubleDB = postgresDB
+	clueDifficultyDB = postgresDB
+	practiceDB = postgresDB
+	dailyDB = postgresDB
+	alternativeDB = postgresDB
+	customBoardDB = postgresDB
+	analyticsDB = postgresDB
+	gameStore = postgresDB
+	eventStore = postgresDB
+	supabase = supabaseDB
+	return nil
+}
+
+// UseMemoryDB backs every game, search and analytics request with the
+// given in-memory database.
+func UseMemoryDB(memoryDB *db.MemoryDB) {
+	newJeopardyDB = func(ctx context.Context) (jeopardyDB, error) {
+		return memoryDB, nil
+	}
+	searchDB = memoryDB
+	dailyDoubleDB = memoryDB
+	clueDifficultyDB = memoryDB
+	practiceDB = memoryDB
+	dailyDB = memoryDB
+	alternativeDB = memoryDB
+	customBoardDB = memoryDB
+	analyticsDB = memoryDB
+	gameStore = memoryDB
+	eventStore = memoryDB
+	supabase = memoryDB
 }
 
 func SearchCategories(ctx context.Context, category, rounds string) ([]db.Category, error) {
@@ -384,25 +601,43 @@ func SearchCategories(ctx context.Context, category, rounds string) ([]db.Catego
 
 func CleanUpGames() {
 	log.Infof("Performing game cleanup")
-	for _, game := range publicGames {
-		if game.Paused && time.Since(game.PausedAt) > time.Hour {
-			log.Infof("Game %s has been paused for over an hour, removing it", game.Name)
-			removeGame(game)
-		}
-	}
-	for _, game := range privateGames {
-		if game.Paused && time.Since(game.PausedAt) > time.Hour {
-			log.Infof("Game %s has been paused for over an hour, removing it", game.Name)
-			removeGame(game)
-		}
+	for _, game := range games.all() {
+		_ = game.do(func() error {
+			if game.Paused && game.clock.Now().Sub(game.PausedAt) > time.Hour {
+				log.Infof("Game %s has been paused for over an hour, removing it", game.Name)
+				removeGame(game)
+			}
+			return nil
+		})
 	}
 }
 
+// removeGame unregisters the game and stops its event loop. It must be
+// called on the game's event loop.
 func removeGame(g *Game) {
+	if g.tournament != nil && g.State != PostGame {
+		g.tournament.reportMatch(g)
+	}
 	g.jeopardyDB.Close()
-	delete(publicGames, g.Name)
-	delete(privateGames, g.Name)
+	playerIds := []string{}
 	for _, p := range g.Players {
-		delete(playerGames, p.id())
+		playerIds = append(playerIds, p.id())
+		if bot, ok := p.(*Bot); ok {
+			bot.stop()
+		}
+		if team, ok := p.(*Team); ok {
+			for _, m := range team.Members {
+				playerIds = append(playerIds, m.id())
+			}
+		}
+	}
+	for _, s := range g.Spectators {
+		playerIds = append(playerIds, s.id())
+	}
+	if g.Host != nil {
+		playerIds = append(playerIds, g.Host.id())
 	}
+	games.removeGame(g, playerIds)
+	g.forget(context.Background())
+	g.stop()
 }
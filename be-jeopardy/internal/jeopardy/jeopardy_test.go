@@ -0,0 +1,79 @@
+package jeopardy
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/db"
+	"github.com/stretchr/testify/assert"
+)
+
+// closeCountingDB counts how many of the databases handed to games are
+// closed.
+type closeCountingDB struct {
+	*db.MemoryDB
+	closed *atomic.Int32
+}
+
+func (d closeCountingDB) Close() {
+	d.closed.Add(1)
+}
+
+func TestNewGames(t *testing.T) {
+	ctx := context.Background()
+	memoryDB := newTestDB(t)
+	opened, closed := atomic.Int32{}, atomic.Int32{}
+	prevDB := newJeopardyDB
+	newJeopardyDB = func(ctx context.Context) (jeopardyDB, error) {
+		opened.Add(1)
+		return closeCountingDB{memoryDB, &closed}, nil
+	}
+	defer func() {
+		newJeopardyDB = prevDB
+	}()
+
+	t.Run("test concurrent public joins fill games", func(t *testing.T) {
+		joined := make([]*Game, 2*maxPlayers)
+		wg := sync.WaitGroup{}
+		for i := range joined {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				req := GameRequest{PlayerName: fmt.Sprintf("player%d", i), PickConfig: 30, BuzzConfig: 30, AnswerConfig: 30, WagerConfig: 30}
+				g, _, err, _ := JoinPublicGame(ctx, req)
+				assert.NoError(t, err)
+				joined[i] = g
+			}()
+		}
+		wg.Wait()
+
+		players := map[*Game]int{}
+		for _, g := range joined {
+			players[g]++
+		}
+		assert.Len(t, players, 2)
+		for g, n := range players {
+			assert.Equal(t, maxPlayers, n)
+			_ = g.do(func() error {
+				removeGame(g)
+				return nil
+			})
+		}
+		assert.Equal(t, int32(2), opened.Load())
+	})
+
+	t.Run("test rejected games close their database", func(t *testing.T) {
+		opened.Store(0)
+		closed.Store(0)
+		req := GameRequest{PlayerName: "", PickConfig: 30, BuzzConfig: 30, AnswerConfig: 30, WagerConfig: 30}
+		_, _, err, _ := CreatePrivateGame(ctx, req)
+		assert.EqualError(t, err, "Invalid player name")
+		_, _, err, _ = JoinPublicGame(ctx, req)
+		assert.EqualError(t, err, "Invalid player name")
+		assert.Equal(t, int32(2), opened.Load())
+		assert.Equal(t, int32(2), closed.Load())
+	})
+}
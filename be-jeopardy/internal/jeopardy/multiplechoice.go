@@ -0,0 +1,97 @@
+package jeopardy
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// numChoices is how many responses a clue offers in a multiple choice game.
+const numChoices = 4
+
+// setChoices gives every clue on the board its choices. The incorrect
+// choices come from the clue's pool of incorrect responses, topped up with
+// the responses to other clues in its category and then its round.
+func (g *Game) setChoices() {
+	if !g.MultipleChoice {
+		return
+	}
+	for _, round := range [][]Category{g.FirstRound, g.SecondRound} {
+		roundAnswers := answersOf(round...)
+		for _, category := range round {
+			categoryAnswers := answersOf(category)
+			for _, q := range category.Questions {
+				q.Choices = g.choicesFor(q, q.Incorrect, categoryAnswers, roundAnswers)
+			}
+		}
+	}
+	g.FinalQuestion.Choices = g.choicesFor(g.FinalQuestion, g.FinalQuestion.Incorrect, answersOf(g.SecondRound...), answersOf(g.FirstRound...))
+}
+
+func answersOf(categories ...Category) []string {
+	answers := []string{}
+	for _, category := range categories {
+		for _, q := range category.Questions {
+			answers = append(answers, q.Answer)
+		}
+	}
+	return answers
+}
+
+// choicesFor shuffles the question's correct response in with incorrect
+// ones, taking them from each pool in turn until it has enough.
+func (g *Game) choicesFor(q *Question, pools ...[]string) []string {
+	choices := []string{}
+	for _, pool := range pools {
+		pool = slices.Clone(pool)
+		g.rng.Shuffle(len(pool), func(i, j int) {
+			pool[i], pool[j] = pool[j], pool[i]
+		})
+		for _, choice := range pool {
+			if len(choices) == numChoices-1 {
+				break
+			}
+			if choice == "" || q.checkAnswer(choice) || slices.ContainsFunc(choices, func(c string) bool {
+				return strings.EqualFold(c, choice)
+			}) {
+				continue
+			}
+			choices = append(choices, choice)
+		}
+	}
+	choices = append(choices, q.Answer)
+	g.rng.Shuffle(len(choices), func(i, j int) {
+		choices[i], choices[j] = choices[j], choices[i]
+	})
+	return choices
+}
+
+// chosenAnswer is the response a player gave, which in a multiple choice
+// game is the choice they picked.
+func (g *Game) chosenAnswer(msg Message) (string, error) {
+	if !g.MultipleChoice {
+		return msg.Answer, nil
+	}
+	if msg.Choice < 0 || msg.Choice >= len(g.CurQuestion.Choices) {
+		return "", fmt.Errorf("invalid choice")
+	}
+	return g.CurQuestion.Choices[msg.Choice], nil
+}
+
+// scoreValue is what the current clue is worth to the player who answered
+// it, and whether a wrong answer costs them. In multiple choice games a
+// wrong answer to a clue that wasn't wagered on costs a share of its value
+// whether or not the game has penalties, so guessing at random is worth
+// nothing on average.
+func (g *Game) scoreValue(isCorrect bool) (int, bool) {
+	choices := len(g.CurQuestion.Choices)
+	if !g.MultipleChoice || isCorrect || g.CurQuestion.DailyDouble || choices < 2 {
+		return g.CurQuestion.Value, g.Penalty
+	}
+	return g.CurQuestion.Value / (choices - 1), true
+}
+
+// correctChoice is the index of the current question's correct response.
+func (q *Question) correctChoice() int {
+	return slices.Index(q.Choices, q.Answer)
+}
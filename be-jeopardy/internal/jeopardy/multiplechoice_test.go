@@ -0,0 +1,101 @@
+package jeopardy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultipleChoice(t *testing.T) {
+	t.Run("test choices prefer incorrect responses", func(t *testing.T) {
+		g := newGame(nil, GameConfig{MultipleChoice: true}, WithSeed(3))
+		q := &Question{Question: db.Question{
+			Answer:       "Michelangelo",
+			Alternatives: []string{"Michelangelo"},
+			Incorrect:    []string{"Raphael", "Leonardo da Vinci", "michelangelo", "Donatello", "Raphael"},
+		}}
+		q.Choices = g.choicesFor(q, q.Incorrect, []string{"Titian"})
+		assert.ElementsMatch(t, []string{"Michelangelo", "Raphael", "Leonardo da Vinci", "Donatello"}, q.Choices)
+		assert.Equal(t, "Michelangelo", q.Choices[q.correctChoice()])
+
+		q.Incorrect = []string{"Raphael"}
+		choices := g.choicesFor(q, q.Incorrect, []string{"Titian", "Raphael"}, []string{"Caravaggio"})
+		assert.ElementsMatch(t, []string{"Michelangelo", "Raphael", "Titian", "Caravaggio"}, choices)
+	})
+
+	t.Run("test players answer by choice and pay for wrong guesses", func(t *testing.T) {
+		ctx := context.Background()
+		eventStore = newTestDB(t)
+		defer func() { eventStore = nil }()
+
+		clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		questionDB := newTestDB(t)
+		questionDB.SetSeed(5)
+		config := GameConfig{FullGame: true, MultipleChoice: true, PickTimeout: 30, BuzzTimeout: 30, AnswerTimeout: 30, WagerTimeout: 30}
+		err := config.Validate()
+		assert.NoError(t, err)
+		g, err := NewGame(ctx, questionDB, config, WithClock(clock), WithSeed(5))
+		assert.NoError(t, err)
+		defer g.stop()
+
+		for _, round := range [][]Category{g.FirstRound, g.SecondRound} {
+			for _, category := range round {
+				for _, q := range category.Questions {
+					assert.Len(t, q.Choices, numChoices)
+					assert.Contains(t, q.Choices, q.Answer)
+				}
+			}
+		}
+		assert.Contains(t, g.FinalQuestion.Choices, g.FinalQuestion.Answer)
+
+		assert.NoError(t, g.do(func() error {
+			for i := 0; i < 2; i++ {
+				player := g.addPlayer(GameRequest{PlayerName: fmt.Sprintf("player%d", i)})
+				g.connect(player, &testConn{})
+			}
+			g.start()
+			return nil
+		}))
+		clock.Advance(boardIntroTimeout * time.Second)
+
+		picker, guesser := g.Players[0], g.Players[1]
+		catIdx := 0
+		for g.FirstRound[catIdx].Questions[1].DailyDouble {
+			catIdx++
+		}
+		q := g.FirstRound[catIdx].Questions[1]
+		wrong := (q.correctChoice() + 1) % numChoices
+		assert.NoError(t, g.do(func() error {
+			assert.NoError(t, g.processMsg(ctx, Message{Player: picker, State: RecvPick, CatIdx: catIdx, ValIdx: 1}))
+			assert.NoError(t, g.processMsg(ctx, Message{Player: guesser, State: RecvBuzz}))
+			assert.Error(t, g.processMsg(ctx, Message{Player: guesser, State: RecvAns, Choice: numChoices}))
+			assert.NoError(t, g.processMsg(ctx, Message{Player: guesser, State: RecvAns, Choice: wrong}))
+			assert.Equal(t, -q.Value/(numChoices-1), guesser.score())
+
+			assert.NoError(t, g.processMsg(ctx, Message{Player: picker, State: RecvBuzz}))
+			return g.processMsg(ctx, Message{Player: picker, State: RecvAns, Choice: q.correctChoice()})
+		}))
+		assert.NoError(t, g.do(func() error {
+			assert.Equal(t, q.Value, picker.score())
+			assert.Equal(t, RecvPick, g.State)
+			assert.EqualError(t, g.processMsg(ctx, Message{Player: guesser, State: RecvPick, InitDispute: true}), "answers cannot be disputed in multiple choice games")
+			return nil
+		}))
+		g.stop()
+
+		events, err := GetGameReplay(ctx, g.Id)
+		assert.NoError(t, err)
+		r, err := Replay(events)
+		if !assert.NoError(t, err) {
+			return
+		}
+		want, _ := json.Marshal(g.snapshot())
+		got, _ := json.Marshal(r.snapshot())
+		assert.JSONEq(t, string(want), string(got))
+	})
+}
@@ -18,25 +18,25 @@ var animals = strings.Split(animalsList, "\n")
 var adjectivesList string
 var adjectives = strings.Split(adjectivesList, "\n")
 
-func genGameName() string {
-	return adjectives[rand.IntN(len(adjectives))] + "-" + nouns[rand.IntN(len(animals))]
+func genGameName(rng *rand.Rand) string {
+	return adjectives[rng.IntN(len(adjectives))] + "-" + nouns[rng.IntN(len(animals))]
 }
 
-func genBotName() string {
-	return adjectives[rand.IntN(len(adjectives))] + "-" + animals[rand.IntN(len(animals))]
+func genBotName(rng *rand.Rand) string {
+	return adjectives[rng.IntN(len(adjectives))] + "-" + animals[rng.IntN(len(animals))]
 }
 
-func genGameCode() string {
+func genGameCode(rng *rand.Rand) string {
 	letters := "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
 	numbers := "0123456789"
 
 	code := ""
 	for i := 0; i < 3; i++ {
-		code += string(letters[rand.IntN(len(letters))])
+		code += string(letters[rng.IntN(len(letters))])
 	}
 	code += "-"
 	for i := 0; i < 3; i++ {
-		code += string(numbers[rand.IntN(len(numbers))])
+		code += string(numbers[rng.IntN(len(numbers))])
 	}
 
 	return code
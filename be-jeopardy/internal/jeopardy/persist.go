@@ -0,0 +1,620 @@
+package jeopardy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/db"
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/log"
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/socket"
+)
+
+// snapshotVersion is bumped whenever gameSnapshot changes in a way that
+// older snapshots can no longer be restored.
+const snapshotVersion = 1
+
+type (
+	gameSnapshotStore interface {
+		SaveGameSnapshot(ctx context.Context, name string, snapshot []byte) error
+		DeleteGameSnapshot(ctx context.Context, name string) error
+		GetGameSnapshots(ctx context.Context) ([][]byte, error)
+	}
+
+	// gameSnapshot is everything needed to rebuild a game after a restart.
+	// Players are stored with their ids, which are the subjects of their
+	// tokens, so clients can rejoin with the tokens they already have.
+	gameSnapshot struct {
+		Version   int                  `json:"version"`
+		SavedAt   time.Time            `json:"savedAt"`
+		Public    bool                 `json:"public"`
+		Seed      uint64               `json:"seed"`
+		ImgOffset int                  `json:"imgOffset"`
+		Config    GameConfig           `json:"config"`
+		Board     *BoardFile           `json:"board,omitempty"`
+		Analytics GameAnalytics        `json:"analytics"`
+		Deadlines map[string]time.Time `json:"deadlines"`
+		// Tournament is the id of the tournament the game is a match of.
+		// Tournaments aren't saved, so their games aren't restored.
+		Tournament string `json:"tournament,omitempty"`
+
+		Id             string             `json:"id"`
+		EventSeq       int                `json:"eventSeq"`
+		Name           string             `json:"name"`
+		Code           string             `json:"code"`
+		State          GameState          `json:"state"`
+		Round          RoundState         `json:"round"`
+		FirstRound     []categorySnapshot `json:"firstRound"`
+		SecondRound    []categorySnapshot `json:"secondRound"`
+		FinalQuestion  *questionSnapshot  `json:"finalQuestion"`
+		CurQuestion    *questionPosition  `json:"curQuestion"`
+		OfficialAnswer string             `json:"officialAnswer"`
+		Players        []playerSnapshot   `json:"players"`
+		Host           *playerSnapshot    `json:"host,omitempty"`
+		LastToPick     string             `json:"lastToPick"`
+		AnsCorrectness bool               `json:"ansCorrectness"`
+		GuessedWrong   []string           `json:"guessedWrong"`
+		Passed         []string           `json:"passed"`
+		NumFinalWagers int                `json:"numFinalWagers"`
+		FinalWagers    []string           `json:"finalWagers"`
+		FinalAnswers   []string           `json:"finalAnswers"`
+		Paused         bool               `json:"paused"`
+		PausedState    GameState          `json:"pausedState"`
+		PausedAt       time.Time          `json:"pausedAt"`
+		StartedAt      time.Time          `json:"startedAt"`
+		DisputePicker  string             `json:"disputePicker"`
+		Disputers      int                `json:"disputes"`
+		NonDisputers   int                `json:"nonDisputes"`
+
+		StartFinalAnswerCountdown bool `json:"startFinalAnswerCountdown"`
+		StartFinalWagerCountdown  bool `json:"startFinalWagerCountdown"`
+	}
+
+	categorySnapshot struct {
+		Title     string             `json:"title"`
+		Questions []questionSnapshot `json:"questions"`
+	}
+
+	questionSnapshot struct {
+		Id           int              `json:"id"`
+		Round        int              `json:"round"`
+		Value        int              `json:"value"`
+		Category     string           `json:"category"`
+		Comments     string           `json:"comments"`
+		Clue         string           `json:"clue"`
+		Answer       string           `json:"answer"`
+		Alternatives []string         `json:"alternatives"`
+		Incorrect    []string         `json:"incorrect"`
+		CanChoose    bool             `json:"canChoose"`
+		DailyDouble  bool             `json:"dailyDouble"`
+		Choices      []string         `json:"choices,omitempty"`
+		Answers      []answerSnapshot `json:"answers"`
+		CurAns       int              `json:"curAns"`
+		CurDisputed  int              `json:"curDisputed"`
+	}
+
+	answerSnapshot struct {
+		PlayerId    string `json:"playerId"`
+		PlayerName  string `json:"playerName"`
+		Answer      string `json:"answer"`
+		Correct     bool   `json:"correct"`
+		HasDisputed bool   `json:"hasDisputed"`
+		Overturned  bool   `json:"overturned"`
+		Bot         bool   `json:"bot"`
+	}
+
+	// questionPosition locates the current question on the board. A nil
+	// position means there is no current question.
+	questionPosition struct {
+		Round  RoundState `json:"round"`
+		CatIdx int        `json:"catIdx"`
+		ValIdx int        `json:"valIdx"`
+	}
+
+	playerSnapshot struct {
+		Id              string           `json:"id"`
+		Name            string           `json:"name"`
+		Email           string           `json:"email"`
+		ImgUrl          string           `json:"imgUrl"`
+		Bot             bool             `json:"bot"`
+		BotLevel        string           `json:"botLevel,omitempty"`
+		Score           int              `json:"score"`
+		CanPick         bool             `json:"canPick"`
+		CanBuzz         bool             `json:"canBuzz"`
+		CanAnswer       bool             `json:"canAnswer"`
+		CanWager        bool             `json:"canWager"`
+		CanDispute      bool             `json:"canDispute"`
+		FinalWager      int              `json:"finalWager"`
+		FinalAnswer     string           `json:"finalAnswer"`
+		FinalCorrect    bool             `json:"finalCorrect"`
+		FinalProtestors map[string]bool  `json:"finalProtestors"`
+		PlayAgain       bool             `json:"playAgain"`
+		Team            bool             `json:"team,omitempty"`
+		Members         []playerSnapshot `json:"members,omitempty"`
+		Answerer        string           `json:"answerer,omitempty"`
+	}
+)
+
+var gameStore gameSnapshotStore
+
+// persist saves the game's state if it has changed since it was last saved.
+// It runs on the game's event loop after every event.
+func (g *Game) persist(ctx context.Context) {
+	if gameStore == nil || (len(g.Players) == 0 && g.Host == nil) {
+		return
+	}
+	select {
+	case <-g.done:
+		return
+	default:
+	}
+	snapshot := g.snapshot()
+	state, err := json.Marshal(snapshot)
+	if err != nil {
+		log.Errorf("Error marshalling snapshot of game %s: %s", g.Name, err.Error())
+		return
+	}
+	if bytes.Equal(state, g.lastSnapshot) {
+		return
+	}
+	snapshot.SavedAt = g.clock.Now()
+	saved, err := json.Marshal(snapshot)
+	if err != nil {
+		log.Errorf("Error marshalling snapshot of game %s: %s", g.Name, err.Error())
+		return
+	}
+	if err := gameStore.SaveGameSnapshot(ctx, g.Name, saved); err != nil {
+		log.Errorf("Error saving snapshot of game %s: %s", g.Name, err.Error())
+		return
+	}
+	g.lastSnapshot = state
+}
+
+// forget deletes the game's saved snapshot once the game is removed.
+func (g *Game) forget(ctx context.Context) {
+	if gameStore == nil {
+		return
+	}
+	if err := gameStore.DeleteGameSnapshot(ctx, g.Name); err != nil {
+		log.Errorf("Error deleting snapshot of game %s: %s", g.Name, err.Error())
+	}
+}
+
+func (g *Game) snapshot() gameSnapshot {
+	snapshot := gameSnapshot{
+		Version:        snapshotVersion,
+		Public:         g.public,
+		Seed:           g.seed,
+		ImgOffset:      g.imgOffset,
+		Config:         g.GameConfig,
+		Board:          g.importedBoard,
+		Analytics:      g.GameAnalytics,
+		Deadlines:      g.deadlines(),
+		Id:             g.Id,
+		EventSeq:       g.eventSeq,
+		Name:           g.Name,
+		Code:           g.Code,
+		State:          g.State,
+		Round:          g.Round,
+		FirstRound:     snapshotCategories(g.FirstRound),
+		SecondRound:    snapshotCategories(g.SecondRound),
+		FinalQuestion:  snapshotQuestion(g.FinalQuestion),
+		CurQuestion:    g.curQuestionPosition(),
+		OfficialAnswer: g.OfficialAnswer,
+		Players:        []playerSnapshot{},
+		AnsCorrectness: g.AnsCorrectness,
+		GuessedWrong:   g.GuessedWrong,
+		Passed:         g.Passed,
+		NumFinalWagers: g.NumFinalWagers,
+		FinalWagers:    g.FinalWagers,
+		FinalAnswers:   g.FinalAnswers,
+		Paused:         g.Paused,
+		PausedState:    g.PausedState,
+		PausedAt:       g.PausedAt,
+		StartedAt:      g.StartedAt,
+		Disputers:      g.Disputers,
+		NonDisputers:   g.NonDisputers,
+
+		StartFinalAnswerCountdown: g.StartFinalAnswerCountdown,
+		StartFinalWagerCountdown:  g.StartFinalWagerCountdown,
+	}
+	if g.LastToPick != nil {
+		snapshot.LastToPick = g.LastToPick.id()
+	}
+	if g.tournament != nil {
+		snapshot.Tournament = g.tournament.Id
+	}
+	if g.DisputePicker != nil {
+		snapshot.DisputePicker = g.DisputePicker.id()
+	}
+	for _, p := range g.Players {
+		snapshot.Players = append(snapshot.Players, snapshotPlayer(p))
+	}
+	if g.Host != nil {
+		host := snapshotPlayer(g.Host)
+		snapshot.Host = &host
+	}
+	return snapshot
+}
+
+func snapshotPlayer(p GamePlayer) playerSnapshot {
+	player := &Player{}
+	var team *Team
+	botLevel := ""
+	switch p := p.(type) {
+	case *Player:
+		player = p
+	case *Bot:
+		player = p.Player
+		botLevel = p.Level
+	case *Team:
+		player = p.Player
+		team = p
+	}
+	snapshot := playerSnapshot{
+		Id:              player.id(),
+		Name:            player.name(),
+		Email:           player.Email,
+		ImgUrl:          player.ImgUrl,
+		Bot:             p.isBot(),
+		BotLevel:        botLevel,
+		Score:           player.Score,
+		CanPick:         player.CanPick,
+		CanBuzz:         player.CanBuzz,
+		CanAnswer:       player.CanAnswer,
+		CanWager:        player.CanWager,
+		CanDispute:      player.CanDispute,
+		FinalWager:      player.FinalWager,
+		FinalAnswer:     player.FinalAnswer,
+		FinalCorrect:    player.FinalCorrect,
+		FinalProtestors: player.FinalProtestors,
+		PlayAgain:       player.PlayAgain,
+	}
+	if team != nil {
+		snapshot.Team = true
+		snapshot.Answerer = team.Answerer
+		snapshot.Members = []playerSnapshot{}
+		for _, m := range team.Members {
+			snapshot.Members = append(snapshot.Members, snapshotPlayer(m))
+		}
+	}
+	return snapshot
+}
+
+func snapshotCategories(categories []Category) []categorySnapshot {
+	snapshots := []categorySnapshot{}
+	for _, category := range categories {
+		snapshot := categorySnapshot{Title: category.Title, Questions: []questionSnapshot{}}
+		for _, q := range category.Questions {
+			snapshot.Questions = append(snapshot.Questions, *snapshotQuestion(q))
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots
+}
+
+func snapshotQuestion(q *Question) *questionSnapshot {
+	if q == nil {
+		return nil
+	}
+	snapshot := &questionSnapshot{
+		Id:           q.Id,
+		Round:        q.Round,
+		Value:        q.Value,
+		Category:     q.Category,
+		Comments:     q.Comments,
+		Clue:         q.Clue,
+		Answer:       q.Answer,
+		Alternatives: q.Alternatives,
+		Incorrect:    q.Incorrect,
+		CanChoose:    q.CanChoose,
+		DailyDouble:  q.DailyDouble,
+		Choices:      q.Choices,
+		Answers:      []answerSnapshot{},
+		CurAns:       -1,
+		CurDisputed:  -1,
+	}
+	for i, ans := range q.Answers {
+		if ans == q.CurAns {
+			snapshot.CurAns = i
+		}
+		if ans == q.CurDisputed {
+			snapshot.CurDisputed = i
+		}
+		answer := answerSnapshot{
+			Answer:      ans.Answer,
+			Correct:     ans.Correct,
+			HasDisputed: ans.HasDisputed,
+			Overturned:  ans.Overturned,
+			Bot:         ans.Bot,
+		}
+		if ans.Player != nil {
+			answer.PlayerId = ans.Player.id()
+			answer.PlayerName = ans.Player.name()
+		}
+		snapshot.Answers = append(snapshot.Answers, answer)
+	}
+	return snapshot
+}
+
+func (g *Game) curQuestionPosition() *questionPosition {
+	if g.CurQuestion == nil {
+		return nil
+	}
+	if g.CurQuestion == g.FinalQuestion {
+		return &questionPosition{Round: FinalRound}
+	}
+	for round, categories := range [][]Category{g.FirstRound, g.SecondRound} {
+		for catIdx, category := range categories {
+			for valIdx, q := range category.Questions {
+				if q == g.CurQuestion {
+					return &questionPosition{Round: RoundState(round), CatIdx: catIdx, ValIdx: valIdx}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// RestoreGames rebuilds every game saved before the server last stopped.
+// Restored games start paused with every player disconnected, the same as
+// a game whose players have all dropped, so players land back where they
+// were once they reconnect with their tokens and resume the game.
+func RestoreGames(ctx context.Context) error {
+	if gameStore == nil {
+		return nil
+	}
+	snapshots, err := gameStore.GetGameSnapshots(ctx)
+	if err != nil {
+		return err
+	}
+	for _, data := range snapshots {
+		var snapshot gameSnapshot
+		if err := json.Unmarshal(data, &snapshot); err != nil {
+			log.Errorf("Error parsing game snapshot: %s", err.Error())
+			continue
+		}
+		if snapshot.Version != snapshotVersion {
+			log.Errorf("Skipping snapshot of game %s with version %d", snapshot.Name, snapshot.Version)
+			continue
+		}
+		if snapshot.Tournament != "" {
+			// the bracket it would report to is gone
+			log.Infof("Not restoring game %s of tournament %s", snapshot.Name, snapshot.Tournament)
+			if err := gameStore.DeleteGameSnapshot(ctx, snapshot.Name); err != nil {
+				log.Errorf("Error deleting snapshot of game %s: %s", snapshot.Name, err.Error())
+			}
+			continue
+		}
+		jeopardyDB, err := newJeopardyDB(ctx)
+		if err != nil {
+			return err
+		}
+		game, err := restoreGame(jeopardyDB, snapshot)
+		if err != nil {
+			log.Errorf("Error restoring game %s: %s", snapshot.Name, err.Error())
+			jeopardyDB.Close()
+			continue
+		}
+		games.restore(game)
+		log.Infof("Restored game %s", game.Name)
+	}
+	return nil
+}
+
+func restoreGame(jeopardyDB jeopardyDB, snapshot gameSnapshot, opts ...GameOption) (*Game, error) {
+	if len(snapshot.Players) == 0 && snapshot.Host == nil {
+		return nil, fmt.Errorf("Game has no players")
+	}
+	game := newGame(jeopardyDB, snapshot.Config, append([]GameOption{WithSeed(snapshot.Seed)}, opts...)...)
+	// continue the random sequence somewhere other than where the game began
+	game.rng = rand.New(rand.NewPCG(snapshot.Seed, uint64(snapshot.SavedAt.UnixNano())))
+	game.public = snapshot.Public
+	game.importedBoard = snapshot.Board
+	game.imgOffset = snapshot.ImgOffset
+	game.GameAnalytics = snapshot.Analytics
+	game.Id = snapshot.Id
+	if game.Id == "" {
+		game.Id = uuid.New().String()
+	}
+	game.eventSeq = snapshot.EventSeq
+	game.Name = snapshot.Name
+	game.Code = snapshot.Code
+	game.State = snapshot.State
+	game.Round = snapshot.Round
+	game.OfficialAnswer = snapshot.OfficialAnswer
+	game.AnsCorrectness = snapshot.AnsCorrectness
+	game.GuessedWrong = snapshot.GuessedWrong
+	game.Passed = snapshot.Passed
+	game.NumFinalWagers = snapshot.NumFinalWagers
+	game.FinalWagers = snapshot.FinalWagers
+	game.FinalAnswers = snapshot.FinalAnswers
+	game.Paused = snapshot.Paused
+	game.PausedState = snapshot.PausedState
+	game.PausedAt = snapshot.PausedAt
+	game.StartedAt = snapshot.StartedAt
+	game.Disputers = snapshot.Disputers
+	game.NonDisputers = snapshot.NonDisputers
+	game.StartFinalAnswerCountdown = snapshot.StartFinalAnswerCountdown
+	game.StartFinalWagerCountdown = snapshot.StartFinalWagerCountdown
+
+	for _, p := range snapshot.Players {
+		game.Players = append(game.Players, game.restorePlayer(p))
+	}
+	if snapshot.Host != nil {
+		game.Host = game.restorePlayer(*snapshot.Host).(*Player)
+	}
+	game.FirstRound = game.restoreCategories(snapshot.FirstRound)
+	game.SecondRound = game.restoreCategories(snapshot.SecondRound)
+	game.FinalQuestion = game.restoreQuestion(snapshot.FinalQuestion)
+	game.CurQuestion = &Question{}
+	if pos := snapshot.CurQuestion; pos != nil {
+		if err := game.restoreCurQuestion(*pos); err != nil {
+			return nil, err
+		}
+	}
+	game.LastToPick = &Player{}
+	if p, err := game.getPlayerById(snapshot.LastToPick); err == nil {
+		game.LastToPick = p
+	}
+	if snapshot.DisputePicker != "" {
+		game.DisputePicker = &Player{}
+		if p, err := game.getPlayerById(snapshot.DisputePicker); err == nil {
+			game.DisputePicker = p
+		}
+	}
+
+	if !game.Paused {
+		for key, deadline := range snapshot.Deadlines {
+			game.restoredTimeouts[key] = max(deadline.Sub(snapshot.SavedAt), 0)
+		}
+	}
+	game.record(GameEvent{Type: EventRestored})
+	game.suspend()
+	game.lastSnapshot, _ = json.Marshal(game.snapshot())
+
+	for _, p := range game.Players {
+		if bot, ok := p.(*Bot); ok {
+			bot.processMessages(game)
+		}
+	}
+	game.processMessages()
+	return game, nil
+}
+
+// suspend leaves a restored game the way a game is left when every player
+// has disconnected: paused, waiting for its players to come back.
+func (g *Game) suspend() {
+	for _, t := range g.running {
+		t.cancel()
+	}
+	for _, p := range g.Players {
+		if !p.isBot() {
+			p.endConnections()
+		}
+	}
+	if g.Host != nil {
+		g.Host.endConnections()
+	}
+	if !g.Paused {
+		g.Paused = true
+		g.PausedState = g.State
+		g.PausedAt = g.clock.Now()
+		if g.State != PostGame {
+			g.State = PreGame
+		}
+	}
+	g.Disconnected = true
+}
+
+func (g *Game) restorePlayer(snapshot playerSnapshot) GamePlayer {
+	player := NewPlayer(snapshot.Name, snapshot.ImgUrl, snapshot.Email)
+	player.Id = snapshot.Id
+	player.Score = snapshot.Score
+	player.CanPick = snapshot.CanPick
+	player.CanBuzz = snapshot.CanBuzz
+	player.CanAnswer = snapshot.CanAnswer
+	player.CanWager = snapshot.CanWager
+	player.CanDispute = snapshot.CanDispute
+	player.FinalWager = snapshot.FinalWager
+	player.FinalAnswer = snapshot.FinalAnswer
+	player.FinalCorrect = snapshot.FinalCorrect
+	player.FinalProtestors = snapshot.FinalProtestors
+	if player.FinalProtestors == nil {
+		player.FinalProtestors = map[string]bool{}
+	}
+	player.PlayAgain = snapshot.PlayAgain
+	if snapshot.Team {
+		team := &Team{Player: player, Members: []*Player{}, Answerer: snapshot.Answerer}
+		for _, m := range snapshot.Members {
+			team.Members = append(team.Members, g.restorePlayer(m).(*Player))
+		}
+		return team
+	}
+	if !snapshot.Bot {
+		return player
+	}
+	bot := g.newBot()
+	bot.Player = player
+	if validBotLevel(snapshot.BotLevel) {
+		bot.setLevel(snapshot.BotLevel)
+	}
+	bot.Conn = socket.NewSafeConn(nil)
+	return bot
+}
+
+func (g *Game) restoreCategories(snapshots []categorySnapshot) []Category {
+	categories := []Category{}
+	for _, snapshot := range snapshots {
+		category := Category{Title: snapshot.Title}
+		for _, q := range snapshot.Questions {
+			category.Questions = append(category.Questions, g.restoreQuestion(&q))
+		}
+		categories = append(categories, category)
+	}
+	return categories
+}
+
+func (g *Game) restoreQuestion(snapshot *questionSnapshot) *Question {
+	if snapshot == nil {
+		return &Question{}
+	}
+	q := &Question{
+		Question: db.Question{
+			Id:           snapshot.Id,
+			Round:        snapshot.Round,
+			Value:        snapshot.Value,
+			Category:     snapshot.Category,
+			Comments:     snapshot.Comments,
+			Clue:         snapshot.Clue,
+			Answer:       snapshot.Answer,
+			Alternatives: snapshot.Alternatives,
+			Incorrect:    snapshot.Incorrect,
+		},
+		CanChoose:   snapshot.CanChoose,
+		DailyDouble: snapshot.DailyDouble,
+		Choices:     snapshot.Choices,
+	}
+	for i, ans := range snapshot.Answers {
+		var player GamePlayer = &Player{Id: ans.PlayerId, Name: ans.PlayerName}
+		if p, err := g.getPlayerById(ans.PlayerId); err == nil {
+			player = p
+		}
+		answer := &Answer{
+			Player:      player,
+			Answer:      ans.Answer,
+			Correct:     ans.Correct,
+			HasDisputed: ans.HasDisputed,
+			Overturned:  ans.Overturned,
+			Bot:         ans.Bot,
+		}
+		q.Answers = append(q.Answers, answer)
+		if i == snapshot.CurAns {
+			q.CurAns = answer
+		}
+		if i == snapshot.CurDisputed {
+			q.CurDisputed = answer
+		}
+	}
+	return q
+}
+
+func (g *Game) restoreCurQuestion(pos questionPosition) error {
+	if pos.Round == FinalRound {
+		g.CurQuestion = g.FinalQuestion
+		return nil
+	}
+	categories := g.FirstRound
+	if pos.Round == SecondRound {
+		categories = g.SecondRound
+	}
+	if pos.CatIdx < 0 || pos.CatIdx >= len(categories) || pos.ValIdx < 0 || pos.ValIdx >= len(categories[pos.CatIdx].Questions) {
+		return fmt.Errorf("Current question is not on the board")
+	}
+	g.CurQuestion = categories[pos.CatIdx].Questions[pos.ValIdx]
+	return nil
+}
@@ -0,0 +1,151 @@
+package jeopardy
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestoreGame(t *testing.T) {
+	t.Run("test restored game resumes where it was saved", func(t *testing.T) {
+		ctx := context.Background()
+		store := newTestDB(t)
+		gameStore = store
+		defer func() { gameStore = nil }()
+
+		clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		g := newTestGame(t, 3, clock, 2)
+		bot := g.newBot()
+		g.Players = append(g.Players, bot)
+		bot.processMessages(g)
+		assert.NoError(t, g.do(func() error {
+			g.startRound(g.Players[0])
+			return nil
+		}))
+		clock.Advance(boardIntroTimeout * time.Second)
+
+		picker, buzzer := g.Players[0], g.Players[1]
+		catIdx := 0
+		for g.FirstRound[catIdx].Questions[1].DailyDouble {
+			catIdx++
+		}
+		assert.NoError(t, g.do(func() error {
+			g.FirstRound[catIdx].Questions[1].Incorrect = []string{"what is a guess"}
+			return g.processMsg(ctx, Message{Player: picker, State: RecvPick, CatIdx: catIdx, ValIdx: 1})
+		}))
+		assert.NoError(t, g.do(func() error {
+			return g.processMsg(ctx, Message{Player: buzzer, State: RecvBuzz})
+		}))
+		savedAt := clock.Now()
+		clock.Advance(10 * time.Second)
+		assert.NoError(t, g.do(func() error { return nil }))
+		g.stop()
+		bot.stop()
+
+		snapshots, err := store.GetGameSnapshots(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, snapshots, 1)
+		var snapshot gameSnapshot
+		assert.NoError(t, json.Unmarshal(snapshots[0], &snapshot))
+		assert.Equal(t, RecvAns, snapshot.State)
+		assert.Equal(t, savedAt, snapshot.SavedAt)
+
+		restoreClock := NewManualClock(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+		r, err := restoreGame(store, snapshot, WithClock(restoreClock))
+		assert.NoError(t, err)
+		defer r.stop()
+
+		assert.NoError(t, r.do(func() error {
+			assert.Equal(t, g.Name, r.Name)
+			assert.Equal(t, g.Code, r.Code)
+			assert.Equal(t, PreGame, r.State)
+			assert.Equal(t, RecvAns, r.PausedState)
+			assert.True(t, r.Paused)
+			assert.Len(t, r.Players, 3)
+			for i, p := range r.Players {
+				assert.Equal(t, g.Players[i].id(), p.id())
+				assert.Equal(t, g.Players[i].score(), p.score())
+				assert.Equal(t, g.Players[i].isBot(), p.isBot())
+				if !p.isBot() {
+					assert.Nil(t, p.conn())
+				}
+			}
+			assert.Same(t, r.FirstRound[catIdx].Questions[1], r.CurQuestion)
+			assert.Equal(t, g.CurQuestion.Answer, r.CurQuestion.Answer)
+			assert.Equal(t, []string{"what is a guess"}, r.CurQuestion.Incorrect)
+			assert.Equal(t, picker.id(), r.LastToPick.id())
+			assert.Equal(t, 30*time.Second, r.restoredTimeouts[timeoutKey(answerTimeoutKey, buzzer)])
+
+			r.Players[0].setConn(&testConn{})
+			r.Players[1].setConn(&testConn{})
+			r.resumeGame()
+			assert.Equal(t, RecvAns, r.State)
+			assert.True(t, r.Players[1].canAnswer())
+			return nil
+		}))
+
+		restoreClock.Advance(29 * time.Second)
+		assert.NoError(t, r.do(func() error {
+			assert.Equal(t, RecvAns, r.State)
+			return nil
+		}))
+		restoreClock.Advance(time.Second)
+		assert.NoError(t, r.do(func() error {
+			assert.Equal(t, RecvBuzz, r.State)
+			assert.Equal(t, "answer-timeout", r.CurQuestion.CurAns.Answer)
+			assert.Same(t, r.Players[1], r.CurQuestion.CurAns.Player)
+			return nil
+		}))
+	})
+
+	t.Run("test tournament games are not restored", func(t *testing.T) {
+		ctx := context.Background()
+		store := newTestDB(t)
+		gameStore = store
+		defer func() { gameStore = nil }()
+
+		clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		g := newTestGame(t, 5, clock, 1)
+		assert.NoError(t, g.do(func() error {
+			g.tournament = &Tournament{Id: "tournament-id"}
+			return nil
+		}))
+		g.stop()
+		snapshots, err := store.GetGameSnapshots(ctx)
+		assert.NoError(t, err)
+		var snapshot gameSnapshot
+		assert.NoError(t, json.Unmarshal(snapshots[0], &snapshot))
+		assert.Equal(t, "tournament-id", snapshot.Tournament)
+
+		assert.NoError(t, RestoreGames(ctx))
+		assert.Nil(t, games.find(g.Name))
+		snapshots, err = store.GetGameSnapshots(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, snapshots)
+	})
+
+	t.Run("test removed game is forgotten", func(t *testing.T) {
+		ctx := context.Background()
+		store := newTestDB(t)
+		gameStore = store
+		defer func() { gameStore = nil }()
+
+		clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		g := newTestGame(t, 5, clock, 1)
+		assert.NoError(t, g.do(func() error { return nil }))
+		snapshots, err := store.GetGameSnapshots(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, snapshots, 1)
+
+		assert.NoError(t, g.do(func() error {
+			removeGame(g)
+			return nil
+		}))
+		snapshots, err = store.GetGameSnapshots(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, snapshots)
+	})
+}
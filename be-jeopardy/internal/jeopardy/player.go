@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/rileythomp/jeopardy/be-jeopardy/internal/log"
@@ -53,9 +54,9 @@ type GamePlayer interface {
 	setFinalCorrect(bool)
 	setPlayAgain(bool)
 
-	readMessages(msgChan chan Message, disconnectChan chan GamePlayer)
-	processChatMessages(chan ChatMessage)
-	processReactions(chan Reaction)
+	readMessages(msgChan chan Message, disconnectChan chan GamePlayer, done <-chan struct{})
+	processChatMessages(chatChan chan ChatMessage, done <-chan struct{})
+	processReactions(reactChan chan Reaction, done <-chan struct{})
 	sendPings()
 	sendChatPings()
 	sendReactionPings()
@@ -101,6 +102,10 @@ type Player struct {
 	CancelAnswerTimeout context.CancelFunc `json:"-"`
 	CancelWagerTimeout  context.CancelFunc `json:"-"`
 
+	// mu guards the name and connections, which are read by the
+	// goroutines serving the player's sockets.
+	mu sync.Mutex
+
 	sendGamePing  *time.Ticker
 	sendChatPing  *time.Ticker
 	sendReactPing *time.Ticker
@@ -140,17 +145,20 @@ func NewPlayer(name, imgUrl, email string) *Player {
 	}
 }
 
-func (p *Player) readMessages(msgChan chan Message, disconnectChan chan GamePlayer) {
+func (p *Player) readMessages(msgChan chan Message, disconnectChan chan GamePlayer, done <-chan struct{}) {
 	go func() {
-		log.Infof("Starting to read messages from player %s", p.Name)
+		log.Infof("Starting to read messages from player %s", p.name())
 		for {
 			message, err := p.readMessage()
 			if err != nil {
-				log.Errorf("Error reading message from player %s: %s", p.Name, err.Error())
+				log.Errorf("Error reading message from player %s: %s", p.name(), err.Error())
 				if websocket.IsCloseError(err, 1001) {
-					log.Infof("Player %s closed connection", p.Name)
+					log.Infof("Player %s closed connection", p.name())
+				}
+				select {
+				case disconnectChan <- p:
+				case <-done:
 				}
-				disconnectChan <- p
 				return
 			}
 			var msg Message
@@ -158,14 +166,18 @@ func (p *Player) readMessages(msgChan chan Message, disconnectChan chan GamePlay
 				log.Errorf("Error parsing message from player: %s", err.Error())
 			}
 			msg.Player = p
-			msgChan <- msg
+			select {
+			case msgChan <- msg:
+			case <-done:
+				return
+			}
 		}
 	}()
 }
 
 func (p *Player) sendPings() {
 	go func() {
-		log.Infof("Starting to send pings to player %s", p.Name)
+		log.Infof("Starting to send pings to player %s", p.name())
 		pingErrors := 0
 		for {
 			select {
@@ -174,14 +186,15 @@ func (p *Player) sendPings() {
 					Code:    socket.Info,
 					Message: ping,
 				}); err != nil {
-					if p.Conn == nil {
-						log.Infof("Stopping sending pings to player %s because connection is nil", p.Name)
+					conn := p.conn()
+					if conn == nil {
+						log.Infof("Stopping sending pings to player %s because connection is nil", p.name())
 						return
 					}
 					pingErrors++
 					if pingErrors >= 3 {
-						log.Infof("Too many ping errors, closing connection to player %s", p.Name)
-						if err := p.Conn.Close(); err != nil {
+						log.Infof("Too many ping errors, closing connection to player %s", p.name())
+						if err := conn.Close(); err != nil {
 							log.Errorf("Error closing connection: %s", err.Error())
 						}
 						return
@@ -234,6 +247,8 @@ func (p *Player) id() string {
 }
 
 func (p *Player) name() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	return p.Name
 }
 
@@ -242,14 +257,20 @@ func (p *Player) email() string {
 }
 
 func (p *Player) conn() SafeConn {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	return p.Conn
 }
 
 func (p *Player) chatConn() SafeConn {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	return p.ChatConn
 }
 
 func (p *Player) reactionConn() SafeConn {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	return p.ReactionConn
 }
 
@@ -302,6 +323,8 @@ func (p *Player) setId(id string) {
 }
 
 func (p *Player) setName(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	p.Name = name
 }
 
@@ -310,14 +333,20 @@ func (p *Player) setImg(img string) {
 }
 
 func (p *Player) setConn(conn SafeConn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	p.Conn = conn
 }
 
 func (p *Player) setChatConn(conn SafeConn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	p.ChatConn = conn
 }
 
 func (p *Player) setReactionConn(conn SafeConn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	p.ReactionConn = conn
 }
 
@@ -362,6 +391,8 @@ func (p *Player) addToScore(points int) {
 }
 
 func (p *Player) endConnections() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	p.Conn = nil
 	p.ChatConn = nil
 	p.ReactionConn = nil
@@ -384,11 +415,12 @@ func (p *Player) cancelWagerTimeout() {
 }
 
 func (p *Player) readMessage() ([]byte, error) {
-	if p.Conn == nil {
-		log.Infof("Skipping reading message from player %s because connection is nil", p.Name)
-		return nil, fmt.Errorf("Player %s has no connection", p.Name)
+	conn := p.conn()
+	if conn == nil {
+		log.Infof("Skipping reading message from player %s because connection is nil", p.name())
+		return nil, fmt.Errorf("Player %s has no connection", p.name())
 	}
-	_, msg, err := p.Conn.ReadMessage()
+	_, msg, err := conn.ReadMessage()
 	if err != nil {
 		return nil, err
 	}
@@ -396,12 +428,13 @@ func (p *Player) readMessage() ([]byte, error) {
 }
 
 func (p *Player) sendMessage(msg Response) error {
-	if p.Conn == nil {
-		log.Errorf("Error sending message to player %s because connection is nil", p.Name)
+	conn := p.conn()
+	if conn == nil {
+		log.Errorf("Error sending message to player %s because connection is nil", p.name())
 		return fmt.Errorf("player has no connection")
 	}
-	if err := p.Conn.WriteJSON(msg); err != nil {
-		log.Errorf("Error sending message to player %s: %s", p.Name, err.Error())
+	if err := conn.WriteJSON(msg); err != nil {
+		log.Errorf("Error sending message to player %s: %s", p.name(), err.Error())
 		return fmt.Errorf("error sending message to player")
 	}
 	return nil
@@ -0,0 +1,297 @@
+package jeopardy
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/db"
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/log"
+)
+
+type (
+	practiceStore interface {
+		GetCategoryQuestions(ctx context.Context, category db.Category) ([]db.Question, error)
+		GetRandomCategory(ctx context.Context) (db.Category, error)
+		GetPracticeResults(ctx context.Context, email string) ([]db.PracticeResult, error)
+		SavePracticeResult(ctx context.Context, r db.PracticeResult) error
+	}
+
+	// PracticeClue is a clue to practice, without its answer. Reason is why
+	// it was picked: it is due for review, it is from a category the player
+	// is weak in, or it is new.
+	PracticeClue struct {
+		Category db.Category `json:"category"`
+		Clue     string      `json:"clue"`
+		Comments string      `json:"comments"`
+		Value    int         `json:"value"`
+		Reason   string      `json:"reason"`
+	}
+
+	PracticeAnswer struct {
+		Email    string      `json:"-"`
+		Category db.Category `json:"category"`
+		Clue     string      `json:"clue"`
+		Answer   string      `json:"answer"`
+	}
+
+	PracticeFeedback struct {
+		Correct bool              `json:"correct"`
+		Answer  string            `json:"answer"`
+		Result  db.PracticeResult `json:"result"`
+	}
+
+	PracticeStats struct {
+		Clues      int                     `json:"clues"`
+		Due        int                     `json:"due"`
+		Categories []PracticeCategoryStats `json:"categories"`
+	}
+
+	PracticeCategoryStats struct {
+		Name     string `json:"name"`
+		Attempts int    `json:"attempts"`
+		Correct  int    `json:"correct"`
+		Weak     bool   `json:"weak"`
+	}
+)
+
+const (
+	ReviewReason       = "review"
+	WeakCategoryReason = "weak category"
+	NewReason          = "new"
+
+	// a category is weak once it has been practiced a few times and less
+	// than 60% of the answers in it were correct
+	weakCategoryAttempts = 3
+	weakCategoryAccuracy = 0.6
+)
+
+var (
+	practiceDB    practiceStore
+	practiceClock Clock = realClock{}
+
+	// practiceIntervals is how long until a clue is practiced again after
+	// a streak of that many correct answers. Missed clues come back soon.
+	practiceIntervals = []time.Duration{
+		10 * time.Minute,
+		24 * time.Hour,
+		3 * 24 * time.Hour,
+		7 * 24 * time.Hour,
+		16 * 24 * time.Hour,
+		35 * 24 * time.Hour,
+	}
+)
+
+// NextPracticeClue picks the player's next clue to practice: the most
+// overdue clue due for review, otherwise a new clue, every other one of
+// which comes from a category they are weak in.
+func NextPracticeClue(ctx context.Context, email string) (PracticeClue, error) {
+	if email == "" {
+		return PracticeClue{}, fmt.Errorf("Log in to practice")
+	}
+	results, err := practiceDB.GetPracticeResults(ctx, email)
+	if err != nil {
+		return PracticeClue{}, err
+	}
+
+	now := practiceClock.Now()
+	for _, r := range results {
+		if r.DueAt.After(now) {
+			continue
+		}
+		q, err := practiceQuestion(ctx, r.Category, r.Clue)
+		if err != nil {
+			log.Errorf("Error loading practice clue: %s", err.Error())
+			continue
+		}
+		return newPracticeClue(r.Category, q, ReviewReason), nil
+	}
+
+	if len(results)%2 == 0 {
+		for _, name := range weakCategories(results) {
+			clue, ok, err := newWeakCategoryClue(ctx, name, results)
+			if err != nil {
+				return PracticeClue{}, err
+			}
+			if ok {
+				return clue, nil
+			}
+		}
+	}
+
+	category, err := practiceDB.GetRandomCategory(ctx)
+	if err != nil {
+		return PracticeClue{}, err
+	}
+	questions, err := practiceDB.GetCategoryQuestions(ctx, category)
+	if err != nil {
+		return PracticeClue{}, err
+	}
+	questions = unpracticed(category, questions, results)
+	if len(questions) == 0 {
+		return PracticeClue{}, fmt.Errorf("No new clues found, try again")
+	}
+	return newPracticeClue(category, questions[rand.IntN(len(questions))], NewReason), nil
+}
+
+// newWeakCategoryClue finds a clue the player hasn't practiced from an
+// airing of the category with the given name.
+func newWeakCategoryClue(ctx context.Context, name string, results []db.PracticeResult) (PracticeClue, bool, error) {
+	categories, err := searchDB.SearchCategories(ctx, strings.ToLower(name), "", 2)
+	if err != nil {
+		return PracticeClue{}, false, err
+	}
+	for _, category := range categories {
+		if !strings.EqualFold(category.Name, name) {
+			continue
+		}
+		questions, err := practiceDB.GetCategoryQuestions(ctx, category)
+		if err != nil {
+			return PracticeClue{}, false, err
+		}
+		if questions = unpracticed(category, questions, results); len(questions) > 0 {
+			return newPracticeClue(category, questions[0], WeakCategoryReason), true, nil
+		}
+	}
+	return PracticeClue{}, false, nil
+}
+
+func unpracticed(category db.Category, questions []db.Question, results []db.PracticeResult) []db.Question {
+	return slices.DeleteFunc(questions, func(q db.Question) bool {
+		return slices.ContainsFunc(results, func(r db.PracticeResult) bool {
+			return r.Category == category && r.Clue == q.Clue
+		})
+	})
+}
+
+func newPracticeClue(category db.Category, q db.Question, reason string) PracticeClue {
+	return PracticeClue{
+		Category: category,
+		Clue:     q.Clue,
+		Comments: q.Comments,
+		Value:    q.Value,
+		Reason:   reason,
+	}
+}
+
+// practiceQuestion loads the clue from its category.
+func practiceQuestion(ctx context.Context, category db.Category, clue string) (db.Question, error) {
+	questions, err := practiceDB.GetCategoryQuestions(ctx, category)
+	if err != nil {
+		return db.Question{}, err
+	}
+	for _, q := range questions {
+		if q.Clue == clue {
+			return q, nil
+		}
+	}
+	return db.Question{}, fmt.Errorf("Clue not found")
+}
+
+// AnswerPracticeClue checks the player's answer like a game would and
+// schedules when they see the clue again.
+func AnswerPracticeClue(ctx context.Context, req PracticeAnswer) (PracticeFeedback, error) {
+	if req.Email == "" {
+		return PracticeFeedback{}, fmt.Errorf("Log in to practice")
+	}
+	q, err := practiceQuestion(ctx, req.Category, req.Clue)
+	if err != nil {
+		return PracticeFeedback{}, err
+	}
+	question := &Question{Question: q}
+	correct := question.checkAnswer(req.Answer)
+
+	results, err := practiceDB.GetPracticeResults(ctx, req.Email)
+	if err != nil {
+		return PracticeFeedback{}, err
+	}
+	result := db.PracticeResult{Email: req.Email, Category: req.Category, Clue: req.Clue}
+	for _, r := range results {
+		if r.Category == req.Category && r.Clue == req.Clue {
+			result = r
+		}
+	}
+	result.Attempts++
+	result.LastCorrect = correct
+	if correct {
+		result.Correct++
+		result.Streak++
+	} else {
+		result.Streak = 0
+	}
+	result.DueAt = practiceClock.Now().Add(practiceIntervals[min(result.Streak, len(practiceIntervals)-1)])
+	if err := practiceDB.SavePracticeResult(ctx, result); err != nil {
+		return PracticeFeedback{}, err
+	}
+
+	return PracticeFeedback{Correct: correct, Answer: q.Answer, Result: result}, nil
+}
+
+// GetPracticeStats sums up the player's practice by category, weakest
+// first.
+func GetPracticeStats(ctx context.Context, email string) (PracticeStats, error) {
+	if email == "" {
+		return PracticeStats{}, fmt.Errorf("Log in to practice")
+	}
+	results, err := practiceDB.GetPracticeResults(ctx, email)
+	if err != nil {
+		return PracticeStats{}, err
+	}
+	stats := PracticeStats{Clues: len(results), Categories: categoryStats(results)}
+	now := practiceClock.Now()
+	for _, r := range results {
+		if !r.DueAt.After(now) {
+			stats.Due++
+		}
+	}
+	return stats, nil
+}
+
+// categoryStats totals the results by category name, since the same
+// category comes back on different shows, ordered by accuracy.
+func categoryStats(results []db.PracticeResult) []PracticeCategoryStats {
+	stats := []PracticeCategoryStats{}
+	for _, r := range results {
+		i := slices.IndexFunc(stats, func(s PracticeCategoryStats) bool {
+			return strings.EqualFold(s.Name, r.Category.Name)
+		})
+		if i == -1 {
+			stats = append(stats, PracticeCategoryStats{Name: r.Category.Name})
+			i = len(stats) - 1
+		}
+		stats[i].Attempts += r.Attempts
+		stats[i].Correct += r.Correct
+	}
+	for i, s := range stats {
+		stats[i].Weak = s.Attempts >= weakCategoryAttempts && accuracy(s) < weakCategoryAccuracy
+	}
+	slices.SortStableFunc(stats, func(a, b PracticeCategoryStats) int {
+		if accuracy(a) < accuracy(b) {
+			return -1
+		} else if accuracy(a) > accuracy(b) {
+			return 1
+		}
+		return b.Attempts - a.Attempts
+	})
+	return stats
+}
+
+func accuracy(s PracticeCategoryStats) float64 {
+	if s.Attempts == 0 {
+		return 0
+	}
+	return float64(s.Correct) / float64(s.Attempts)
+}
+
+func weakCategories(results []db.PracticeResult) []string {
+	names := []string{}
+	for _, s := range categoryStats(results) {
+		if s.Weak {
+			names = append(names, s.Name)
+		}
+	}
+	return names
+}
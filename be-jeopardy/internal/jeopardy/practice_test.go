@@ -0,0 +1,105 @@
+package jeopardy
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPractice(t *testing.T) {
+	ctx := context.Background()
+	clues := []db.Clue{}
+	for _, category := range []db.Category{
+		{Name: "POTPOURRI", Round: 1, AirDate: "2020-01-01"},
+		{Name: "POTPOURRI", Round: 1, AirDate: "2021-01-01"},
+		{Name: "SCIENCE", Round: 2, AirDate: "2020-01-01"},
+	} {
+		for i := 1; i <= 5; i++ {
+			clues = append(clues, db.Clue{
+				Round:    category.Round,
+				Value:    200 * category.Round * i,
+				Category: category.Name,
+				AirDate:  category.AirDate,
+				Clue:     fmt.Sprintf("%s %s clue %d", category.Name, category.AirDate, i),
+				Answer:   fmt.Sprintf("answer %d", i),
+			})
+		}
+	}
+	memoryDB := db.NewMemoryDB(clues)
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	prevPracticeDB, prevSearchDB, prevClock := practiceDB, searchDB, practiceClock
+	practiceDB, searchDB, practiceClock = memoryDB, memoryDB, clock
+	defer func() {
+		practiceDB, searchDB, practiceClock = prevPracticeDB, prevSearchDB, prevClock
+	}()
+
+	t.Run("test missed clues come back for review", func(t *testing.T) {
+		email := "review@example.com"
+		clue, err := NextPracticeClue(ctx, email)
+		assert.NoError(t, err)
+		assert.Equal(t, NewReason, clue.Reason)
+
+		feedback, err := AnswerPracticeClue(ctx, PracticeAnswer{Email: email, Category: clue.Category, Clue: clue.Clue, Answer: "no idea"})
+		assert.NoError(t, err)
+		assert.False(t, feedback.Correct)
+		assert.Equal(t, clock.Now().Add(10*time.Minute), feedback.Result.DueAt)
+
+		next, err := NextPracticeClue(ctx, email)
+		assert.NoError(t, err)
+		assert.Equal(t, NewReason, next.Reason)
+		assert.NotEqual(t, clue.Clue, next.Clue)
+
+		clock.Advance(10 * time.Minute)
+		review, err := NextPracticeClue(ctx, email)
+		assert.NoError(t, err)
+		assert.Equal(t, PracticeClue{Category: clue.Category, Clue: clue.Clue, Value: clue.Value, Reason: ReviewReason}, review)
+
+		feedback, err = AnswerPracticeClue(ctx, PracticeAnswer{Email: email, Category: clue.Category, Clue: clue.Clue, Answer: feedback.Answer})
+		assert.NoError(t, err)
+		assert.True(t, feedback.Correct)
+		assert.Equal(t, db.PracticeResult{
+			Email:       email,
+			Category:    clue.Category,
+			Clue:        clue.Clue,
+			Attempts:    2,
+			Correct:     1,
+			Streak:      1,
+			LastCorrect: true,
+			DueAt:       clock.Now().Add(24 * time.Hour),
+		}, feedback.Result)
+
+		_, err = NextPracticeClue(ctx, "")
+		assert.Error(t, err)
+	})
+
+	t.Run("test weak categories are practiced more", func(t *testing.T) {
+		email := "weak@example.com"
+		category := db.Category{Name: "POTPOURRI", Round: 1, AirDate: "2020-01-01"}
+		for i := 1; i <= 4; i++ {
+			clue := fmt.Sprintf("POTPOURRI 2020-01-01 clue %d", i)
+			_, err := AnswerPracticeClue(ctx, PracticeAnswer{Email: email, Category: category, Clue: clue, Answer: "wrong"})
+			assert.NoError(t, err)
+		}
+
+		stats, err := GetPracticeStats(ctx, email)
+		assert.NoError(t, err)
+		assert.Equal(t, PracticeStats{
+			Clues:      4,
+			Categories: []PracticeCategoryStats{{Name: "POTPOURRI", Attempts: 4, Weak: true}},
+		}, stats)
+
+		clue, err := NextPracticeClue(ctx, email)
+		assert.NoError(t, err)
+		assert.Equal(t, WeakCategoryReason, clue.Reason)
+		assert.Equal(t, "POTPOURRI", clue.Category.Name)
+
+		clock.Advance(10 * time.Minute)
+		stats, err = GetPracticeStats(ctx, email)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, stats.Due)
+	})
+}
@@ -2,16 +2,15 @@ package jeopardy
 
 import (
 	"context"
-	"math/rand/v2"
-	"strings"
 
-	"github.com/agnivade/levenshtein"
 	"github.com/rileythomp/jeopardy/be-jeopardy/internal/db"
 )
 
 const (
 	numCategories = 6
 	numQuestions  = 5
+	maxCategories = 8
+	maxQuestions  = 5
 )
 
 type (
@@ -33,6 +32,8 @@ type (
 		db.Question
 		CanChoose   bool `json:"canChoose"`
 		DailyDouble bool `json:"-"`
+		// Choices are the responses offered in a multiple choice game.
+		Choices []string `json:"choices"`
 
 		Answers     []*Answer `json:"answers"`
 		CurAns      *Answer   `json:"curAns"`
@@ -40,31 +41,16 @@ type (
 	}
 )
 
-func (q *Question) checkAnswer(ans string) bool {
-	for _, corr := range q.Alternatives {
-		ans, corr = strings.ToLower(ans), strings.ToLower(corr)
-		dist := levenshtein.ComputeDistance(ans, corr)
-		if len(corr) <= 5 && dist <= 0 {
-			return true
-		} else if 5 < len(corr) && len(corr) <= 7 && dist <= 1 {
-			return true
-		} else if 7 < len(corr) && len(corr) <= 9 && dist <= 2 {
-			return true
-		} else if 9 < len(corr) && len(corr) <= 12 && dist <= 3 {
-			return true
-		} else if 12 < len(corr) && len(corr) <= 15 && dist <= 4 {
-			return true
-		} else if 15 < len(corr) && dist <= 5 {
-			return true
-		}
-	}
-	return false
-}
-
 func (q *Question) equal(q0 *Question) bool {
 	return q.Clue == q0.Clue && q.Answer == q0.Answer
 }
 
+// playerBoard is whether the game is played on a board a player wrote or
+// imported, whose clues aren't in the database.
+func (g *Game) playerBoard() bool {
+	return g.CustomBoard != "" || g.importedBoard != nil
+}
+
 func (g *Game) setQuestions(ctx context.Context) error {
 	g.FirstRound = []Category{}
 	g.SecondRound = []Category{}
@@ -72,6 +58,26 @@ func (g *Game) setQuestions(ctx context.Context) error {
 	g.CurQuestion = &Question{}
 	g.OfficialAnswer = ""
 
+	if g.replaying {
+		return g.nextReplayBoard()
+	}
+
+	if g.importedBoard != nil {
+		return g.setImportedBoard()
+	}
+
+	if g.CustomBoard != "" {
+		questions, err := g.customBoardQuestions(ctx)
+		if err != nil {
+			return err
+		}
+		return g.setBoard(questions)
+	}
+
+	if g.DailyChallenge != "" && dailyDB != nil {
+		return g.setDailyBoard(ctx)
+	}
+
 	questions := []db.Question{}
 
 	categories := append(g.FirstRoundCategories, g.SecondRoundCategories...)
@@ -80,15 +86,51 @@ func (g *Game) setQuestions(ctx context.Context) error {
 		if err != nil {
 			return err
 		}
-		questions = append(questions, categoryQuestions...)
+		questions = append(questions, categoryQuestions[:min(g.Questions, len(categoryQuestions))]...)
 	}
 
-	randomQuestions, err := g.jeopardyDB.GetQuestions(ctx, 6-len(g.FirstRoundCategories), 6-len(g.SecondRoundCategories))
+	frCategories, srCategories := g.Categories-len(g.FirstRoundCategories), g.Categories-len(g.SecondRoundCategories)
+	var randomQuestions []db.Question
+	var err error
+	if g.DailyChallenge != "" {
+		randomQuestions, err = g.jeopardyDB.GetDailyQuestions(ctx, g.DailyChallenge, frCategories, srCategories, g.Questions)
+	} else {
+		randomQuestions, err = g.jeopardyDB.GetQuestions(ctx, frCategories, srCategories, g.Questions)
+	}
 	if err != nil {
 		return err
 	}
 	questions = append(questions, randomQuestions...)
+	return g.setBoard(questions)
+}
 
+// setBoard arranges the questions on the board and records the board.
+func (g *Game) setBoard(questions []db.Question) error {
+	g.arrangeBoard(questions)
+	g.record(GameEvent{Type: EventBoard, Board: g.board()})
+
+	return nil
+}
+
+// arrangeBoard lays out the questions on the board and hides its Daily
+// Doubles.
+func (g *Game) arrangeBoard(questions []db.Question) {
+	g.layoutBoard(questions)
+	setValues(g.FirstRound, g.FirstRoundValues)
+	setValues(g.SecondRound, g.SecondRoundValues)
+	if g.DailyChallenge != "" {
+		// every board of the day has its Daily Doubles in the same places
+		rng := g.rng
+		g.rng = dailyRng(g.DailyChallenge)
+		defer func() { g.rng = rng }()
+	}
+	g.setDailyDoubles()
+	g.setChoices()
+}
+
+// layoutBoard puts the questions, ordered by round and category, in the
+// rounds and Final Jeopardy.
+func (g *Game) layoutBoard(questions []db.Question) {
 	category := Category{}
 	for i, q := range questions {
 		question := &Question{Question: q}
@@ -98,7 +140,7 @@ func (g *Game) setQuestions(ctx context.Context) error {
 		}
 		question.CanChoose = true
 		category.Questions = append(category.Questions, question)
-		if i%numQuestions == (numQuestions - 1) {
+		if i%g.Questions == (g.Questions - 1) {
 			category.Title = question.Category
 			if question.Round == 1 {
 				g.FirstRound = append(g.FirstRound, category)
@@ -108,53 +150,18 @@ func (g *Game) setQuestions(ctx context.Context) error {
 			category = Category{}
 		}
 	}
-
-	g.setDailyDoubles()
-
-	return nil
 }
 
-func (g *Game) setDailyDoubles() {
-	// based on daily_double_occurrence_bounds.sql
-	g.setFirstRoundDailyDouble()
-	g.setSecondRoundDailyDouble()
-	g.setSecondRoundDailyDouble()
-}
-
-func (g *Game) setFirstRoundDailyDouble() {
-	tIdx := rand.IntN(numCategories)
-	qIdx := 0
-	num := rand.IntN(10000)
-	if num < 15 {
-		qIdx = 0
-	} else if num < 1150 {
-		qIdx = 1
-	} else if num < 3916 {
-		qIdx = 2
-	} else if num < 7409 {
-		qIdx = 3
-	} else {
-		qIdx = 4
+// setValues gives the clues of a round the values of a custom value ladder.
+func setValues(round []Category, values []int) {
+	if len(values) == 0 {
+		return
 	}
-	g.FirstRound[tIdx].Questions[qIdx].DailyDouble = true
-}
-
-func (g *Game) setSecondRoundDailyDouble() {
-	tIdx := rand.IntN(numCategories)
-	qIdx := 0
-	num := rand.IntN(10000)
-	if num < 15 {
-		qIdx = 0
-	} else if num < 1524 {
-		qIdx = 1
-	} else if num < 4682 {
-		qIdx = 2
-	} else if num < 8220 {
-		qIdx = 3
-	} else {
-		qIdx = 4
+	for _, category := range round {
+		for i, q := range category.Questions {
+			q.Value = values[i]
+		}
 	}
-	g.SecondRound[tIdx].Questions[qIdx].DailyDouble = true
 }
 
 func (g *Game) firstAvailableQuestion() (int, int) {
@@ -162,8 +169,8 @@ func (g *Game) firstAvailableQuestion() (int, int) {
 	if g.Round == SecondRound {
 		curRound = g.SecondRound
 	}
-	for valIdx := 0; valIdx < numQuestions; valIdx++ {
-		for catIdx := 0; catIdx < numCategories; catIdx++ {
+	for valIdx := 0; valIdx < g.Questions; valIdx++ {
+		for catIdx := range curRound {
 			if curRound[catIdx].Questions[valIdx].CanChoose {
 				return catIdx, valIdx
 			}
@@ -23,30 +23,32 @@ func JoinReactions(playerId string, conn SafeConn) error {
 		return err
 	}
 
-	player, err := game.getPlayerById(playerId)
-	if err != nil {
-		return err
-	}
-	if player.reactionConn() != nil {
-		return fmt.Errorf("Player already in game reactions")
-	}
-	player.setReactionConn(conn)
+	return game.do(func() error {
+		player, err := game.getMemberById(playerId)
+		if err != nil {
+			return err
+		}
+		if player.reactionConn() != nil {
+			return fmt.Errorf("Player already in game reactions")
+		}
+		player.setReactionConn(conn)
 
-	player.sendReactionPings()
-	player.processReactions(game.reactChan)
+		player.sendReactionPings()
+		player.processReactions(game.reactChan, game.done)
 
-	return nil
+		return nil
+	})
 }
 
-func (p *Player) processReactions(reactChan chan Reaction) {
+func (p *Player) processReactions(reactChan chan Reaction, done <-chan struct{}) {
 	go func() {
-		log.Infof("Starting to process reaction messages for player %s", p.Name)
+		log.Infof("Starting to process reaction messages for player %s", p.name())
 		for {
 			message, err := p.readReaction()
 			if err != nil {
-				log.Errorf("Error reading reaction message from player %s: %s", p.Name, err.Error())
+				log.Errorf("Error reading reaction message from player %s: %s", p.name(), err.Error())
 				if websocket.IsCloseError(err, 1001) {
-					log.Infof("Player %s closed reaction connection", p.Name)
+					log.Infof("Player %s closed reaction connection", p.name())
 				}
 				return
 			}
@@ -54,20 +56,25 @@ func (p *Player) processReactions(reactChan chan Reaction) {
 			if err := json.Unmarshal(message, &msg); err != nil {
 				log.Errorf("Error parsing reaction message: %s", err.Error())
 			}
-			msg.PlayerName = p.Name
+			msg.PlayerName = p.name()
 			msg.TimeStamp = time.Now().Unix()
 			msg.RandPos = getRandPos(10, 150)
-			reactChan <- msg
+			select {
+			case reactChan <- msg:
+			case <-done:
+				return
+			}
 		}
 	}()
 }
 
 func (p *Player) readReaction() ([]byte, error) {
-	if p.ReactionConn == nil {
-		log.Infof("Skipping reading reaction from player %s because connection is nil", p.Name)
-		return nil, fmt.Errorf("Player %s has no reaction connection", p.Name)
+	conn := p.reactionConn()
+	if conn == nil {
+		log.Infof("Skipping reading reaction from player %s because connection is nil", p.name())
+		return nil, fmt.Errorf("Player %s has no reaction connection", p.name())
 	}
-	_, msg, err := p.ReactionConn.ReadMessage()
+	_, msg, err := conn.ReadMessage()
 	if err != nil {
 		return nil, err
 	}
@@ -75,11 +82,12 @@ func (p *Player) readReaction() ([]byte, error) {
 }
 
 func (p *Player) sendReaction(msg Reaction) error {
-	if p.ReactionConn == nil {
+	conn := p.reactionConn()
+	if conn == nil {
 		return fmt.Errorf("player has no reaction connection")
 	}
-	if err := p.ReactionConn.WriteJSON(msg); err != nil {
-		log.Errorf("Error sending reaction to player %s: %s", p.Name, err.Error())
+	if err := conn.WriteJSON(msg); err != nil {
+		log.Errorf("Error sending reaction to player %s: %s", p.name(), err.Error())
 		return fmt.Errorf("error sending reaction to player")
 	}
 	return nil
@@ -87,7 +95,7 @@ func (p *Player) sendReaction(msg Reaction) error {
 
 func (p *Player) sendReactionPings() {
 	go func() {
-		log.Infof("Starting to send reaction pings to player %s", p.Name)
+		log.Infof("Starting to send reaction pings to player %s", p.name())
 		pingErrors := 0
 		for {
 			select {
@@ -98,14 +106,15 @@ func (p *Player) sendReactionPings() {
 					TimeStamp:  time.Now().Unix(),
 					RandPos:    getRandPos(10, 10),
 				}); err != nil {
-					if p.ReactionConn == nil {
-						log.Infof("Stopping sending reaction pings to player %s because connection is nil", p.Name)
+					conn := p.reactionConn()
+					if conn == nil {
+						log.Infof("Stopping sending reaction pings to player %s because connection is nil", p.name())
 						return
 					}
 					pingErrors++
 					if pingErrors >= 3 {
-						log.Infof("Too many reaction ping errors, closing connection to player %s", p.Name)
-						if err := p.ReactionConn.Close(); err != nil {
+						log.Infof("Too many reaction ping errors, closing connection to player %s", p.name())
+						if err := conn.Close(); err != nil {
 							log.Errorf("Error closing connection: %s", err.Error())
 						}
 						return
@@ -0,0 +1,138 @@
+package jeopardy
+
+import (
+	"strings"
+	"sync"
+)
+
+// gameRegistry tracks every live game and which game each player is in.
+// It is safe for concurrent use by HTTP handlers and game event loops.
+type gameRegistry struct {
+	mu sync.RWMutex
+	// joinMu makes finding a seat in a public game, or starting one when
+	// there is none, a single step.
+	joinMu       sync.Mutex
+	privateGames map[string]*Game
+	publicGames  map[string]*Game
+	playerGames  map[string]*Game
+}
+
+var games = newGameRegistry()
+
+func newGameRegistry() *gameRegistry {
+	return &gameRegistry{
+		privateGames: map[string]*Game{},
+		publicGames:  map[string]*Game{},
+		playerGames:  map[string]*Game{},
+	}
+}
+
+func (r *gameRegistry) addPrivateGame(g *Game) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.privateGames[g.Name] = g
+}
+
+func (r *gameRegistry) addPublicGame(g *Game) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.publicGames[g.Name] = g
+}
+
+// restore registers a game rebuilt from a snapshot along with its players.
+func (r *gameRegistry) restore(g *Game) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if g.public {
+		r.publicGames[g.Name] = g
+	} else {
+		r.privateGames[g.Name] = g
+	}
+	for _, p := range g.Players {
+		if team, ok := p.(*Team); ok {
+			for _, m := range team.Members {
+				r.playerGames[m.id()] = g
+			}
+		} else if !p.isBot() {
+			r.playerGames[p.id()] = g
+		}
+	}
+	if g.Host != nil {
+		r.playerGames[g.Host.id()] = g
+	}
+}
+
+func (r *gameRegistry) addPlayer(playerId string, g *Game) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.playerGames[playerId] = g
+}
+
+func (r *gameRegistry) removePlayer(playerId string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.playerGames, playerId)
+}
+
+func (r *gameRegistry) playerGame(playerId string) (*Game, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	g, ok := r.playerGames[playerId]
+	return g, ok
+}
+
+func (r *gameRegistry) removeGame(g *Game, playerIds []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.publicGames, g.Name)
+	delete(r.privateGames, g.Name)
+	for _, id := range playerIds {
+		// tournament players may have moved on to their next game
+		if r.playerGames[id] == g {
+			delete(r.playerGames, id)
+		}
+	}
+}
+
+func (r *gameRegistry) public() []*Game {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	games := []*Game{}
+	for _, g := range r.publicGames {
+		games = append(games, g)
+	}
+	return games
+}
+
+func (r *gameRegistry) private() []*Game {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	games := []*Game{}
+	for _, g := range r.privateGames {
+		games = append(games, g)
+	}
+	return games
+}
+
+func (r *gameRegistry) all() []*Game {
+	return append(r.public(), r.private()...)
+}
+
+func (r *gameRegistry) playerGameNames() map[string]string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := map[string]string{}
+	for playerId, g := range r.playerGames {
+		names[playerId] = g.Name
+	}
+	return names
+}
+
+func (r *gameRegistry) find(joinCode string) *Game {
+	for _, g := range r.all() {
+		if strings.EqualFold(g.Name, joinCode) || strings.EqualFold(g.Code, joinCode) {
+			return g
+		}
+	}
+	return nil
+}
@@ -0,0 +1,393 @@
+package jeopardy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/db"
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/log"
+)
+
+type (
+	EventType string
+
+	// GameEvent is an entry in a game's append-only replay log. Inputs to
+	// the game (messages, timeouts, players joining and leaving) are enough
+	// to replay it; state transitions are logged alongside them so the log
+	// can be read on its own.
+	GameEvent struct {
+		Seq      int             `json:"seq"`
+		Type     EventType       `json:"type"`
+		Time     time.Time       `json:"time"`
+		PlayerId string          `json:"playerId,omitempty"`
+		Team     string          `json:"team,omitempty"`
+		Slot     int             `json:"slot,omitempty"`
+		Player   *playerSnapshot `json:"player,omitempty"`
+		Message  *Message        `json:"message,omitempty"`
+		Timeout  string          `json:"timeout,omitempty"`
+		State    GameState       `json:"state,omitempty"`
+		Round    RoundState      `json:"round,omitempty"`
+		Game     *gameCreated    `json:"game,omitempty"`
+		Board    *boardSnapshot  `json:"board,omitempty"`
+	}
+
+	gameCreated struct {
+		Id        string     `json:"id"`
+		Name      string     `json:"name"`
+		Code      string     `json:"code"`
+		Seed      uint64     `json:"seed"`
+		ImgOffset int        `json:"imgOffset"`
+		Config    GameConfig `json:"config"`
+	}
+
+	boardSnapshot struct {
+		FirstRound    []categorySnapshot `json:"firstRound"`
+		SecondRound   []categorySnapshot `json:"secondRound"`
+		FinalQuestion *questionSnapshot  `json:"finalQuestion"`
+	}
+
+	gameEventStore interface {
+		AppendGameEvents(ctx context.Context, gameId string, firstSeq int, events []string) error
+		GetGameEvents(ctx context.Context, gameId string) ([][]byte, error)
+	}
+)
+
+const (
+	EventCreated      EventType = "created"
+	EventBoard        EventType = "board"
+	EventJoined       EventType = "joined"
+	EventHosted       EventType = "hosted"
+	EventRejoined     EventType = "rejoined"
+	EventBotAdded     EventType = "botAdded"
+	EventConnected    EventType = "connected"
+	EventStarted      EventType = "started"
+	EventMessage      EventType = "message"
+	EventTimeout      EventType = "timeout"
+	EventDisconnected EventType = "disconnected"
+	EventPlayAgain    EventType = "playAgain"
+	EventRestored     EventType = "restored"
+	EventState        EventType = "state"
+)
+
+var eventStore gameEventStore
+
+// record appends an event to the game's replay log. It runs on the game's
+// event loop, which writes the new events out after every event.
+func (g *Game) record(event GameEvent) {
+	event.Seq = g.eventSeq
+	event.Time = g.clock.Now()
+	g.eventSeq++
+	g.pendingEvents = append(g.pendingEvents, event)
+}
+
+func (g *Game) flushEvents(ctx context.Context) {
+	if g.replaying || len(g.pendingEvents) == 0 {
+		return
+	}
+	if eventStore == nil {
+		g.pendingEvents = nil
+		return
+	}
+	events := []string{}
+	for _, event := range g.pendingEvents {
+		data, err := json.Marshal(event)
+		if err != nil {
+			log.Errorf("Error marshalling event for game %s: %s", g.Name, err.Error())
+			return
+		}
+		events = append(events, string(data))
+	}
+	if err := eventStore.AppendGameEvents(ctx, g.Id, g.pendingEvents[0].Seq, events); err != nil {
+		log.Errorf("Error saving events for game %s: %s", g.Name, err.Error())
+		return
+	}
+	g.pendingEvents = nil
+}
+
+func (g *Game) created() *gameCreated {
+	return &gameCreated{
+		Id:        g.Id,
+		Name:      g.Name,
+		Code:      g.Code,
+		Seed:      g.seed,
+		ImgOffset: g.imgOffset,
+		Config:    g.GameConfig,
+	}
+}
+
+func (g *Game) board() *boardSnapshot {
+	return &boardSnapshot{
+		FirstRound:    snapshotCategories(g.FirstRound),
+		SecondRound:   snapshotCategories(g.SecondRound),
+		FinalQuestion: snapshotQuestion(g.FinalQuestion),
+	}
+}
+
+// loggedPlayer is how a player appears in the replay log, without the
+// email address the rest of the log has no need for.
+func loggedPlayer(p GamePlayer) *playerSnapshot {
+	player := snapshotPlayer(p)
+	player.Email = ""
+	for i := range player.Members {
+		player.Members[i].Email = ""
+	}
+	return &player
+}
+
+// GetGameReplay returns the replay log of the game with the given id.
+func GetGameReplay(ctx context.Context, gameId string) ([]GameEvent, error) {
+	if _, err := uuid.Parse(gameId); err != nil {
+		return nil, fmt.Errorf("Invalid game id")
+	}
+	if eventStore == nil {
+		return nil, fmt.Errorf("Replays are not being recorded")
+	}
+	// the log has every answer on the board, so it stays hidden until the
+	// game is over
+	for _, g := range games.all() {
+		if g.Id != gameId {
+			continue
+		}
+		over := false
+		if err := g.do(func() error {
+			over = g.State == PostGame
+			return nil
+		}); err == nil && !over {
+			return nil, fmt.Errorf("Game not found")
+		}
+	}
+	data, err := eventStore.GetGameEvents(ctx, gameId)
+	if err != nil {
+		log.Errorf("Error getting events for game %s: %s", gameId, err.Error())
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("Game not found")
+	}
+	events := []GameEvent{}
+	for _, d := range data {
+		var event GameEvent
+		if err := json.Unmarshal(d, &event); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// Replay rebuilds a game from its replay log by feeding a fresh game the
+// same inputs at the same times. The game it returns has no event loop,
+// players or bots attached and is only meant to be inspected.
+func Replay(events []GameEvent) (*Game, error) {
+	if len(events) == 0 || events[0].Type != EventCreated || events[0].Game == nil {
+		return nil, fmt.Errorf("Replay must begin with the game being created")
+	}
+	created := events[0].Game
+	clock := &replayClock{now: events[0].Time}
+	g := newGame(replayDB{}, created.Config, WithSeed(created.Seed), WithClock(clock))
+	g.replaying = true
+	g.Id = created.Id
+	g.Name = created.Name
+	g.Code = created.Code
+	g.imgOffset = created.ImgOffset
+	g.replayBoards = []*boardSnapshot{}
+	for _, event := range events {
+		if event.Type == EventBoard {
+			g.replayBoards = append(g.replayBoards, event.Board)
+		}
+	}
+
+	ctx := context.Background()
+	for _, event := range events {
+		clock.now = event.Time
+		if err := g.replay(ctx, event); err != nil {
+			return nil, fmt.Errorf("Error replaying event %d: %w", event.Seq, err)
+		}
+	}
+	return g, nil
+}
+
+func (g *Game) replay(ctx context.Context, event GameEvent) error {
+	switch event.Type {
+	case EventCreated:
+		g.record(GameEvent{Type: EventCreated, Game: g.created()})
+		return g.setQuestions(ctx)
+	case EventJoined:
+		if event.Player == nil {
+			return fmt.Errorf("missing player")
+		}
+		player := g.restorePlayer(*event.Player)
+		if event.Team != "" {
+			team, ok := g.teamById(event.Team)
+			if !ok {
+				return fmt.Errorf("team %s not found", event.Team)
+			}
+			team.Members = append(team.Members, player.(*Player))
+		} else {
+			g.Players = append(g.Players, player)
+		}
+		g.record(GameEvent{Type: EventJoined, Team: event.Team, Player: loggedPlayer(player)})
+	case EventHosted:
+		if event.Player == nil {
+			return fmt.Errorf("missing host")
+		}
+		g.Host = g.restorePlayer(*event.Player).(*Player)
+		g.record(GameEvent{Type: EventHosted, Player: loggedPlayer(g.Host)})
+	case EventRejoined:
+		if event.Player == nil || event.Slot < 0 || event.Slot >= len(g.Players) {
+			return fmt.Errorf("invalid rejoin")
+		}
+		g.rejoin(g.Players[event.Slot], event.Slot, event.Player.Id, event.Player.Name, event.Player.ImgUrl)
+	case EventBotAdded:
+		if event.Player == nil || event.Slot < 0 || event.Slot > len(g.Players) {
+			return fmt.Errorf("invalid bot")
+		}
+		bot := g.restorePlayer(*event.Player).(*Bot)
+		bot.stopped = true
+		if event.Slot == len(g.Players) {
+			g.Players = append(g.Players, bot)
+		} else {
+			g.Players[event.Slot] = bot
+		}
+		g.record(GameEvent{Type: EventBotAdded, Slot: event.Slot, Player: loggedPlayer(bot)})
+	case EventConnected:
+		player, err := g.getMemberById(event.PlayerId)
+		if err != nil {
+			return err
+		}
+		g.connect(player, replayConn{})
+	case EventStarted:
+		g.start()
+	case EventMessage:
+		if event.Message == nil {
+			return fmt.Errorf("missing message")
+		}
+		player, err := g.getMemberById(event.PlayerId)
+		if err != nil {
+			return err
+		}
+		msg := *event.Message
+		msg.Player = player
+		if err := g.processMsg(ctx, msg); err != nil {
+			log.Infof("Replayed message was rejected: %s", err.Error())
+		}
+	case EventTimeout:
+		t, ok := g.running[event.Timeout]
+		if !ok {
+			return fmt.Errorf("timeout %s is not running", event.Timeout)
+		}
+		t.fire()
+	case EventDisconnected:
+		player, err := g.getMemberById(event.PlayerId)
+		if err != nil {
+			return err
+		}
+		g.disconnectPlayer(player)
+	case EventPlayAgain:
+		player, err := g.getPlayerById(event.PlayerId)
+		if err != nil {
+			return err
+		}
+		g.playAgain(ctx, player)
+	case EventRestored:
+		g.record(GameEvent{Type: EventRestored})
+		g.suspend()
+	case EventBoard, EventState:
+		// outputs of the events above, recorded again as they happen
+	default:
+		return fmt.Errorf("unknown event type %s", event.Type)
+	}
+	return nil
+}
+
+func (g *Game) nextReplayBoard() error {
+	if len(g.replayBoards) == 0 {
+		return fmt.Errorf("replay has no more boards")
+	}
+	board := g.replayBoards[0]
+	g.replayBoards = g.replayBoards[1:]
+	g.restoreBoard(board)
+	return nil
+}
+
+// restoreBoard puts the rounds and Final Jeopardy of a snapshot on the board.
+func (g *Game) restoreBoard(board *boardSnapshot) {
+	g.FirstRound = g.restoreCategories(board.FirstRound)
+	g.SecondRound = g.restoreCategories(board.SecondRound)
+	g.FinalQuestion = g.restoreQuestion(board.FinalQuestion)
+	g.record(GameEvent{Type: EventBoard, Board: g.board()})
+}
+
+// replayClock is the clock of a replayed game. Time is set to each event's
+// time as it is replayed, and timeouts only fire when the log says they did.
+type replayClock struct {
+	now time.Time
+}
+
+func (c *replayClock) Now() time.Time {
+	return c.now
+}
+
+func (c *replayClock) After(d time.Duration) <-chan time.Time {
+	return nil
+}
+
+func (c *replayClock) AfterFunc(d time.Duration, f func()) func() {
+	return func() {}
+}
+
+// replayConn stands in for the connection of a player in a replayed game.
+type replayConn struct{}
+
+func (replayConn) ReadMessage() (int, []byte, error) {
+	return 0, nil, fmt.Errorf("replayed connections cannot be read")
+}
+
+func (replayConn) WriteJSON(v interface{}) error {
+	return nil
+}
+
+func (replayConn) Close() error {
+	return nil
+}
+
+// replayDB discards the writes a replayed game makes, which were already
+// made when the game was played.
+type replayDB struct{}
+
+func (replayDB) GetQuestions(ctx context.Context, frCategories, srCategories, clues int) ([]db.Question, error) {
+	return nil, fmt.Errorf("replayed games take their questions from the replay")
+}
+
+func (replayDB) GetDailyQuestions(ctx context.Context, day string, frCategories, srCategories, clues int) ([]db.Question, error) {
+	return nil, fmt.Errorf("replayed games take their boards from the log")
+}
+
+func (replayDB) GetCategoryQuestions(ctx context.Context, category db.Category) ([]db.Question, error) {
+	return nil, fmt.Errorf("replayed games take their questions from the replay")
+}
+
+func (replayDB) GetCustomBoard(ctx context.Context, id string) (db.CustomBoard, error) {
+	return db.CustomBoard{}, fmt.Errorf("replayed games take their boards from the log")
+}
+
+func (replayDB) AddPendingAlternative(ctx context.Context, alt db.PendingAlternative) error {
+	return nil
+}
+
+func (replayDB) AddIncorrect(ctx context.Context, clueId int, incorrect string) error {
+	return nil
+}
+
+func (replayDB) SaveGameAnalytics(ctx context.Context, gameID uuid.UUID, createdAt int64, fr db.AnalyticsRound, sr db.AnalyticsRound) error {
+	return nil
+}
+
+func (replayDB) IncrementPlayerGames(ctx context.Context, email string, wins, points, answers, correct int) error {
+	return nil
+}
+
+func (replayDB) Close() {}
@@ -0,0 +1,89 @@
+package jeopardy
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplay(t *testing.T) {
+	t.Run("test replay reaches the same state as the game", func(t *testing.T) {
+		ctx := context.Background()
+		store := newTestDB(t)
+		eventStore = store
+		defer func() { eventStore = nil }()
+
+		clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		g := newTestGame(t, 11, clock, 2)
+		playScript(t, g, clock)
+		assert.NoError(t, g.do(func() error {
+			for _, p := range g.Players {
+				g.playAgain(ctx, p)
+			}
+			return nil
+		}))
+		clock.Advance(boardIntroTimeout * time.Second)
+		assert.NoError(t, g.do(func() error {
+			assert.Equal(t, RecvPick, g.State)
+			return g.processMsg(ctx, Message{Player: g.Players[0], State: RecvPick, CatIdx: 1, ValIdx: 0})
+		}))
+		clock.Advance(time.Duration(g.BuzzTimeout) * time.Second)
+		g.stop()
+
+		events, err := GetGameReplay(ctx, g.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, EventCreated, events[0].Type)
+		boards := 0
+		for _, event := range events {
+			if event.Type == EventBoard {
+				boards++
+			}
+		}
+		assert.Equal(t, 2, boards)
+
+		r, err := Replay(events)
+		if !assert.NoError(t, err) {
+			return
+		}
+		want, _ := json.Marshal(g.snapshot())
+		got, _ := json.Marshal(r.snapshot())
+		assert.JSONEq(t, string(want), string(got))
+
+		logged, _ := json.Marshal(events)
+		replayed, _ := json.Marshal(r.pendingEvents)
+		assert.JSONEq(t, string(logged), string(replayed))
+	})
+
+	t.Run("test replay of a game that isn't over", func(t *testing.T) {
+		ctx := context.Background()
+		eventStore = newTestDB(t)
+		defer func() { eventStore = nil }()
+
+		clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		g := newTestGame(t, 11, clock, 2)
+		games.addPrivateGame(g)
+		defer games.removeGame(g, nil)
+		defer g.stop()
+
+		_, err := GetGameReplay(ctx, g.Id)
+		assert.EqualError(t, err, "Game not found")
+
+		playScript(t, g, clock)
+		events, err := GetGameReplay(ctx, g.Id)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, events)
+	})
+
+	t.Run("test replay of unknown game", func(t *testing.T) {
+		eventStore = newTestDB(t)
+		defer func() { eventStore = nil }()
+
+		_, err := GetGameReplay(context.Background(), "not-a-game")
+		assert.Error(t, err)
+		_, err = GetGameReplay(context.Background(), "6f1c8a4e-0d0b-4a5e-9a43-6d8c2c0e6a11")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,247 @@
+package jeopardy
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/db"
+)
+
+type (
+	// SimulationConfig describes the bot only games to simulate.
+	SimulationConfig struct {
+		Games     int
+		BotLevels []string
+		FullGame  bool
+		// Seed makes the simulation repeatable when it isn't zero. The
+		// boards still come from the database.
+		Seed uint64
+	}
+
+	// SimulationReport is the distribution of the final scores of each seat
+	// in the simulated games.
+	SimulationReport struct {
+		Games int          `json:"games"`
+		Seats []SeatReport `json:"seats"`
+	}
+
+	SeatReport struct {
+		Level  string  `json:"level"`
+		Mean   float64 `json:"mean"`
+		StdDev float64 `json:"stdDev"`
+		Min    int     `json:"min"`
+		P10    int     `json:"p10"`
+		Median int     `json:"median"`
+		P90    int     `json:"p90"`
+		Max    int     `json:"max"`
+		// WinRate is the share of games the seat finished with the top
+		// score, ties counting as a win for everyone tied.
+		WinRate float64 `json:"winRate"`
+	}
+
+	// simulationDB reads clues from the game's database but discards the
+	// writes of simulated games, which nobody played.
+	simulationDB struct {
+		jeopardyDB
+	}
+
+	simulatedAction struct {
+		action *botAction
+		at     time.Time
+	}
+)
+
+const (
+	maxSimulatedGames = 100000
+
+	// maxSimulationSteps is how many messages and timeouts a simulated game
+	// can take before it is considered stuck.
+	maxSimulationSteps = 10000
+
+	// botsOnlyBuzzWait is how long a bot waits before buzzing in or passing
+	// once every person has passed, which in a game of only bots is from
+	// the start. See sendBuzzAfter.
+	botsOnlyBuzzWait = 4 * time.Second
+)
+
+// Simulate plays games between bots at the given levels, taking each bot's
+// actions and the game's timeouts in order without waiting for them, and
+// reports how the bots scored.
+func Simulate(ctx context.Context, sim SimulationConfig) (SimulationReport, error) {
+	if sim.Games < 1 || sim.Games > maxSimulatedGames {
+		return SimulationReport{}, fmt.Errorf("Games must be between 1 and %d, got: %d", maxSimulatedGames, sim.Games)
+	}
+	if len(sim.BotLevels) < 2 {
+		return SimulationReport{}, fmt.Errorf("At least 2 bots are needed to simulate a game, got: %d", len(sim.BotLevels))
+	}
+	config := GameConfig{
+		FullGame:      sim.FullGame,
+		Penalty:       true,
+		Bots:          len(sim.BotLevels),
+		BotLevels:     sim.BotLevels,
+		PickTimeout:   30,
+		BuzzTimeout:   30,
+		AnswerTimeout: 30,
+		WagerTimeout:  30,
+	}
+	if err := config.Validate(); err != nil {
+		return SimulationReport{}, err
+	}
+	jeopardyDB, err := newJeopardyDB(ctx)
+	if err != nil {
+		return SimulationReport{}, err
+	}
+	defer jeopardyDB.Close()
+
+	rng := rand.New(rand.NewPCG(sim.Seed, sim.Seed))
+	if sim.Seed == 0 {
+		rng = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	}
+	scores := make([][]int, len(sim.BotLevels))
+	wins := make([]int, len(sim.BotLevels))
+	for i := 0; i < sim.Games; i++ {
+		g, err := simulateGame(ctx, simulationDB{jeopardyDB}, config, rng.Uint64())
+		if err != nil {
+			return SimulationReport{}, fmt.Errorf("Error simulating game %d: %w", i+1, err)
+		}
+		for seat, player := range g.Players {
+			scores[seat] = append(scores[seat], player.score())
+			if g.isWinner(player.score()) {
+				wins[seat]++
+			}
+		}
+	}
+
+	report := SimulationReport{Games: sim.Games, Seats: []SeatReport{}}
+	for seat, level := range sim.BotLevels {
+		seatReport := scoreDistribution(scores[seat])
+		seatReport.Level = level
+		seatReport.WinRate = float64(wins[seat]) / float64(sim.Games)
+		report.Seats = append(report.Seats, seatReport)
+	}
+	return report, nil
+}
+
+// simulateGame plays one game between bots to the end. Each bot's latest
+// action is sent once its delay passes, unless a timeout comes first.
+func simulateGame(ctx context.Context, jeopardyDB jeopardyDB, config GameConfig, seed uint64) (*Game, error) {
+	clock := &replayClock{now: time.Unix(0, 0)}
+	g := newGame(jeopardyDB, config, WithSeed(seed), WithClock(clock))
+	if err := g.setQuestions(ctx); err != nil {
+		return nil, err
+	}
+
+	pending := make([]*simulatedAction, config.Bots)
+	for seat := 0; seat < config.Bots; seat++ {
+		bot := g.addBot(seat)
+		bot.onAction = func(action *botAction) {
+			pending[seat] = nil
+			if action == nil {
+				return
+			}
+			delay := action.delay
+			if action.buzz {
+				delay = min(delay, botsOnlyBuzzWait)
+				if action.msg.IsPass {
+					delay = min(delay, botPassTimeout)
+				}
+			}
+			pending[seat] = &simulatedAction{action: action, at: clock.now.Add(delay)}
+		}
+	}
+	g.start()
+
+	for steps := 0; g.State != PostGame; steps++ {
+		if steps == maxSimulationSteps {
+			return nil, fmt.Errorf("game did not finish after %d steps", maxSimulationSteps)
+		}
+		g.pendingEvents = nil
+
+		next := -1
+		for seat, a := range pending {
+			if a != nil && (next == -1 || a.at.Before(pending[next].at)) {
+				next = seat
+			}
+		}
+		keys := []string{}
+		for key := range g.running {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		timeout := ""
+		for _, key := range keys {
+			if timeout == "" || g.running[key].deadline.Before(g.running[timeout].deadline) {
+				timeout = key
+			}
+		}
+
+		switch {
+		case next != -1 && (timeout == "" || !g.running[timeout].deadline.Before(pending[next].at)):
+			a := pending[next]
+			pending[next] = nil
+			clock.now = a.at
+			// messages sent for an earlier state are ignored, as they are
+			// when bots play
+			_ = g.processMsg(ctx, a.action.msg)
+		case timeout != "":
+			t := g.running[timeout]
+			clock.now = t.deadline
+			t.fire()
+		default:
+			return nil, fmt.Errorf("game is stuck in state %d", g.State)
+		}
+	}
+	g.pendingEvents = nil
+	return g, nil
+}
+
+func scoreDistribution(scores []int) SeatReport {
+	sorted := slices.Clone(scores)
+	slices.Sort(sorted)
+	total := 0
+	for _, score := range sorted {
+		total += score
+	}
+	mean := float64(total) / float64(len(sorted))
+	variance := 0.0
+	for _, score := range sorted {
+		variance += (float64(score) - mean) * (float64(score) - mean)
+	}
+	percentile := func(p int) int {
+		return sorted[(len(sorted)-1)*p/100]
+	}
+	return SeatReport{
+		Mean:   mean,
+		StdDev: math.Sqrt(variance / float64(len(sorted))),
+		Min:    sorted[0],
+		P10:    percentile(10),
+		Median: percentile(50),
+		P90:    percentile(90),
+		Max:    sorted[len(sorted)-1],
+	}
+}
+
+func (simulationDB) AddPendingAlternative(ctx context.Context, alt db.PendingAlternative) error {
+	return nil
+}
+
+func (simulationDB) AddIncorrect(ctx context.Context, clueId int, incorrect string) error {
+	return nil
+}
+
+func (simulationDB) SaveGameAnalytics(ctx context.Context, gameID uuid.UUID, createdAt int64, fr db.AnalyticsRound, sr db.AnalyticsRound) error {
+	return nil
+}
+
+func (simulationDB) IncrementPlayerGames(ctx context.Context, email string, wins, points, answers, correct int) error {
+	return nil
+}
+
+// Close leaves the database open for the rest of the simulation.
+func (simulationDB) Close() {}
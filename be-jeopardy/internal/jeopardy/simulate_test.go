@@ -0,0 +1,101 @@
+package jeopardy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClueDifficulty(t *testing.T) {
+	t.Run("test rates are read from rows with enough answers", func(t *testing.T) {
+		rates, loaded := clueDifficultyRates(clueDifficulty, []db.ClueDifficulty{
+			{Round: 1, Value: 200, Shown: 40, Answered: 36, Answers: 30, Correct: 27},
+			{Round: 1, Value: 200, Shown: 60, Answered: 54, Answers: 70, Correct: 49},
+			{Round: 2, Value: 2000, Shown: 100, Answered: 40, Answers: 20, Correct: 10},
+			{Round: 1, Value: 300, Shown: 1000, Answered: 0, Answers: 1000, Correct: 0},
+			{Round: 3, Value: 400, Shown: 1000, Answered: 0, Answers: 1000, Correct: 0},
+		})
+		assert.Equal(t, 3, loaded)
+		assert.Equal(t, clueRates{attempt: 0.9, accuracy: 0.76}, rates[0][0])
+		assert.Equal(t, clueRates{attempt: 0.4, accuracy: clueDifficulty[1][4].accuracy}, rates[1][4])
+		assert.Equal(t, clueDifficulty[0][1], rates[0][1])
+	})
+
+	t.Run("test rates of the current clue", func(t *testing.T) {
+		config := GameConfig{FullGame: true, Penalty: true, PickTimeout: 30, BuzzTimeout: 30, AnswerTimeout: 30, WagerTimeout: 30, Categories: 4, Questions: 3}
+		err := config.Validate()
+		assert.NoError(t, err)
+		g := newGame(newTestDB(t), config, WithSeed(1))
+		assert.NoError(t, g.setQuestions(context.Background()))
+
+		g.CurQuestion = g.FirstRound[0].Questions[2]
+		g.CurQuestion.Incorrect = nil
+		assert.Equal(t, clueDifficulty[0][3], g.curClueRates())
+		g.CurQuestion.Incorrect = make([]string, incorrectWeight)
+		assert.InDelta(t, clueDifficulty[0][3].accuracy/2, g.curClueRates().accuracy, 1e-9)
+
+		g.Round = SecondRound
+		g.CurQuestion = g.SecondRound[1].Questions[0]
+		g.CurQuestion.Incorrect = nil
+		assert.Equal(t, clueDifficulty[1][0], g.curClueRates())
+
+		g.Round = FinalRound
+		g.CurQuestion = g.FinalQuestion
+		g.CurQuestion.Incorrect = nil
+		assert.Equal(t, clueRates{attempt: 1, accuracy: finalJeopardyAccuracy}, g.curClueRates())
+	})
+}
+
+func TestSimulate(t *testing.T) {
+	ctx := context.Background()
+	memoryDB := newTestDB(t)
+	prevDB := newJeopardyDB
+	newJeopardyDB = func(ctx context.Context) (jeopardyDB, error) {
+		return memoryDB, nil
+	}
+	defer func() {
+		newJeopardyDB = prevDB
+	}()
+
+	t.Run("test simulating bot only games", func(t *testing.T) {
+		games := 1000
+		if testing.Short() {
+			games = 100
+		}
+		report, err := Simulate(ctx, SimulationConfig{Games: games, BotLevels: []string{EasyBot, ContestantBot, ChampionBot}, FullGame: true, Seed: 7})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, games, report.Games)
+		assert.Len(t, report.Seats, 3)
+		easy, contestant, champion := report.Seats[0], report.Seats[1], report.Seats[2]
+		assert.Equal(t, ContestantBot, contestant.Level)
+		assert.Less(t, easy.Mean, contestant.Mean)
+		assert.Less(t, easy.Mean, champion.Mean)
+		assert.Greater(t, champion.WinRate, easy.WinRate)
+		for _, seat := range report.Seats {
+			assert.LessOrEqual(t, seat.Min, seat.P10)
+			assert.LessOrEqual(t, seat.P10, seat.Median)
+			assert.LessOrEqual(t, seat.Median, seat.P90)
+			assert.LessOrEqual(t, seat.P90, seat.Max)
+			assert.Greater(t, seat.StdDev, 0.0)
+		}
+	})
+
+	t.Run("test simulations that can't be run", func(t *testing.T) {
+		_, err := Simulate(ctx, SimulationConfig{Games: 0, BotLevels: []string{EasyBot, HardBot}})
+		assert.EqualError(t, err, "Games must be between 1 and 100000, got: 0")
+		_, err = Simulate(ctx, SimulationConfig{Games: 10, BotLevels: []string{EasyBot}})
+		assert.EqualError(t, err, "At least 2 bots are needed to simulate a game, got: 1")
+		_, err = Simulate(ctx, SimulationConfig{Games: 10, BotLevels: []string{EasyBot, "expert"}})
+		assert.EqualError(t, err, "Bot level must be easy, medium, hard, champion or contestant, got: expert")
+	})
+}
+
+func TestScoreDistribution(t *testing.T) {
+	report := scoreDistribution([]int{400, -200, 1000, 0, 800})
+	assert.Equal(t, SeatReport{Mean: 400, StdDev: report.StdDev, Min: -200, P10: -200, Median: 400, P90: 800, Max: 1000}, report)
+	assert.InDelta(t, 456.07, report.StdDev, 0.01)
+}
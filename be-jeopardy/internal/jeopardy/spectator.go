@@ -0,0 +1,161 @@
+package jeopardy
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/log"
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/socket"
+)
+
+// spectatorResponse is the Response sent to spectators, with the answer to
+// the question in play left out of the game.
+type spectatorResponse struct {
+	Response
+	Game      json.RawMessage `json:"game,omitempty"`
+	Spectator bool            `json:"spectator"`
+}
+
+var maxSpectators = 50
+
+// SpectateGame joins the game with the given name or code as a spectator.
+// Spectators don't take a seat in the game, so they can watch full games.
+func SpectateGame(req GameRequest, joinCode string) (*Game, string, error) {
+	game := games.find(joinCode)
+	if game == nil {
+		return &Game{}, "", fmt.Errorf("Game not found")
+	}
+
+	var spectator *Player
+	err := game.do(func() error {
+		if err := game.validateName(req.PlayerName); err != nil {
+			return err
+		}
+		if len(game.Spectators) >= maxSpectators {
+			return fmt.Errorf("Game has too many spectators")
+		}
+		imgUrl := req.PlayerImg
+		if imgUrl == "" {
+			imgUrl = playerImgs[(len(game.Spectators)+game.imgOffset)%len(playerImgs)]
+		}
+		spectator = NewPlayer(req.PlayerName, imgUrl, req.PlayerEmail)
+		game.Spectators = append(game.Spectators, spectator)
+		return nil
+	})
+	if err != nil {
+		return &Game{}, "", err
+	}
+
+	games.addPlayer(spectator.Id, game)
+
+	return game, spectator.Id, nil
+}
+
+// watch connects a spectator to the game's updates.
+func (g *Game) watch(spectator GamePlayer, conn SafeConn) error {
+	spectator.setConn(conn)
+	spectator.sendPings()
+	spectator.readMessages(g.msgChan, g.disconnectChan, g.done)
+	view, err := g.spectatorView()
+	if err != nil {
+		return err
+	}
+	g.messageSpectator(spectator, "Watching the game", view)
+	return nil
+}
+
+func (g *Game) isSpectator(player GamePlayer) bool {
+	for _, s := range g.Spectators {
+		if s.id() == player.id() {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Game) removeSpectator(spectator GamePlayer) {
+	spectator.endConnections()
+	for i, s := range g.Spectators {
+		if s.id() == spectator.id() {
+			g.Spectators = append(g.Spectators[:i], g.Spectators[i+1:]...)
+			break
+		}
+	}
+	games.removePlayer(spectator.id())
+}
+
+// getMemberById finds a player, team member, spectator or the host in the
+// game.
+func (g *Game) getMemberById(id string) (GamePlayer, error) {
+	for _, s := range g.Spectators {
+		if s.id() == id {
+			return s, nil
+		}
+	}
+	if g.Host != nil && g.Host.id() == id {
+		return g.Host, nil
+	}
+	for _, p := range g.Players {
+		if t, ok := p.(*Team); ok {
+			for _, m := range t.Members {
+				if m.id() == id {
+					return m, nil
+				}
+			}
+		}
+	}
+	return g.getPlayerById(id)
+}
+
+// answerHidden reports whether the answer to the current question is still
+// in play and should not be shown to anyone watching the game.
+func (g *Game) answerHidden() bool {
+	state := g.State
+	if g.Paused {
+		state = g.PausedState
+	}
+	return state == RecvBuzz || state == RecvAns || state == RecvWager
+}
+
+// spectatorView returns the game as JSON without the answer to the question
+// in play. It must be called on the game's event loop.
+func (g *Game) spectatorView() (json.RawMessage, error) {
+	officialAnswer := g.OfficialAnswer
+	if g.answerHidden() {
+		g.OfficialAnswer = ""
+	}
+	defer func() { g.OfficialAnswer = officialAnswer }()
+	return json.Marshal(g)
+}
+
+func (g *Game) messageSpectators(msg string) {
+	if len(g.Spectators) == 0 {
+		return
+	}
+	view, err := g.spectatorView()
+	if err != nil {
+		log.Errorf("Error marshalling game for spectators: %s", err.Error())
+		return
+	}
+	for _, s := range g.Spectators {
+		g.messageSpectator(s, msg, view)
+	}
+}
+
+func (g *Game) messageSpectator(spectator GamePlayer, msg string, view json.RawMessage) {
+	conn := spectator.conn()
+	if conn == nil {
+		return
+	}
+	if err := conn.WriteJSON(spectatorResponse{
+		Response: Response{
+			Code:      socket.Ok,
+			Message:   msg,
+			CurPlayer: spectator,
+		},
+		Game:      view,
+		Spectator: true,
+	}); err != nil {
+		log.Errorf("Error sending message to spectator %s: %s", spectator.name(), err.Error())
+	}
+}
@@ -0,0 +1,90 @@
+package jeopardy
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpectateGame(t *testing.T) {
+	t.Run("test spectators watch without playing", func(t *testing.T) {
+		ctx := context.Background()
+		clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		g := newTestGame(t, 9, clock, 2)
+		games.addPrivateGame(g)
+		defer func() {
+			_ = g.do(func() error {
+				removeGame(g)
+				return nil
+			})
+		}()
+
+		_, spectatorId, err := SpectateGame(GameRequest{PlayerName: "player0"}, g.Code)
+		assert.Error(t, err)
+		_, spectatorId, err = SpectateGame(GameRequest{PlayerName: "watcher"}, g.Code)
+		assert.NoError(t, err)
+		game, err := GetPlayerGame(spectatorId)
+		assert.NoError(t, err)
+		assert.Same(t, g, game)
+
+		conn := &testConn{}
+		chatConn := &testConn{}
+		var spectator GamePlayer
+		assert.NoError(t, g.do(func() error {
+			spectator, err = g.getMemberById(spectatorId)
+			if err != nil {
+				return err
+			}
+			spectator.setChatConn(chatConn)
+			return g.watch(spectator, conn)
+		}))
+
+		assert.NoError(t, g.do(func() error {
+			g.start()
+			return nil
+		}))
+		clock.Advance(boardIntroTimeout * time.Second)
+		assert.NoError(t, g.do(func() error {
+			assert.Error(t, g.processMsg(ctx, Message{Player: spectator, State: RecvPick, Pause: 1}))
+			assert.False(t, g.Paused)
+			return g.processMsg(ctx, Message{Player: g.Players[0], State: RecvPick, CatIdx: 0, ValIdx: 0})
+		}))
+
+		assert.NoError(t, g.do(func() error {
+			assert.False(t, spectator.canPick())
+			assert.False(t, spectator.canBuzz())
+			assert.Len(t, g.Players, 2)
+			assert.Equal(t, 2, g.acceptMajority())
+			assert.NotEmpty(t, g.OfficialAnswer)
+			return nil
+		}))
+
+		conn.mu.Lock()
+		last := conn.spectated[len(conn.spectated)-1]
+		conn.mu.Unlock()
+		assert.True(t, last.Spectator)
+		var view struct {
+			State          GameState `json:"state"`
+			OfficialAnswer string    `json:"officialAnswer"`
+		}
+		assert.NoError(t, json.Unmarshal(last.Game, &view))
+		assert.Contains(t, []GameState{RecvBuzz, RecvWager}, view.State)
+		assert.Empty(t, view.OfficialAnswer)
+
+		g.chatChan <- ChatMessage{PlayerName: "player0", Message: "hello"}
+		assert.NoError(t, g.do(func() error {
+			g.disconnectPlayer(spectator)
+			assert.False(t, g.Paused)
+			assert.Empty(t, g.Spectators)
+			return nil
+		}))
+		chatConn.mu.Lock()
+		assert.Len(t, chatConn.chats, 1)
+		chatConn.mu.Unlock()
+		_, err = GetPlayerGame(spectatorId)
+		assert.Error(t, err)
+	})
+}
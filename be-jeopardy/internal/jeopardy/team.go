@@ -0,0 +1,165 @@
+package jeopardy
+
+import (
+	"fmt"
+)
+
+// Team takes a single seat in a game in team mode. Its members share the
+// team's score and flags, so the rest of the game treats it like a player.
+type Team struct {
+	*Player
+	Members []*Player `json:"members"`
+	// Answerer is the member who buzzed in for the team.
+	Answerer string `json:"answerer"`
+}
+
+var maxTeamSize = 4
+
+func NewTeam(name, imgUrl string) *Team {
+	return &Team{
+		Player:  NewPlayer(name, imgUrl, ""),
+		Members: []*Player{},
+	}
+}
+
+// conn returns the connection of a connected member, so a team is only
+// disconnected once all of its members are.
+func (t *Team) conn() SafeConn {
+	for _, m := range t.Members {
+		if conn := m.conn(); conn != nil {
+			return conn
+		}
+	}
+	return nil
+}
+
+// captain is the member who wagers and answers for the team when no one
+// buzzed in, the first member still connected.
+func (t *Team) captain() *Player {
+	for _, m := range t.Members {
+		if m.conn() != nil {
+			return m
+		}
+	}
+	return t.Members[0]
+}
+
+func (t *Team) sendMessage(resp Response) error {
+	for _, m := range t.Members {
+		if m.conn() != nil {
+			_ = m.sendMessage(resp)
+		}
+	}
+	return nil
+}
+
+func (t *Team) sendChatMessage(msg ChatMessage) error {
+	for _, m := range t.Members {
+		_ = m.sendChatMessage(msg)
+	}
+	return nil
+}
+
+func (t *Team) sendReaction(msg Reaction) error {
+	for _, m := range t.Members {
+		_ = m.sendReaction(msg)
+	}
+	return nil
+}
+
+func (t *Team) endConnections() {
+	for _, m := range t.Members {
+		m.endConnections()
+	}
+}
+
+// joinTeam adds the player to the team they asked for, creating it if there
+// is a seat for it. Players who don't ask for a team fill the smallest one.
+func (g *Game) joinTeam(req GameRequest) (*Player, error) {
+	var team *Team
+	for _, p := range g.Players {
+		t, ok := p.(*Team)
+		if !ok {
+			continue
+		}
+		if req.Team == "" && (team == nil || len(t.Members) < len(team.Members)) {
+			team = t
+		} else if req.Team != "" && t.name() == req.Team {
+			team = t
+		}
+	}
+	if team == nil || (req.Team == "" && len(team.Members) >= maxTeamSize) {
+		if len(g.Players) >= maxPlayers {
+			return nil, GameFull
+		}
+		name := req.Team
+		if name == "" {
+			name = fmt.Sprintf("Team %d", len(g.Players)+1)
+		}
+		team = NewTeam(name, g.nextImg())
+		g.Players = append(g.Players, team)
+		g.record(GameEvent{Type: EventJoined, Player: loggedPlayer(team)})
+	}
+	if len(team.Members) >= maxTeamSize {
+		return nil, fmt.Errorf("Team %s is full", team.name())
+	}
+	imgUrl := req.PlayerImg
+	if imgUrl == "" {
+		imgUrl = team.ImgUrl
+	}
+	member := NewPlayer(req.PlayerName, imgUrl, req.PlayerEmail)
+	team.Members = append(team.Members, member)
+	g.record(GameEvent{Type: EventJoined, Team: team.id(), Player: loggedPlayer(member)})
+	return member, nil
+}
+
+// teamOf returns the team the member plays for, or nil if they aren't on a
+// team.
+func (g *Game) teamOf(member GamePlayer) *Team {
+	for _, p := range g.Players {
+		t, ok := p.(*Team)
+		if !ok {
+			continue
+		}
+		for _, m := range t.Members {
+			if m.id() == member.id() {
+				return t
+			}
+		}
+	}
+	return nil
+}
+
+// checkTeamTurn decides whether a member can act for their team. Any member
+// can pick or buzz, but only the member who buzzed can answer, and only the
+// captain wagers and answers Daily Doubles and Final Jeopardy.
+func (g *Game) checkTeamTurn(team *Team, member GamePlayer, msg Message) error {
+	switch g.State {
+	case RecvBuzz:
+		if team.canBuzz() && !msg.IsPass {
+			team.Answerer = member.id()
+		}
+	case RecvAns:
+		answerer := team.Answerer
+		if g.Round == FinalRound || g.CurQuestion.DailyDouble {
+			answerer = team.captain().id()
+		}
+		if member.id() != answerer {
+			return fmt.Errorf("player cannot answer for their team")
+		}
+	case RecvWager:
+		if member.id() != team.captain().id() {
+			return fmt.Errorf("only the team captain can wager")
+		}
+	}
+	return nil
+}
+
+func (g *Game) teamById(id string) (*Team, bool) {
+	for _, p := range g.Players {
+		if t, ok := p.(*Team); ok && t.id() == id {
+			return t, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,84 @@
+package jeopardy
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeamMode(t *testing.T) {
+	t.Run("test teams share a score and take turns", func(t *testing.T) {
+		ctx := context.Background()
+		eventStore = newTestDB(t)
+		defer func() { eventStore = nil }()
+
+		clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		questionDB := newTestDB(t)
+		questionDB.SetSeed(17)
+		config := GameConfig{FullGame: true, Penalty: true, TeamMode: true, PickTimeout: 30, BuzzTimeout: 30, AnswerTimeout: 30, WagerTimeout: 30}
+		err := config.Validate()
+		assert.NoError(t, err)
+		g, err := NewGame(ctx, questionDB, config, WithClock(clock), WithSeed(17))
+		assert.NoError(t, err)
+		defer g.stop()
+
+		var red, blue []*Player
+		assert.NoError(t, g.do(func() error {
+			for _, name := range []string{"r0", "r1", "r2", "r3"} {
+				member, err := g.joinTeam(GameRequest{PlayerName: name, Team: "Red"})
+				assert.NoError(t, err)
+				g.connect(member, &testConn{})
+				red = append(red, member)
+			}
+			_, err := g.joinTeam(GameRequest{PlayerName: "r4", Team: "Red"})
+			assert.Error(t, err)
+			member, err := g.joinTeam(GameRequest{PlayerName: "b0"})
+			assert.NoError(t, err)
+			g.connect(member, &testConn{})
+			blue = append(blue, member)
+			assert.Len(t, g.Players, 2)
+			assert.Equal(t, 2, g.numPlayers())
+			g.start()
+			return nil
+		}))
+		clock.Advance(boardIntroTimeout * time.Second)
+
+		team := g.Players[0].(*Team)
+		catIdx := 0
+		for g.FirstRound[catIdx].Questions[0].DailyDouble {
+			catIdx++
+		}
+		assert.NoError(t, g.do(func() error {
+			assert.Equal(t, "Red", team.name())
+			assert.NoError(t, g.processMsg(ctx, Message{Player: red[1], State: RecvPick, CatIdx: catIdx, ValIdx: 0}))
+			assert.NoError(t, g.processMsg(ctx, Message{Player: red[2], State: RecvBuzz}))
+			assert.Error(t, g.processMsg(ctx, Message{Player: red[1], State: RecvAns, Answer: g.CurQuestion.Answer}))
+			assert.NoError(t, g.processMsg(ctx, Message{Player: red[2], State: RecvAns, Answer: g.CurQuestion.Answer}))
+			assert.Equal(t, 200, team.score())
+			assert.True(t, team.canPick())
+			assert.Same(t, red[0], team.captain())
+
+			g.disconnectPlayer(red[0])
+			assert.False(t, g.Paused)
+			assert.Same(t, red[1], team.captain())
+			member, err := g.getMemberById(red[3].id())
+			assert.NoError(t, err)
+			assert.Same(t, red[3], member)
+			return nil
+		}))
+		g.stop()
+
+		events, err := GetGameReplay(ctx, g.Id)
+		assert.NoError(t, err)
+		r, err := Replay(events)
+		if !assert.NoError(t, err) {
+			return
+		}
+		want, _ := json.Marshal(g.snapshot())
+		got, _ := json.Marshal(r.snapshot())
+		assert.JSONEq(t, string(want), string(got))
+	})
+}
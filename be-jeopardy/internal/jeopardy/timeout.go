@@ -9,33 +9,103 @@ import (
 
 const boardIntroTimeout = 27
 
-type GameTimeouts struct {
-	cancelBoardIntroTimeout context.CancelFunc
-	cancelPickTimeout       context.CancelFunc
-	cancelBuzzTimeout       context.CancelFunc
-	cancelDisputeTimeout    context.CancelFunc
+type (
+	GameTimeouts struct {
+		cancelBoardIntroTimeout context.CancelFunc
+		cancelPickTimeout       context.CancelFunc
+		cancelBuzzTimeout       context.CancelFunc
+		cancelDisputeTimeout    context.CancelFunc
+
+		// running holds every timeout that has not yet fired or been
+		// cancelled, keyed by timeoutKey.
+		running map[string]*runningTimeout
+		// restoredTimeouts holds the time that was left on each timeout when
+		// a restored game was snapshotted, used in place of the full timeout
+		// the first time the game resumes.
+		restoredTimeouts map[string]time.Duration
+	}
+
+	runningTimeout struct {
+		deadline time.Time
+		fire     func()
+		cancel   context.CancelFunc
+	}
+)
+
+const (
+	boardIntroTimeoutKey = "boardIntro"
+	pickTimeoutKey       = "pick"
+	buzzTimeoutKey       = "buzz"
+	disputeTimeoutKey    = "dispute"
+	answerTimeoutKey     = "answer"
+	wagerTimeoutKey      = "wager"
+)
+
+func timeoutKey(name string, player GamePlayer) string {
+	if player.id() == "" {
+		return name
+	}
+	return name + ":" + player.id()
 }
 
-func (g *Game) startTimeout(ctx context.Context, timeout int, player GamePlayer, processTimeout func(player GamePlayer) error) {
-	go func() {
-		timeoutCtx, timeoutCancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
-		defer timeoutCancel()
-		select {
-		case <-ctx.Done():
-			return
-		case <-timeoutCtx.Done():
-			if err := processTimeout(player); err != nil {
-				log.Errorf("Unexpected error after timeout for player %s: %s\n", player.name(), err)
-			}
+// startTimeout runs processTimeout on the game's event loop once the timeout
+// passes, unless the returned cancel function is called first. Cancelling
+// must also happen on the event loop.
+func (g *Game) startTimeout(name string, timeout int, player GamePlayer, processTimeout func(player GamePlayer) error) context.CancelFunc {
+	key := timeoutKey(name, player)
+	duration := time.Duration(timeout) * time.Second
+	if remaining, ok := g.restoredTimeouts[key]; ok {
+		duration = remaining
+		delete(g.restoredTimeouts, key)
+	}
+	t := &runningTimeout{deadline: g.clock.Now().Add(duration)}
+	cancelled := false
+	t.fire = func() {
+		if cancelled {
 			return
 		}
-	}()
+		cancelled = true
+		g.clearTimeout(key, t)
+		g.record(GameEvent{Type: EventTimeout, Timeout: key})
+		if err := processTimeout(player); err != nil {
+			log.Errorf("Unexpected error after timeout for player %s: %s\n", player.name(), err)
+		}
+	}
+	stop := g.clock.AfterFunc(duration, func() {
+		_ = g.do(func() error {
+			t.fire()
+			return nil
+		})
+	})
+	t.cancel = func() {
+		if !cancelled {
+			cancelled = true
+			g.clearTimeout(key, t)
+		}
+		stop()
+	}
+	g.running[key] = t
+	return t.cancel
+}
+
+// clearTimeout forgets a timeout unless it has since been restarted.
+func (g *Game) clearTimeout(key string, t *runningTimeout) {
+	if g.running[key] == t {
+		delete(g.running, key)
+	}
+}
+
+// deadlines returns when each running timeout fires.
+func (g *Game) deadlines() map[string]time.Time {
+	deadlines := map[string]time.Time{}
+	for key, t := range g.running {
+		deadlines[key] = t.deadline
+	}
+	return deadlines
 }
 
 func (g *Game) startBoardIntroTimeout() {
-	ctx, cancel := context.WithCancel(context.Background())
-	g.cancelBoardIntroTimeout = cancel
-	g.startTimeout(ctx, boardIntroTimeout, &Player{}, func(_ GamePlayer) error {
+	g.cancelBoardIntroTimeout = g.startTimeout(boardIntroTimeoutKey, boardIntroTimeout, &Player{}, func(_ GamePlayer) error {
 		if g.Round == FirstRound {
 			g.resumeGame()
 		} else {
@@ -48,32 +118,27 @@ func (g *Game) startBoardIntroTimeout() {
 }
 
 func (g *Game) startPickTimeout(player GamePlayer) {
-	ctx, cancel := context.WithCancel(context.Background())
-	g.cancelPickTimeout = cancel
-	g.startTimeout(ctx, g.PickTimeout, &Player{}, func(_ GamePlayer) error {
+	g.cancelPickTimeout = g.startTimeout(pickTimeoutKey, g.PickTimeout, &Player{}, func(_ GamePlayer) error {
 		catIdx, valIdx := g.firstAvailableQuestion()
 		return g.processPick(player, catIdx, valIdx)
 	})
 }
 
 func (g *Game) startBuzzTimeout() {
-	ctx, cancel := context.WithCancel(context.Background())
-	g.cancelBuzzTimeout = cancel
-	g.startTimeout(ctx, g.BuzzTimeout, &Player{}, func(_ GamePlayer) error {
-		g.skipQuestion(ctx)
+	g.cancelBuzzTimeout = g.startTimeout(buzzTimeoutKey, g.BuzzTimeout, &Player{}, func(_ GamePlayer) error {
+		g.skipQuestion(context.Background())
 		return nil
 	})
 }
 
 func (g *Game) startAnswerTimeout(player GamePlayer) {
-	ctx, cancel := context.WithCancel(context.Background())
-	player.setCancelAnswerTimeout(cancel)
 	timeout := g.AnswerTimeout
 	if g.Round == FinalRound {
 		timeout = g.FinalAnswerTimeout
 		g.StartFinalAnswerCountdown = true
 	}
-	go g.startTimeout(ctx, timeout, player, func(player GamePlayer) error {
+	player.setCancelAnswerTimeout(g.startTimeout(answerTimeoutKey, timeout, player, func(player GamePlayer) error {
+		ctx := context.Background()
 		if g.Round == FinalRound {
 			return g.processFinalRoundAns(ctx, player, false, "answer-timeout")
 		}
@@ -86,13 +151,11 @@ func (g *Game) startAnswerTimeout(player GamePlayer) {
 		g.CurQuestion.Answers = append(g.CurQuestion.Answers, g.CurQuestion.CurAns)
 		g.nextQuestion(ctx, player, false)
 		return nil
-	})
+	}))
 }
 
 func (g *Game) startDisputeTimeout() {
-	ctx, cancel := context.WithCancel(context.Background())
-	g.cancelDisputeTimeout = cancel
-	g.startTimeout(ctx, g.DisputeTimeout, &Player{}, func(_ GamePlayer) error {
+	g.cancelDisputeTimeout = g.startTimeout(disputeTimeoutKey, g.DisputeTimeout, &Player{}, func(_ GamePlayer) error {
 		g.Disputers = 0
 		g.NonDisputers = 0
 		g.setState(RecvPick, g.DisputePicker)
@@ -102,18 +165,16 @@ func (g *Game) startDisputeTimeout() {
 }
 
 func (g *Game) startWagerTimeout(player GamePlayer) {
-	ctx, cancel := context.WithCancel(context.Background())
-	player.setCancelWagerTimeout(cancel)
 	wagerTimeout := g.WagerTimeout
 	if g.Round == FinalRound {
 		wagerTimeout = g.FinalWagerTimeout
 		g.StartFinalWagerCountdown = true
 	}
-	g.startTimeout(ctx, wagerTimeout, player, func(player GamePlayer) error {
+	player.setCancelWagerTimeout(g.startTimeout(wagerTimeoutKey, wagerTimeout, player, func(player GamePlayer) error {
 		wager := 5
 		if g.Round == FinalRound {
 			wager = 0
 		}
 		return g.processWager(player, wager)
-	})
+	}))
 }
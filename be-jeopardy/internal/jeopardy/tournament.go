@@ -0,0 +1,502 @@
+package jeopardy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/log"
+)
+
+type (
+	TournamentStage string
+
+	// Tournament seeds its players into games of three and advances the
+	// winner of each game, along with the highest scoring non-winners as
+	// wildcards, until a final decides the champion. Tournaments live in
+	// memory, so they don't survive a restart.
+	Tournament struct {
+		mu        sync.Mutex
+		config    GameConfig
+		organizer string
+		stages    []TournamentStage
+
+		Id       string              `json:"id"`
+		Name     string              `json:"name"`
+		Stage    TournamentStage     `json:"stage"`
+		Players  []*TournamentPlayer `json:"players"`
+		Rounds   []*TournamentRound  `json:"rounds"`
+		Champion string              `json:"champion"`
+	}
+
+	TournamentPlayer struct {
+		Id     string `json:"id"`
+		Name   string `json:"name"`
+		ImgUrl string `json:"imgUrl"`
+		Email  string `json:"-"`
+		Seed   int    `json:"seed"`
+		// Stage is the furthest stage the player has been seated in.
+		Stage      TournamentStage `json:"stage"`
+		Wildcard   bool            `json:"wildcard"`
+		Eliminated bool            `json:"eliminated"`
+	}
+
+	TournamentRound struct {
+		Stage   TournamentStage    `json:"stage"`
+		Matches []*TournamentMatch `json:"matches"`
+	}
+
+	// TournamentMatch is one game of a tournament round. Its scores are the
+	// players' final scores once the game ends, and a match that is
+	// abandoned before it ends is scored as it stands. When players tie for
+	// the top score, the best seed among them wins and Tiebreak is set.
+	TournamentMatch struct {
+		GameId   string         `json:"gameId"`
+		Game     string         `json:"game"`
+		Code     string         `json:"code"`
+		Players  []string       `json:"players"`
+		Scores   map[string]int `json:"scores"`
+		Winner   string         `json:"winner"`
+		Tiebreak bool           `json:"tiebreak"`
+		Complete bool           `json:"complete"`
+	}
+
+	TournamentStanding struct {
+		Rank       int             `json:"rank"`
+		PlayerId   string          `json:"playerId"`
+		Name       string          `json:"name"`
+		Seed       int             `json:"seed"`
+		Stage      TournamentStage `json:"stage"`
+		Score      int             `json:"score"`
+		Wildcard   bool            `json:"wildcard"`
+		Eliminated bool            `json:"eliminated"`
+	}
+
+	TournamentProgress struct {
+		Stage           TournamentStage `json:"stage"`
+		Matches         int             `json:"matches"`
+		CompleteMatches int             `json:"completeMatches"`
+		Players         int             `json:"players"`
+		Remaining       int             `json:"remaining"`
+	}
+
+	TournamentRequest struct {
+		Name   string      `json:"name"`
+		Config GameRequest `json:"config"`
+	}
+
+	tournamentRegistry struct {
+		mu          sync.RWMutex
+		tournaments map[string]*Tournament
+	}
+)
+
+const (
+	RegistrationStage TournamentStage = "registration"
+	QuarterfinalStage TournamentStage = "quarterfinal"
+	SemifinalStage    TournamentStage = "semifinal"
+	FinalStage        TournamentStage = "final"
+	CompleteStage     TournamentStage = "complete"
+
+	playersPerMatch = 3
+	// semifinalPlayers is the size of the semifinal field when there are
+	// quarterfinals, filled by the quarterfinal winners and wildcards.
+	semifinalPlayers     = 9
+	minTournamentPlayers = 4
+	maxTournamentPlayers = 27
+)
+
+var tournaments = &tournamentRegistry{tournaments: map[string]*Tournament{}}
+
+func (r *tournamentRegistry) add(t *Tournament) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tournaments[t.Id] = t
+}
+
+func (r *tournamentRegistry) get(id string) (*Tournament, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, ok := r.tournaments[id]
+	return t, ok
+}
+
+func (r *tournamentRegistry) all() []*Tournament {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ts := []*Tournament{}
+	for _, t := range r.tournaments {
+		ts = append(ts, t)
+	}
+	return ts
+}
+
+// inTournament makes the game a match of the tournament, which it reports
+// its result to when it ends.
+func inTournament(t *Tournament) GameOption {
+	return func(g *Game) {
+		g.tournament = t
+	}
+}
+
+// CreateTournament opens a tournament for registration. It returns the id
+// of the organizer, who is the only one who can start it.
+func CreateTournament(req TournamentRequest) (*Tournament, string, error) {
+	if len(req.Name) < 1 || len(req.Name) > 50 {
+		return nil, "", fmt.Errorf("Invalid tournament name")
+	}
+	c := req.Config
+	if c.HostMode || c.TeamMode || c.Bots > 0 {
+		return nil, "", fmt.Errorf("Tournament games cannot have a host, teams or bots")
+	}
+	config := c.gameConfig()
+	if err := config.Validate(); err != nil {
+		return nil, "", err
+	}
+	t := &Tournament{
+		config:    config,
+		organizer: uuid.New().String(),
+		Id:        uuid.New().String(),
+		Name:      req.Name,
+		Stage:     RegistrationStage,
+		Players:   []*TournamentPlayer{},
+		Rounds:    []*TournamentRound{},
+	}
+	tournaments.add(t)
+	return t, t.organizer, nil
+}
+
+func GetTournament(id string) (*Tournament, error) {
+	t, ok := tournaments.get(id)
+	if !ok {
+		return nil, fmt.Errorf("Tournament not found")
+	}
+	return t, nil
+}
+
+func GetTournaments() map[string]json.RawMessage {
+	resp := map[string]json.RawMessage{}
+	for _, t := range tournaments.all() {
+		snapshot, err := t.Snapshot()
+		if err != nil {
+			log.Errorf("Error getting tournament snapshot: %s", err.Error())
+			continue
+		}
+		resp[t.Id] = snapshot
+	}
+	return resp
+}
+
+// RegisterTournamentPlayer adds a player to the tournament and returns their
+// id, which they keep for every game they play in it. Players are seeded in
+// the order they register.
+func RegisterTournamentPlayer(tournamentId string, req GameRequest) (string, error) {
+	t, err := GetTournament(tournamentId)
+	if err != nil {
+		return "", err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.Stage != RegistrationStage {
+		return "", fmt.Errorf("Registration is closed")
+	}
+	if len(t.Players) >= maxTournamentPlayers {
+		return "", fmt.Errorf("Tournament is full")
+	}
+	if len(req.PlayerName) < 1 || len(req.PlayerName) > 50 {
+		return "", fmt.Errorf("Invalid player name")
+	}
+	for _, p := range t.Players {
+		if p.Name == req.PlayerName {
+			return "", fmt.Errorf("Sorry, %s is already taken", req.PlayerName)
+		}
+	}
+	player := &TournamentPlayer{
+		Id:     uuid.New().String(),
+		Name:   req.PlayerName,
+		ImgUrl: req.PlayerImg,
+		Email:  req.PlayerEmail,
+		Seed:   len(t.Players) + 1,
+		Stage:  RegistrationStage,
+	}
+	t.Players = append(t.Players, player)
+	return player.Id, nil
+}
+
+// StartTournament closes registration and creates the games of the first
+// round. Tournaments with more players than fit in the semifinals start
+// with quarterfinals.
+func StartTournament(ctx context.Context, tournamentId, organizerId string) error {
+	t, err := GetTournament(tournamentId)
+	if err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.organizer != organizerId {
+		return fmt.Errorf("Only the organizer can start the tournament")
+	}
+	if t.Stage != RegistrationStage {
+		return fmt.Errorf("Tournament has already started")
+	}
+	if len(t.Players) < minTournamentPlayers {
+		return fmt.Errorf("Tournament needs at least %d players, has %d", minTournamentPlayers, len(t.Players))
+	}
+	t.stages = []TournamentStage{SemifinalStage, FinalStage}
+	if len(t.Players) > semifinalPlayers {
+		t.stages = append([]TournamentStage{QuarterfinalStage}, t.stages...)
+	}
+	return t.startRound(ctx, t.stages[0], t.Players)
+}
+
+// startRound seats the field in games of at most three, spreading the seeds
+// across the games, and registers each player with their game.
+func (t *Tournament) startRound(ctx context.Context, stage TournamentStage, field []*TournamentPlayer) error {
+	round := &TournamentRound{Stage: stage, Matches: []*TournamentMatch{}}
+	for _, seats := range bracket(field, (len(field)+playersPerMatch-1)/playersPerMatch) {
+		jeopardyDB, err := newJeopardyDB(ctx)
+		if err != nil {
+			return err
+		}
+		game, err := NewGame(ctx, jeopardyDB, t.config, inTournament(t))
+		if err != nil {
+			jeopardyDB.Close()
+			return err
+		}
+		match := &TournamentMatch{
+			GameId:  game.Id,
+			Game:    game.Name,
+			Code:    game.Code,
+			Players: []string{},
+			Scores:  map[string]int{},
+		}
+		_ = game.do(func() error {
+			for _, p := range seats {
+				game.addTournamentPlayer(p)
+				match.Players = append(match.Players, p.Id)
+			}
+			return nil
+		})
+		games.addPrivateGame(game)
+		for _, p := range seats {
+			p.Stage = stage
+			games.addPlayer(p.Id, game)
+		}
+		round.Matches = append(round.Matches, match)
+	}
+	t.Rounds = append(t.Rounds, round)
+	t.Stage = stage
+	return nil
+}
+
+// bracket splits the players, who are in order of rank, into the given
+// number of games, snaking through the games so each gets a mix of seeds.
+func bracket(players []*TournamentPlayer, matches int) [][]*TournamentPlayer {
+	seats := make([][]*TournamentPlayer, matches)
+	for i, p := range players {
+		m := i % matches
+		if (i/matches)%2 == 1 {
+			m = matches - 1 - m
+		}
+		seats[m] = append(seats[m], p)
+	}
+	return seats
+}
+
+func (g *Game) addTournamentPlayer(tp *TournamentPlayer) {
+	imgUrl := tp.ImgUrl
+	if imgUrl == "" {
+		imgUrl = g.nextImg()
+	}
+	player := NewPlayer(tp.Name, imgUrl, tp.Email)
+	player.Id = tp.Id
+	g.Players = append(g.Players, player)
+	g.record(GameEvent{Type: EventJoined, Player: loggedPlayer(player)})
+}
+
+// reportMatch records the result of one of the tournament's games. It is
+// called on the game's event loop when the game ends, or when it is removed
+// before ending. Once every game of the round has a result, the next round
+// starts, so a protest that changes a result after that is not counted.
+func (t *Tournament) reportMatch(g *Game) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.Rounds) == 0 {
+		return
+	}
+	round := t.Rounds[len(t.Rounds)-1]
+	var match *TournamentMatch
+	for _, m := range round.Matches {
+		if m.GameId == g.Id {
+			match = m
+		}
+	}
+	if match == nil {
+		return
+	}
+
+	match.Winner, match.Tiebreak = "", false
+	for _, p := range g.Players {
+		match.Scores[p.id()] = p.score()
+		if !g.isWinner(p.score()) {
+			continue
+		}
+		if match.Winner == "" {
+			match.Winner = p.id()
+			continue
+		}
+		match.Tiebreak = true
+		if t.player(p.id()).Seed < t.player(match.Winner).Seed {
+			match.Winner = p.id()
+		}
+	}
+	match.Complete = true
+
+	for _, m := range round.Matches {
+		if !m.Complete {
+			return
+		}
+	}
+	if err := t.advance(context.Background(), round); err != nil {
+		log.Errorf("Error advancing tournament %s: %s", t.Id, err.Error())
+	}
+}
+
+// advance moves the winners of a finished round and the highest scoring
+// non-winners on to the next round, or crowns the winner of the final.
+func (t *Tournament) advance(ctx context.Context, round *TournamentRound) error {
+	winners, others := []*TournamentPlayer{}, []*TournamentPlayer{}
+	scores := map[string]int{}
+	for _, m := range round.Matches {
+		for _, id := range m.Players {
+			scores[id] = m.Scores[id]
+			if id == m.Winner {
+				winners = append(winners, t.player(id))
+			} else {
+				others = append(others, t.player(id))
+			}
+		}
+	}
+	byScore := func(a, b *TournamentPlayer) int {
+		if scores[a.Id] != scores[b.Id] {
+			return scores[b.Id] - scores[a.Id]
+		}
+		return a.Seed - b.Seed
+	}
+	slices.SortFunc(winners, byScore)
+	slices.SortFunc(others, byScore)
+
+	if round.Stage == FinalStage {
+		t.Champion = winners[0].Id
+		for _, p := range others {
+			p.Eliminated = true
+		}
+		t.Stage = CompleteStage
+		return nil
+	}
+
+	next := t.stages[slices.Index(t.stages, round.Stage)+1]
+	size := playersPerMatch
+	if next == SemifinalStage {
+		size = semifinalPlayers
+	}
+	field := winners
+	for _, p := range others {
+		if len(field) < size {
+			p.Wildcard = true
+			field = append(field, p)
+		} else {
+			p.Eliminated = true
+		}
+	}
+	return t.startRound(ctx, next, field)
+}
+
+func (t *Tournament) player(id string) *TournamentPlayer {
+	for _, p := range t.Players {
+		if p.Id == id {
+			return p
+		}
+	}
+	return &TournamentPlayer{Id: id}
+}
+
+// standings ranks the champion first, then players by how far they got,
+// their score in the last game they played and their seed.
+func (t *Tournament) standings() []TournamentStanding {
+	stageRank := func(p *TournamentPlayer) int {
+		if p.Id == t.Champion {
+			return len(t.stages) + 1
+		}
+		return slices.Index(t.stages, p.Stage) + 1
+	}
+	scores := map[string]int{}
+	for _, round := range t.Rounds {
+		for _, m := range round.Matches {
+			for id, score := range m.Scores {
+				scores[id] = score
+			}
+		}
+	}
+	players := slices.Clone(t.Players)
+	slices.SortFunc(players, func(a, b *TournamentPlayer) int {
+		if stageRank(a) != stageRank(b) {
+			return stageRank(b) - stageRank(a)
+		}
+		if scores[a.Id] != scores[b.Id] {
+			return scores[b.Id] - scores[a.Id]
+		}
+		return a.Seed - b.Seed
+	})
+	standings := []TournamentStanding{}
+	for i, p := range players {
+		standings = append(standings, TournamentStanding{
+			Rank:       i + 1,
+			PlayerId:   p.Id,
+			Name:       p.Name,
+			Seed:       p.Seed,
+			Stage:      p.Stage,
+			Score:      scores[p.Id],
+			Wildcard:   p.Wildcard,
+			Eliminated: p.Eliminated,
+		})
+	}
+	return standings
+}
+
+func (t *Tournament) progress() TournamentProgress {
+	progress := TournamentProgress{Stage: t.Stage, Players: len(t.Players)}
+	for _, p := range t.Players {
+		if !p.Eliminated {
+			progress.Remaining++
+		}
+	}
+	if len(t.Rounds) > 0 {
+		for _, m := range t.Rounds[len(t.Rounds)-1].Matches {
+			progress.Matches++
+			if m.Complete {
+				progress.CompleteMatches++
+			}
+		}
+	}
+	return progress
+}
+
+// Snapshot returns the tournament as JSON along with its standings and
+// progress.
+func (t *Tournament) Snapshot() (json.RawMessage, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return json.Marshal(struct {
+		*Tournament
+		Standings []TournamentStanding `json:"standings"`
+		Progress  TournamentProgress   `json:"progress"`
+	}{
+		Tournament: t,
+		Standings:  t.standings(),
+		Progress:   t.progress(),
+	})
+}
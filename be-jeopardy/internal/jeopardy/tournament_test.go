@@ -0,0 +1,224 @@
+package jeopardy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTournament(t *testing.T) {
+	ctx := context.Background()
+	memoryDB := newTestDB(t)
+	prevDB := newJeopardyDB
+	newJeopardyDB = func(ctx context.Context) (jeopardyDB, error) {
+		return memoryDB, nil
+	}
+	defer func() { newJeopardyDB = prevDB }()
+
+	// finishRound ends every game of the tournament's current round with
+	// each player scoring more the better they are seeded, except for the
+	// player given, who scores the most.
+	finishRound := func(t *testing.T, tournament *Tournament, topSeed int) []*Game {
+		t.Helper()
+		tournament.mu.Lock()
+		round := tournament.Rounds[len(tournament.Rounds)-1]
+		tournament.mu.Unlock()
+		gs := []*Game{}
+		for _, m := range round.Matches {
+			g, err := GetPlayerGame(m.Players[0])
+			assert.NoError(t, err)
+			gs = append(gs, g)
+		}
+		for _, g := range gs {
+			assert.NoError(t, g.do(func() error {
+				for _, p := range g.Players {
+					seed := tournament.player(p.id()).Seed
+					score := 1000 * (30 - seed)
+					if seed == topSeed {
+						score = 100000
+					}
+					p.addToScore(score)
+				}
+				g.setState(PostGame, &Player{})
+				return nil
+			}))
+		}
+		return gs
+	}
+
+	t.Run("test players advance from quarterfinals to a champion", func(t *testing.T) {
+		tournament, organizer, err := CreateTournament(TournamentRequest{
+			Name:   "Tournament of Champions",
+			Config: GameRequest{PickConfig: 30, BuzzConfig: 30, AnswerConfig: 30, WagerConfig: 30},
+		})
+		assert.NoError(t, err)
+
+		ids := []string{}
+		for i := 1; i <= 10; i++ {
+			id, err := RegisterTournamentPlayer(tournament.Id, GameRequest{PlayerName: fmt.Sprintf("player%d", i)})
+			assert.NoError(t, err)
+			ids = append(ids, id)
+		}
+		_, err = RegisterTournamentPlayer(tournament.Id, GameRequest{PlayerName: "player1"})
+		assert.Error(t, err)
+
+		assert.Error(t, StartTournament(ctx, tournament.Id, ids[0]))
+		assert.NoError(t, StartTournament(ctx, tournament.Id, organizer))
+		assert.Error(t, StartTournament(ctx, tournament.Id, organizer))
+		_, err = RegisterTournamentPlayer(tournament.Id, GameRequest{PlayerName: "late"})
+		assert.Error(t, err)
+
+		assert.Equal(t, QuarterfinalStage, tournament.Stage)
+		assert.Len(t, tournament.Rounds[0].Matches, 4)
+		quarterfinal, err := GetPlayerGame(ids[0])
+		assert.NoError(t, err)
+		_, _, err = JoinGameByCode(GameRequest{PlayerName: "crasher"}, quarterfinal.Code)
+		assert.Error(t, err)
+		assert.Error(t, AddBot(ids[0]))
+		assert.Error(t, PlayAgain(ids[0]))
+
+		quarterfinals := finishRound(t, tournament, 0)
+		assert.Equal(t, SemifinalStage, tournament.Stage)
+		assert.Len(t, tournament.Rounds[1].Matches, 3)
+		for i, p := range tournament.Players {
+			assert.Equal(t, i >= 4 && i < 9, p.Wildcard, p.Name)
+			assert.Equal(t, i == 9, p.Eliminated, p.Name)
+		}
+		semifinal, err := GetPlayerGame(ids[0])
+		assert.NoError(t, err)
+		assert.NotEqual(t, quarterfinal.Name, semifinal.Name)
+
+		// removing a finished game leaves its players in their next game
+		assert.NoError(t, quarterfinal.do(func() error {
+			removeGame(quarterfinal)
+			return nil
+		}))
+		_, err = GetPlayerGame(ids[0])
+		assert.NoError(t, err)
+
+		semifinals := finishRound(t, tournament, 0)
+		assert.Equal(t, FinalStage, tournament.Stage)
+		final := tournament.Rounds[2].Matches[0]
+		assert.ElementsMatch(t, ids[:3], final.Players)
+
+		finals := finishRound(t, tournament, 3)
+		assert.Equal(t, CompleteStage, tournament.Stage)
+		assert.Equal(t, ids[2], tournament.Champion)
+
+		snapshot, err := tournament.Snapshot()
+		assert.NoError(t, err)
+		var resp struct {
+			Standings []TournamentStanding `json:"standings"`
+			Progress  TournamentProgress   `json:"progress"`
+		}
+		assert.NoError(t, json.Unmarshal(snapshot, &resp))
+		assert.Equal(t, ids[2], resp.Standings[0].PlayerId)
+		assert.Equal(t, ids[0], resp.Standings[1].PlayerId)
+		assert.Equal(t, ids[9], resp.Standings[9].PlayerId)
+		assert.Equal(t, TournamentProgress{Stage: CompleteStage, Matches: 1, CompleteMatches: 1, Players: 10, Remaining: 1}, resp.Progress)
+
+		for _, g := range append(append(quarterfinals, semifinals...), finals...) {
+			g.stop()
+		}
+	})
+
+	t.Run("test small tournaments start with semifinals and abandoned games count", func(t *testing.T) {
+		tournament, organizer, err := CreateTournament(TournamentRequest{
+			Name:   "Invitational",
+			Config: GameRequest{PickConfig: 30, BuzzConfig: 30, AnswerConfig: 30, WagerConfig: 30},
+		})
+		assert.NoError(t, err)
+		ids := []string{}
+		for i := 1; i <= 4; i++ {
+			id, err := RegisterTournamentPlayer(tournament.Id, GameRequest{PlayerName: fmt.Sprintf("player%d", i)})
+			assert.NoError(t, err)
+			ids = append(ids, id)
+		}
+		assert.NoError(t, StartTournament(ctx, tournament.Id, organizer))
+		assert.Equal(t, SemifinalStage, tournament.Stage)
+		assert.Len(t, tournament.Rounds[0].Matches, 2)
+
+		semifinal, err := GetPlayerGame(ids[0])
+		assert.NoError(t, err)
+		assert.NoError(t, semifinal.do(func() error {
+			semifinal.Players[0].addToScore(2000)
+			semifinal.Players[1].addToScore(1000)
+			semifinal.setState(PostGame, &Player{})
+			return nil
+		}))
+		assert.Equal(t, SemifinalStage, tournament.Stage)
+
+		abandoned, err := GetPlayerGame(ids[1])
+		assert.NoError(t, err)
+		assert.NoError(t, abandoned.do(func() error {
+			abandoned.Players[0].addToScore(400)
+			removeGame(abandoned)
+			return nil
+		}))
+
+		assert.Equal(t, FinalStage, tournament.Stage)
+		final := tournament.Rounds[1].Matches[0]
+		assert.ElementsMatch(t, []string{ids[0], ids[1], ids[3]}, final.Players)
+		assert.True(t, tournament.player(ids[3]).Wildcard)
+		assert.True(t, tournament.player(ids[2]).Eliminated)
+
+		semifinal.stop()
+		for _, g := range finishRound(t, tournament, 0) {
+			g.stop()
+		}
+		assert.Equal(t, ids[0], tournament.Champion)
+	})
+
+	t.Run("test a tied match goes to the better seed", func(t *testing.T) {
+		tournament, organizer, err := CreateTournament(TournamentRequest{
+			Name:   "Tiebreaker",
+			Config: GameRequest{PickConfig: 30, BuzzConfig: 30, AnswerConfig: 30, WagerConfig: 30},
+		})
+		assert.NoError(t, err)
+		ids := []string{}
+		for i := 1; i <= 4; i++ {
+			id, err := RegisterTournamentPlayer(tournament.Id, GameRequest{PlayerName: fmt.Sprintf("player%d", i)})
+			assert.NoError(t, err)
+			ids = append(ids, id)
+		}
+		assert.NoError(t, StartTournament(ctx, tournament.Id, organizer))
+
+		semifinal, err := GetPlayerGame(ids[1])
+		assert.NoError(t, err)
+		assert.NoError(t, semifinal.do(func() error {
+			// seat the worse seed first so the order can't decide the tie
+			slices.SortFunc(semifinal.Players, func(a, b GamePlayer) int {
+				return tournament.player(b.id()).Seed - tournament.player(a.id()).Seed
+			})
+			for _, p := range semifinal.Players {
+				p.addToScore(1000)
+			}
+			semifinal.setState(PostGame, &Player{})
+			return nil
+		}))
+		match := tournament.Rounds[0].Matches[1]
+		assert.ElementsMatch(t, []string{ids[1], ids[2]}, match.Players)
+		assert.Equal(t, ids[1], match.Winner)
+		assert.True(t, match.Tiebreak)
+
+		other, err := GetPlayerGame(ids[0])
+		assert.NoError(t, err)
+		assert.NoError(t, other.do(func() error {
+			other.Players[0].addToScore(2000)
+			other.setState(PostGame, &Player{})
+			return nil
+		}))
+		assert.False(t, tournament.Rounds[0].Matches[0].Tiebreak)
+		assert.Equal(t, FinalStage, tournament.Stage)
+
+		semifinal.stop()
+		other.stop()
+		for _, g := range finishRound(t, tournament, 0) {
+			g.stop()
+		}
+	})
+}
@@ -0,0 +1,247 @@
+package jeopardy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type (
+	// WagerSituation is what a wager is decided on: the player's score, the
+	// scores of everyone else and, for a Daily Double, how much the round
+	// allows and how much is left to play for.
+	WagerSituation struct {
+		Final     bool
+		Score     int
+		Opponents []int
+		// RoundMax is the top value on the board, the most a player with
+		// less than it can wager on a Daily Double.
+		RoundMax int
+		// BoardLeft is the value of the clues still to be played after the
+		// Daily Double, the second round's included if it is still to come.
+		BoardLeft int
+	}
+
+	WagerAdvice struct {
+		Wager    int    `json:"wager"`
+		Min      int    `json:"min"`
+		Max      int    `json:"max"`
+		Strategy string `json:"strategy"`
+		Reason   string `json:"reason"`
+	}
+)
+
+const (
+	// LockWager can't lose the game whether the response is right or wrong.
+	LockWager = "lock"
+	// LockTieWager can at worst tie for the win.
+	LockTieWager = "lock-tie"
+	// ShutOutWager leaves nobody able to catch up if the response is right.
+	ShutOutWager = "shut-out"
+	// CoverWager finishes ahead of second place doubling their score if the
+	// response is right.
+	CoverWager = "cover"
+	// TieWager catches the leader for a shared win if the response is right.
+	TieWager = "tie"
+	// StayAheadWager finishes ahead of a leader who covers and is wrong even
+	// if the response is wrong too.
+	StayAheadWager = "stay-ahead"
+	// KeepLeadWager keeps the lead if the response is wrong.
+	KeepLeadWager = "keep-lead"
+	// AllInWager is the most the player can wager, when nothing less can
+	// win.
+	AllInWager = "all-in"
+	// LockedOutWager keeps the score of a player who can't win.
+	LockedOutWager = "locked-out"
+	// NoWager is for players who sit out Final Jeopardy.
+	NoWager = "none"
+)
+
+// AdviseWager is the wager that gives the player the best chance of winning.
+func AdviseWager(s WagerSituation) WagerAdvice {
+	if s.Final {
+		return adviseFinalWager(s.Score, s.Opponents)
+	}
+	return adviseDailyDoubleWager(s)
+}
+
+// adviseFinalWager assumes everyone else with a positive score wagers it all
+// and is right, and that the leader covers second place.
+func adviseFinalWager(score int, opponents []int) WagerAdvice {
+	if score <= 0 {
+		return WagerAdvice{Strategy: NoWager, Reason: "Only players with a positive score play Final Jeopardy"}
+	}
+	advice := WagerAdvice{Min: 0, Max: score}
+
+	// reach is the most anyone else can finish with, and leader is the
+	// most anyone else has now
+	reach, leader, leaderIdx := 0, 0, -1
+	for i, opponent := range opponents {
+		reach = max(reach, finalReach(opponent))
+		if leaderIdx == -1 || opponent > leader {
+			leader, leaderIdx = opponent, i
+		}
+	}
+
+	switch {
+	case score > reach:
+		advice.Wager = score - reach - 1
+		advice.Strategy = LockWager
+		advice.Reason = fmt.Sprintf("Nobody can finish with more than %d, so any wager up to %d wins", reach, advice.Wager)
+	case score == reach:
+		advice.Strategy = LockTieWager
+		advice.Reason = fmt.Sprintf("Nobody can finish with more than %d, so wagering nothing at worst ties", reach)
+	case score == leader:
+		advice.Wager = score
+		advice.Strategy = TieWager
+		advice.Reason = "You are tied for the lead, so wager everything to at least share the win if you're right"
+	case score > leader:
+		advice.Wager = reach - score + 1
+		advice.Strategy = CoverWager
+		advice.Reason = fmt.Sprintf("Wager %d to finish above %d, the most anyone else can finish with, if you're right", advice.Wager, reach)
+	default:
+		leaderOpponents := []int{score}
+		for i, opponent := range opponents {
+			if i != leaderIdx {
+				leaderOpponents = append(leaderOpponents, opponent)
+			}
+		}
+		leaderAdvice := adviseFinalWager(leader, leaderOpponents)
+		leaderMiss := leader - leaderAdvice.Wager
+		switch {
+		case leaderAdvice.Strategy == LockTieWager && 2*score == leader:
+			advice.Wager = score
+			advice.Strategy = TieWager
+			advice.Reason = fmt.Sprintf("Wager everything to tie %d if you're right and the leader wagers nothing", leader)
+		case leaderAdvice.Strategy == LockWager || leaderAdvice.Strategy == LockTieWager:
+			advice.Strategy = LockedOutWager
+			advice.Reason = fmt.Sprintf("You can't catch %d, so wager nothing to keep your score", leader)
+		case score > leaderMiss:
+			advice.Wager = score - leaderMiss - 1
+			advice.Strategy = StayAheadWager
+			advice.Reason = fmt.Sprintf("If the leader wagers %d to cover and is wrong they finish with %d, so wager at most %d to stay ahead of them", leaderAdvice.Wager, leaderMiss, advice.Wager)
+		default:
+			advice.Wager = score
+			advice.Strategy = AllInWager
+			advice.Reason = fmt.Sprintf("You need the leader to be wrong and to be right yourself to pass %d", leaderMiss)
+		}
+	}
+	return advice
+}
+
+// finalReach is the most a player can finish Final Jeopardy with.
+func finalReach(score int) int {
+	if score > 0 {
+		return 2 * score
+	}
+	return score
+}
+
+// adviseDailyDoubleWager plays for a lock going into Final Jeopardy, where
+// second place finishes the round with everything left on the board.
+func adviseDailyDoubleWager(s WagerSituation) WagerAdvice {
+	advice := WagerAdvice{Min: 5, Max: max(s.Score, s.RoundMax, 5)}
+	clamp := func(wager int) int {
+		return min(max(wager, advice.Min), advice.Max)
+	}
+	leader := 0
+	for _, opponent := range s.Opponents {
+		leader = max(leader, opponent)
+	}
+	// a lock going into Final Jeopardy needs more than twice what second
+	// place can finish the round with
+	lock := 2 * (leader + s.BoardLeft)
+
+	switch {
+	case s.Score-advice.Min > lock:
+		advice.Wager = clamp(s.Score - lock - 1)
+		advice.Strategy = LockWager
+		advice.Reason = fmt.Sprintf("Wager at most %d to stay above %d, twice what anyone can reach, if you're wrong", advice.Wager, lock)
+	case s.Score+advice.Max > lock:
+		advice.Wager = clamp(lock - s.Score + 1)
+		advice.Strategy = ShutOutWager
+		advice.Reason = fmt.Sprintf("Wager %d to finish above %d, twice what anyone can reach, if you're right", advice.Wager, lock)
+	case s.Score > leader:
+		advice.Wager = clamp(s.Score - leader - 1)
+		advice.Strategy = KeepLeadWager
+		advice.Reason = fmt.Sprintf("Wager %d to keep the lead over %d if you're wrong", advice.Wager, leader)
+	default:
+		advice.Wager = advice.Max
+		advice.Strategy = AllInWager
+		advice.Reason = fmt.Sprintf("You trail %d, so wager everything to catch up", leader)
+	}
+	return advice
+}
+
+// wagerSituation is the situation of a player who can wager in the game.
+func (g *Game) wagerSituation(player GamePlayer) WagerSituation {
+	s := WagerSituation{
+		Final:     g.Round == FinalRound,
+		Score:     player.score(),
+		Opponents: []int{},
+		RoundMax:  g.roundMax(),
+	}
+	for _, p := range g.Players {
+		if p.id() != player.id() {
+			s.Opponents = append(s.Opponents, p.score())
+		}
+	}
+	rounds := [][]Category{g.curRound()}
+	if g.Round == FirstRound && g.FullGame {
+		rounds = append(rounds, g.SecondRound)
+	}
+	for _, round := range rounds {
+		for _, category := range round {
+			for _, q := range category.Questions {
+				if q.CanChoose && q != g.CurQuestion {
+					s.BoardLeft += q.Value
+				}
+			}
+		}
+	}
+	return s
+}
+
+// GetWagerAdvice gives the advice bots wager by for a situation described
+// by query parameters, so players can practice wagering.
+func GetWagerAdvice(round, score, opponents, roundMax, boardLeft string) (WagerAdvice, error) {
+	s := WagerSituation{Opponents: []int{}}
+	switch round {
+	case "final":
+		s.Final = true
+	case "first", "":
+		s.RoundMax = 1000
+	case "second":
+		s.RoundMax = 2000
+	default:
+		return WagerAdvice{}, fmt.Errorf("Round must be first, second or final, got: %s", round)
+	}
+	var err error
+	if s.Score, err = strconv.Atoi(score); err != nil {
+		return WagerAdvice{}, fmt.Errorf("Score must be a number, got: %s", score)
+	}
+	for _, opponent := range strings.Split(opponents, ",") {
+		if opponent == "" {
+			continue
+		}
+		o, err := strconv.Atoi(strings.TrimSpace(opponent))
+		if err != nil {
+			return WagerAdvice{}, fmt.Errorf("Opponent scores must be numbers, got: %s", opponent)
+		}
+		s.Opponents = append(s.Opponents, o)
+	}
+	if len(s.Opponents) == 0 || len(s.Opponents) > maxPlayers-1 {
+		return WagerAdvice{}, fmt.Errorf("Opponents must have between 1 and %d scores, got: %d", maxPlayers-1, len(s.Opponents))
+	}
+	if roundMax != "" {
+		if s.RoundMax, err = strconv.Atoi(roundMax); err != nil || s.RoundMax < 1 {
+			return WagerAdvice{}, fmt.Errorf("Round max must be a positive number, got: %s", roundMax)
+		}
+	}
+	if boardLeft != "" {
+		if s.BoardLeft, err = strconv.Atoi(boardLeft); err != nil || s.BoardLeft < 0 {
+			return WagerAdvice{}, fmt.Errorf("Board left must be a number of at least 0, got: %s", boardLeft)
+		}
+	}
+	return AdviseWager(s), nil
+}
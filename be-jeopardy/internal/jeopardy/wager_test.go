@@ -0,0 +1,96 @@
+package jeopardy
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdviseWager(t *testing.T) {
+	t.Run("test final jeopardy wagers", func(t *testing.T) {
+		for _, test := range []struct {
+			score     int
+			opponents []int
+			wager     int
+			strategy  string
+		}{
+			{10000, []int{4000, 3000}, 1999, LockWager},
+			{8000, []int{4000}, 0, LockTieWager},
+			{1000, []int{-500, 0}, 999, LockWager},
+			{10000, []int{6000, 2000}, 2001, CoverWager},
+			{6000, []int{6000, 1000}, 6000, TieWager},
+			{5000, []int{10000}, 5000, TieWager},
+			{8000, []int{10000}, 4000, StayAheadWager},
+			{6000, []int{10000, 2000}, 6000, AllInWager},
+			{4000, []int{10000, 3000}, 0, LockedOutWager},
+			{-200, []int{1000}, 0, NoWager},
+		} {
+			advice := AdviseWager(WagerSituation{Final: true, Score: test.score, Opponents: test.opponents})
+			msg := fmt.Sprintf("%d against %v", test.score, test.opponents)
+			assert.Equal(t, test.wager, advice.Wager, msg)
+			assert.Equal(t, test.strategy, advice.Strategy, msg)
+			assert.GreaterOrEqual(t, advice.Wager, advice.Min, msg)
+			assert.LessOrEqual(t, advice.Wager, advice.Max, msg)
+		}
+	})
+
+	t.Run("test daily double wagers", func(t *testing.T) {
+		for _, test := range []struct {
+			score     int
+			opponents []int
+			roundMax  int
+			boardLeft int
+			wager     int
+			strategy  string
+		}{
+			{20000, []int{3000}, 2000, 2000, 9999, LockWager},
+			{9000, []int{3000, 1000}, 2000, 1000, 999, LockWager},
+			{7000, []int{3000}, 2000, 800, 601, ShutOutWager},
+			{5000, []int{3000}, 2000, 10000, 1999, KeepLeadWager},
+			{3000, []int{2998}, 2000, 20000, 5, KeepLeadWager},
+			{400, []int{3000}, 1000, 20000, 1000, AllInWager},
+			{-600, []int{0}, 1000, 20000, 1000, AllInWager},
+		} {
+			advice := AdviseWager(WagerSituation{Score: test.score, Opponents: test.opponents, RoundMax: test.roundMax, BoardLeft: test.boardLeft})
+			msg := fmt.Sprintf("%d against %v with %d left", test.score, test.opponents, test.boardLeft)
+			assert.Equal(t, test.wager, advice.Wager, msg)
+			assert.Equal(t, test.strategy, advice.Strategy, msg)
+			assert.GreaterOrEqual(t, advice.Wager, advice.Min, msg)
+			assert.LessOrEqual(t, advice.Wager, advice.Max, msg)
+		}
+	})
+}
+
+func TestGetWagerAdvice(t *testing.T) {
+	t.Run("test advice for query parameters", func(t *testing.T) {
+		advice, err := GetWagerAdvice("final", "10000", "6000, 2000", "", "")
+		assert.NoError(t, err)
+		assert.Equal(t, WagerAdvice{Wager: 2001, Min: 0, Max: 10000, Strategy: CoverWager, Reason: advice.Reason}, advice)
+
+		advice, err = GetWagerAdvice("second", "7000", "3000", "", "800")
+		assert.NoError(t, err)
+		assert.Equal(t, WagerAdvice{Wager: 601, Min: 5, Max: 7000, Strategy: ShutOutWager, Reason: advice.Reason}, advice)
+
+		advice, err = GetWagerAdvice("", "400", "3000", "", "20000")
+		assert.NoError(t, err)
+		assert.Equal(t, 1000, advice.Wager)
+	})
+
+	t.Run("test invalid query parameters", func(t *testing.T) {
+		for _, test := range []struct {
+			round, score, opponents, roundMax, boardLeft string
+			msg                                          string
+		}{
+			{"third", "1000", "500", "", "", "Round must be first, second or final, got: third"},
+			{"final", "lots", "500", "", "", "Score must be a number, got: lots"},
+			{"final", "1000", "500,x", "", "", "Opponent scores must be numbers, got: x"},
+			{"final", "1000", "", "", "", "Opponents must have between 1 and 5 scores, got: 0"},
+			{"first", "1000", "500", "0", "", "Round max must be a positive number, got: 0"},
+			{"first", "1000", "500", "", "-1", "Board left must be a number of at least 0, got: -1"},
+		} {
+			_, err := GetWagerAdvice(test.round, test.score, test.opponents, test.roundMax, test.boardLeft)
+			assert.EqualError(t, err, test.msg)
+		}
+	})
+}
@@ -0,0 +1,393 @@
+package loadtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/jeopardy"
+)
+
+type (
+	// client is a synthetic player with the game, chat and reaction sockets
+	// the frontend opens.
+	client struct {
+		name     string
+		token    string
+		gameName string
+		cfg      Config
+		stats    *stats
+		rng      *rand.Rand
+
+		play      *websocket.Conn
+		chat      *websocket.Conn
+		reactions *websocket.Conn
+
+		mu sync.Mutex
+		// sentAt is when the client's last game message was sent, until the
+		// server's next update arrives.
+		sentAt     time.Time
+		lastAction string
+		// chats and reacts are the messages sent on the chat and reaction
+		// sockets that haven't come back yet.
+		chats    map[string]time.Time
+		reacts   map[string]time.Time
+		sends    int
+		finished bool
+		closed   bool
+		done     chan struct{}
+	}
+
+	gameResponse struct {
+		Code      int         `json:"code"`
+		Token     string      `json:"token"`
+		Message   string      `json:"message"`
+		Game      *gameState  `json:"game"`
+		CurPlayer playerState `json:"curPlayer"`
+	}
+
+	// gameState is the part of a game update the clients play from.
+	gameState struct {
+		Name         string              `json:"name"`
+		State        jeopardy.GameState  `json:"state"`
+		Round        jeopardy.RoundState `json:"round"`
+		FirstRound   []category          `json:"firstRound"`
+		SecondRound  []category          `json:"secondRound"`
+		CurQuestion  *question           `json:"curQuestion"`
+		GuessedWrong []string            `json:"guessedWrong"`
+		Passed       []string            `json:"passed"`
+	}
+
+	category struct {
+		Questions []question `json:"questions"`
+	}
+
+	question struct {
+		Clue      string            `json:"question"`
+		CanChoose bool              `json:"canChoose"`
+		Answers   []json.RawMessage `json:"answers"`
+	}
+
+	playerState struct {
+		Score      int  `json:"score"`
+		CanPick    bool `json:"canPick"`
+		CanBuzz    bool `json:"canBuzz"`
+		CanAnswer  bool `json:"canAnswer"`
+		CanWager   bool `json:"canWager"`
+		CanDispute bool `json:"canDispute"`
+	}
+)
+
+func newClient(i int, cfg Config, stats *stats) *client {
+	return &client{
+		name:   fmt.Sprintf("load%d", i),
+		cfg:    cfg,
+		stats:  stats,
+		rng:    rand.New(rand.NewPCG(uint64(i), 0)),
+		chats:  map[string]time.Time{},
+		reacts: map[string]time.Time{},
+		done:   make(chan struct{}),
+	}
+}
+
+// join puts the client in a public game.
+func (c *client) join() error {
+	body, err := json.Marshal(jeopardy.GameRequest{
+		PlayerName:   c.name,
+		FullGame:     c.cfg.FullGame,
+		Penalty:      true,
+		PickConfig:   30,
+		BuzzConfig:   30,
+		AnswerConfig: 30,
+		WagerConfig:  30,
+	})
+	if err != nil {
+		return err
+	}
+	var resp gameResponse
+	if err := c.request("join", http.MethodPut, "/jeopardy/games", body, &resp); err != nil {
+		return err
+	}
+	if resp.Game == nil || resp.Token == "" {
+		return fmt.Errorf("join response has no game or token")
+	}
+	c.token, c.gameName = resp.Token, resp.Game.Name
+	return nil
+}
+
+// start starts the client's game.
+func (c *client) start() error {
+	return c.request("start", http.MethodPut, "/jeopardy/games/start", nil, nil)
+}
+
+func (c *client) request(name, method, path string, body []byte, v any) error {
+	req, err := http.NewRequest(method, c.cfg.URL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Access-Token", c.token)
+	begin := time.Now()
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	c.stats.observe(name, time.Since(begin))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s %s returned %d: %s", method, path, resp.StatusCode, data)
+	}
+	if v == nil {
+		return nil
+	}
+	return json.Unmarshal(data, v)
+}
+
+// connect opens the game, chat and reaction sockets and starts reading
+// from them.
+func (c *client) connect() error {
+	wsURL := "ws" + strings.TrimPrefix(c.cfg.URL, "http")
+	for _, conn := range []struct {
+		path string
+		ws   **websocket.Conn
+		read func()
+	}{
+		{"/jeopardy/play/" + c.gameName, &c.play, c.readGame},
+		{"/jeopardy/chat", &c.chat, c.readChat},
+		{"/jeopardy/reactions", &c.reactions, c.readReactions},
+	} {
+		header := http.Header{}
+		if c.cfg.Origin != "" {
+			header.Set("Origin", c.cfg.Origin)
+		}
+		begin := time.Now()
+		ws, _, err := websocket.DefaultDialer.Dial(wsURL+conn.path, header)
+		if err != nil {
+			return fmt.Errorf("error connecting to %s: %w", conn.path, err)
+		}
+		if err := ws.WriteJSON(map[string]string{"token": c.token}); err != nil {
+			ws.Close()
+			return err
+		}
+		c.stats.observe("connect", time.Since(begin))
+		*conn.ws = ws
+		go conn.read()
+	}
+	return nil
+}
+
+// close reports what never came back and closes the client's sockets.
+func (c *client) close() {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return
+	}
+	c.closed = true
+	dropped := len(c.chats) + len(c.reacts)
+	if !c.sentAt.IsZero() {
+		dropped++
+	}
+	c.mu.Unlock()
+	c.stats.add(&c.stats.dropped, dropped)
+	for _, ws := range []*websocket.Conn{c.play, c.chat, c.reactions} {
+		if ws == nil {
+			continue
+		}
+		_ = ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, ""), time.Now().Add(time.Second))
+		ws.Close()
+	}
+}
+
+func (c *client) readGame() {
+	for {
+		_, data, err := c.play.ReadMessage()
+		if err != nil {
+			c.finish(err)
+			return
+		}
+		var resp gameResponse
+		if err := json.Unmarshal(data, &resp); err != nil {
+			c.stats.error(fmt.Errorf("error parsing game update: %w", err))
+			continue
+		}
+		if resp.Game == nil {
+			// pings
+			continue
+		}
+		c.stats.add(&c.stats.received, 1)
+		c.mu.Lock()
+		if !c.sentAt.IsZero() {
+			c.stats.observe("game", time.Since(c.sentAt))
+			c.sentAt = time.Time{}
+		}
+		c.mu.Unlock()
+		if resp.Game.State == jeopardy.PostGame {
+			c.finish(nil)
+			return
+		}
+		if msg, ok := c.respond(resp.Game, resp.CurPlayer); ok {
+			c.send(msg)
+		}
+	}
+}
+
+// respond decides the client's move, once for each turn it can act on.
+func (c *client) respond(g *gameState, me playerState) (jeopardy.Message, bool) {
+	msg := jeopardy.Message{State: g.State}
+	clue, answers := "", 0
+	if g.CurQuestion != nil {
+		clue, answers = g.CurQuestion.Clue, len(g.CurQuestion.Answers)
+	}
+	action := fmt.Sprint(g.State, g.Round, clue, answers, len(g.GuessedWrong), len(g.Passed))
+	c.mu.Lock()
+	if action == c.lastAction {
+		c.mu.Unlock()
+		return msg, false
+	}
+	c.mu.Unlock()
+
+	switch {
+	case g.State == jeopardy.RecvPick && me.CanPick:
+		round := g.FirstRound
+		if g.Round == jeopardy.SecondRound {
+			round = g.SecondRound
+		}
+		available := [][2]int{}
+		for catIdx, category := range round {
+			for valIdx, q := range category.Questions {
+				if q.CanChoose {
+					available = append(available, [2]int{catIdx, valIdx})
+				}
+			}
+		}
+		if len(available) == 0 {
+			return msg, false
+		}
+		pick := available[c.rng.IntN(len(available))]
+		msg.CatIdx, msg.ValIdx = pick[0], pick[1]
+		c.chatAndReact()
+	case g.State == jeopardy.RecvBuzz && me.CanBuzz:
+		msg.IsPass = c.rng.IntN(2) == 0
+	case g.State == jeopardy.RecvAns && me.CanAnswer:
+		msg.Answer = "what is something"
+	case g.State == jeopardy.RecvWager && me.CanWager:
+		msg.Wager = 5
+		if g.Round == jeopardy.FinalRound {
+			msg.Wager = 0
+		}
+	case g.State == jeopardy.RecvDispute && me.CanDispute:
+		msg.Dispute = false
+	default:
+		return msg, false
+	}
+	c.mu.Lock()
+	c.lastAction = action
+	c.mu.Unlock()
+	return msg, true
+}
+
+func (c *client) send(msg jeopardy.Message) {
+	c.mu.Lock()
+	c.sentAt = time.Now()
+	c.mu.Unlock()
+	if err := c.play.WriteJSON(msg); err != nil {
+		c.stats.error(fmt.Errorf("error sending game message: %w", err))
+		return
+	}
+	c.stats.add(&c.stats.sent, 1)
+}
+
+// chatAndReact sends a chat message and a reaction, each of which the
+// server sends back to everyone in the game.
+func (c *client) chatAndReact() {
+	c.mu.Lock()
+	c.sends++
+	id := fmt.Sprintf("%s-%d", c.name, c.sends)
+	c.chats[id] = time.Now()
+	c.reacts[id] = time.Now()
+	c.mu.Unlock()
+	if err := c.chat.WriteJSON(jeopardy.ChatMessage{Message: id}); err != nil {
+		c.stats.error(fmt.Errorf("error sending chat message: %w", err))
+	} else {
+		c.stats.add(&c.stats.sent, 1)
+	}
+	if err := c.reactions.WriteJSON(jeopardy.Reaction{Reaction: id}); err != nil {
+		c.stats.error(fmt.Errorf("error sending reaction: %w", err))
+	} else {
+		c.stats.add(&c.stats.sent, 1)
+	}
+}
+
+func (c *client) readChat() {
+	for {
+		var msg jeopardy.ChatMessage
+		if err := c.chat.ReadJSON(&msg); err != nil {
+			return
+		}
+		c.received("chat", c.chats, msg.Message)
+	}
+}
+
+func (c *client) readReactions() {
+	for {
+		var msg jeopardy.Reaction
+		if err := c.reactions.ReadJSON(&msg); err != nil {
+			return
+		}
+		c.received("reaction", c.reacts, msg.Reaction)
+	}
+}
+
+// received times a chat message or reaction the client sent coming back.
+func (c *client) received(name string, pending map[string]time.Time, id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	sentAt, ok := pending[id]
+	if !ok {
+		return
+	}
+	delete(pending, id)
+	c.stats.add(&c.stats.received, 1)
+	c.stats.observe(name, time.Since(sentAt))
+}
+
+// abandon marks a client whose game hasn't ended as done, so closing its
+// socket isn't reported as an error, and reports whether it did.
+func (c *client) abandon() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.finished {
+		return false
+	}
+	c.finished = true
+	close(c.done)
+	return true
+}
+
+// finish marks the client's game as over, or its socket as closed before
+// the game ended.
+func (c *client) finish(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.finished {
+		return
+	}
+	c.finished = true
+	if err != nil {
+		c.stats.error(fmt.Errorf("game socket closed before the game ended: %w", err))
+	} else {
+		c.stats.add(&c.stats.finished, 1)
+	}
+	close(c.done)
+}
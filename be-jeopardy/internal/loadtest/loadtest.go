@@ -0,0 +1,277 @@
+// Package loadtest plays games on a running server with synthetic clients
+// that use the same HTTP requests, sockets and messages as the frontend.
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+)
+
+type (
+	Config struct {
+		// URL is the server's base URL, such as http://127.0.0.1:8080.
+		URL string
+		// Clients is how many players join public games, which fill up to
+		// six players each.
+		Clients  int
+		FullGame bool
+		// Timeout is how long the clients get to finish their games.
+		Timeout time.Duration
+		// Origin is sent when opening sockets, which the server checks
+		// against ALLOW_ORIGIN.
+		Origin string
+	}
+
+	Report struct {
+		Clients  int           `json:"clients"`
+		Games    int           `json:"games"`
+		Finished int           `json:"finished"`
+		Duration time.Duration `json:"duration"`
+
+		Latencies []Latency `json:"latencies"`
+		// Sent and Received count game messages, chat messages and
+		// reactions. Dropped are the chat messages and reactions that never
+		// came back, the game messages the server never answered and the
+		// clients whose games didn't end before the timeout.
+		Sent     int `json:"sent"`
+		Received int `json:"received"`
+		Dropped  int `json:"dropped"`
+		Errors   int `json:"errors"`
+		// FirstErrors are the first few errors, to see what went wrong.
+		FirstErrors []string `json:"firstErrors"`
+
+		// Goroutines and HeapBytes are measured in this process, which runs
+		// the server as well as the clients when it is in process. After is
+		// measured once the clients have disconnected.
+		Goroutines Growth `json:"goroutines"`
+		HeapBytes  Growth `json:"heapBytes"`
+	}
+
+	Latency struct {
+		Name  string        `json:"name"`
+		Count int           `json:"count"`
+		P50   time.Duration `json:"p50"`
+		P90   time.Duration `json:"p90"`
+		P99   time.Duration `json:"p99"`
+		Max   time.Duration `json:"max"`
+	}
+
+	Growth struct {
+		Before uint64 `json:"before"`
+		Peak   uint64 `json:"peak"`
+		After  uint64 `json:"after"`
+	}
+
+	stats struct {
+		mu          sync.Mutex
+		latencies   map[string][]time.Duration
+		sent        int
+		received    int
+		dropped     int
+		finished    int
+		errors      int
+		firstErrors []string
+	}
+)
+
+const (
+	maxFirstErrors = 10
+	// maxJoining is how many clients join or connect at once.
+	maxJoining = 50
+	// settleTime is how long the server gets to clean up after the clients
+	// disconnect before it is measured again.
+	settleTime = 2 * time.Second
+)
+
+// latencyNames are the latencies reported, in order.
+var latencyNames = []string{"join", "connect", "start", "game", "chat", "reaction"}
+
+// Run joins the clients to public games, starts the games once everyone has
+// joined and plays them to the end.
+func Run(ctx context.Context, cfg Config) (Report, error) {
+	if cfg.Clients < 1 {
+		return Report{}, fmt.Errorf("Clients must be at least 1, got: %d", cfg.Clients)
+	}
+	stats := &stats{latencies: map[string][]time.Duration{}}
+	report := Report{Clients: cfg.Clients}
+	report.Goroutines.Before, report.HeapBytes.Before = measure()
+	sampleCtx, stopSampling := context.WithCancel(ctx)
+	peaks := sample(sampleCtx, &report)
+	fail := func(err error) (Report, error) {
+		stopSampling()
+		<-peaks
+		return report, err
+	}
+
+	begin := time.Now()
+	clients := make([]*client, cfg.Clients)
+	for i := range clients {
+		clients[i] = newClient(i, cfg, stats)
+	}
+	defer func() {
+		for _, c := range clients {
+			c.close()
+		}
+	}()
+	if err := forEach(clients, (*client).join); err != nil {
+		return fail(err)
+	}
+	if err := forEach(clients, (*client).connect); err != nil {
+		return fail(err)
+	}
+
+	games := map[string]*client{}
+	for _, c := range clients {
+		if _, ok := games[c.gameName]; !ok {
+			games[c.gameName] = c
+		}
+	}
+	report.Games = len(games)
+	starters := []*client{}
+	for _, c := range games {
+		starters = append(starters, c)
+	}
+	if err := forEach(starters, (*client).start); err != nil {
+		return fail(err)
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+	for _, c := range clients {
+		select {
+		case <-c.done:
+		case <-waitCtx.Done():
+			if ctx.Err() != nil {
+				return fail(ctx.Err())
+			}
+		}
+	}
+	report.Duration = time.Since(begin)
+	unfinished := 0
+	for _, c := range clients {
+		if c.abandon() {
+			unfinished++
+		}
+	}
+	stats.add(&stats.dropped, unfinished)
+	for _, c := range clients {
+		c.close()
+	}
+	http.DefaultClient.CloseIdleConnections()
+	stopSampling()
+	<-peaks
+
+	time.Sleep(settleTime)
+	report.Goroutines.After, report.HeapBytes.After = measure()
+	stats.report(&report)
+	return report, nil
+}
+
+// forEach runs fn for every client, a few at a time, and returns the first
+// error.
+func forEach(clients []*client, fn func(c *client) error) error {
+	sem := make(chan struct{}, maxJoining)
+	errs := make(chan error, len(clients))
+	wg := sync.WaitGroup{}
+	for _, c := range clients {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := fn(c); err != nil {
+				errs <- fmt.Errorf("%s: %w", c.name, err)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	return <-errs
+}
+
+// measure garbage collects first so the heap holds only what is still in
+// use.
+func measure() (uint64, uint64) {
+	runtime.GC()
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	return uint64(runtime.NumGoroutine()), mem.HeapAlloc
+}
+
+// sample records the most goroutines and heap seen until ctx is done, and
+// closes the returned channel once it has stopped.
+func sample(ctx context.Context, report *Report) <-chan struct{} {
+	done := make(chan struct{})
+	report.Goroutines.Peak, report.HeapBytes.Peak = report.Goroutines.Before, report.HeapBytes.Before
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(250 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				var mem runtime.MemStats
+				runtime.ReadMemStats(&mem)
+				report.Goroutines.Peak = max(report.Goroutines.Peak, uint64(runtime.NumGoroutine()))
+				report.HeapBytes.Peak = max(report.HeapBytes.Peak, mem.HeapAlloc)
+			}
+		}
+	}()
+	return done
+}
+
+func (s *stats) observe(name string, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latencies[name] = append(s.latencies[name], d)
+}
+
+func (s *stats) add(count *int, n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	*count += n
+}
+
+func (s *stats) error(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errors++
+	if len(s.firstErrors) < maxFirstErrors {
+		s.firstErrors = append(s.firstErrors, err.Error())
+	}
+}
+
+func (s *stats) report(report *Report) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	report.Finished = s.finished
+	report.Sent, report.Received, report.Dropped = s.sent, s.received, s.dropped
+	report.Errors, report.FirstErrors = s.errors, s.firstErrors
+	report.Latencies = []Latency{}
+	for _, name := range latencyNames {
+		report.Latencies = append(report.Latencies, percentiles(name, s.latencies[name]))
+	}
+}
+
+func percentiles(name string, latencies []time.Duration) Latency {
+	l := Latency{Name: name, Count: len(latencies)}
+	if len(latencies) == 0 {
+		return l
+	}
+	sorted := append([]time.Duration{}, latencies...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	percentile := func(p int) time.Duration {
+		return sorted[(len(sorted)-1)*p/100]
+	}
+	l.P50, l.P90, l.P99, l.Max = percentile(50), percentile(90), percentile(99), sorted[len(sorted)-1]
+	return l
+}
@@ -0,0 +1,101 @@
+package loadtest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/auth"
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/db"
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/handlers"
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/jeopardy"
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/logic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	// skips the board introduction at the start of each round
+	t.Setenv("GIN_MODE", "debug")
+	t.Setenv("ALLOW_ORIGIN", "")
+	memoryDB, err := db.NewMemoryDBFromFile("../db/testdata/clues.json")
+	if err != nil {
+		t.Fatalf("Error loading test clues: %s", err.Error())
+	}
+	jeopardy.UseMemoryDB(memoryDB)
+	logic.SetUserDB(memoryDB)
+	if err := auth.GenerateJWTKeys(); err != nil {
+		t.Fatalf("Error generating JWT keys: %s", err.Error())
+	}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	for _, route := range handlers.Routes {
+		router.Handle(route.Method, route.Path, route.Handler)
+	}
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	t.Run("test clients play their games to the end", func(t *testing.T) {
+		report, err := Run(context.Background(), Config{URL: server.URL, Clients: 4, Timeout: time.Minute})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, 4, report.Finished)
+		assert.Equal(t, 0, report.Errors, report.FirstErrors)
+		assert.Equal(t, 0, report.Dropped)
+		assert.Greater(t, report.Received, 0)
+		assert.Len(t, report.Latencies, len(latencyNames))
+		for _, l := range report.Latencies {
+			assert.Greater(t, l.Count, 0, l.Name)
+			assert.LessOrEqual(t, l.P50, l.P90, l.Name)
+			assert.LessOrEqual(t, l.P90, l.P99, l.Name)
+			assert.LessOrEqual(t, l.P99, l.Max, l.Name)
+		}
+		assert.GreaterOrEqual(t, report.Goroutines.Peak, report.Goroutines.Before)
+	})
+
+	t.Run("test a load test with no clients", func(t *testing.T) {
+		_, err := Run(context.Background(), Config{URL: server.URL, Clients: 0})
+		assert.EqualError(t, err, "Clients must be at least 1, got: 0")
+	})
+}
+
+func TestRunTimeout(t *testing.T) {
+	// a server whose games never start, so none of the clients finish
+	upgrader := websocket.Upgrader{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /jeopardy/games", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"token": "token", "game": {"name": "stuck"}}`))
+	})
+	mux.HandleFunc("PUT /jeopardy/games/start", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("/jeopardy/", func(w http.ResponseWriter, r *http.Request) {
+		ws, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer ws.Close()
+		for {
+			if _, _, err := ws.ReadMessage(); err != nil {
+				return
+			}
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	t.Run("test clients that never finish are dropped", func(t *testing.T) {
+		begin := time.Now()
+		report, err := Run(context.Background(), Config{URL: server.URL, Clients: 3, Timeout: 500 * time.Millisecond})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Less(t, time.Since(begin), 10*time.Second)
+		assert.Equal(t, 1, report.Games)
+		assert.Equal(t, 0, report.Finished)
+		assert.Equal(t, 3, report.Dropped)
+		assert.Equal(t, 0, report.Errors, report.FirstErrors)
+	})
+}
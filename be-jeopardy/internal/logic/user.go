@@ -7,14 +7,14 @@ import (
 	"github.com/rileythomp/jeopardy/be-jeopardy/internal/log"
 )
 
-var supabase *db.SupabaseDB
+type userDB interface {
+	GetUserByName(ctx context.Context, name string) (db.User, error)
+}
 
-func init() {
-	var err error
-	supabase, err = db.NewSupabaseDB(context.Background())
-	if err != nil {
-		log.Fatalf("error creating supabase db: %v", err)
-	}
+var supabase userDB
+
+func SetUserDB(users userDB) {
+	supabase = users
 }
 
 func GetUserByName(ctx context.Context, name string) (db.User, error) {
@@ -1,37 +1,105 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"flag"
+	"fmt"
+	"io"
 	"log"
+	"net"
+	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/rileythomp/jeopardy/be-jeopardy/internal/auth"
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/db"
 	"github.com/rileythomp/jeopardy/be-jeopardy/internal/handlers"
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/importer"
 	"github.com/rileythomp/jeopardy/be-jeopardy/internal/jeopardy"
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/loadtest"
+	"github.com/rileythomp/jeopardy/be-jeopardy/internal/logic"
+)
+
+var (
+	dbType    = flag.String("db", "postgres", "database to use, either postgres or memory")
+	cluesFile = flag.String("clues", "", "JSON or TSV clue file to load into the memory database")
 )
 
 func main() {
 	flag.Parse()
 	log.SetFlags(0)
 
+	if flag.Arg(0) == "migrate" {
+		if err := migrate(context.Background(), flag.Args()[1:]); err != nil {
+			log.Fatalf("Failed to migrate database: %s", err)
+		}
+		return
+	}
+
+	if flag.Arg(0) == "fetch" {
+		if err := fetchGames(context.Background(), flag.Args()[1:]); err != nil {
+			log.Fatalf("Failed to fetch games: %s", err)
+		}
+		return
+	}
+
+	if flag.Arg(0) == "export-answers" {
+		if err := exportAnswers(context.Background(), flag.Args()[1:]); err != nil {
+			log.Fatalf("Failed to export answers: %s", err)
+		}
+		return
+	}
+
+	if flag.Arg(0) == "import" {
+		if err := importClues(context.Background(), flag.Args()[1:]); err != nil {
+			log.Fatalf("Failed to import clues: %s", err)
+		}
+		return
+	}
+
+	if err := setDatabase(context.Background()); err != nil {
+		log.Fatalf("Failed to set up database: %s", err)
+	}
+
+	if err := jeopardy.LoadDailyDoubles(context.Background()); err != nil {
+		log.Fatalf("Failed to load Daily Double placement: %s", err)
+	}
+
+	if err := jeopardy.LoadClueDifficulty(context.Background()); err != nil {
+		log.Fatalf("Failed to load clue difficulty: %s", err)
+	}
+
+	if flag.Arg(0) == "simulate" {
+		if err := simulate(context.Background(), flag.Args()[1:]); err != nil {
+			log.Fatalf("Failed to simulate games: %s", err)
+		}
+		return
+	}
+
+	if flag.Arg(0) == "loadtest" {
+		if err := loadTest(context.Background(), flag.Args()[1:]); err != nil {
+			log.Fatalf("Failed to load test: %s", err)
+		}
+		return
+	}
+
+	if err := jeopardy.RestoreGames(context.Background()); err != nil {
+		log.Fatalf("Failed to restore games: %s", err)
+	}
+
 	if err := auth.SetJWTKeys(); err != nil {
 		log.Fatalf("Failed to set JWT keys: %s", err)
 	}
 
-	router := gin.Default()
-	if err := router.SetTrustedProxies([]string{"127.0.0.1"}); err != nil {
+	router, err := newRouter()
+	if err != nil {
 		log.Fatalf("Failed to set trusted proxies: %s", err)
 	}
-	corsConfig := cors.DefaultConfig()
-	corsConfig.AllowAllOrigins = true
-	corsConfig.AllowHeaders = append(corsConfig.AllowHeaders, "Access-Token")
-	router.Use(cors.New(corsConfig))
-	for _, route := range handlers.Routes {
-		router.Handle(route.Method, route.Path, route.Handler)
-	}
 
 	go func() {
 		cleanUpTicker := time.NewTicker(1 * time.Hour)
@@ -47,3 +115,329 @@ func main() {
 	addr := flag.String("addr", ":"+port, "http service address")
 	log.Fatal(router.Run(*addr))
 }
+
+func newRouter() (*gin.Engine, error) {
+	router := gin.Default()
+	if err := router.SetTrustedProxies([]string{"127.0.0.1"}); err != nil {
+		return nil, err
+	}
+	corsConfig := cors.DefaultConfig()
+	corsConfig.AllowAllOrigins = true
+	corsConfig.AllowHeaders = append(corsConfig.AllowHeaders, "Access-Token", "Admin-Token", "Authorization")
+	router.Use(cors.New(corsConfig))
+	for _, route := range handlers.Routes {
+		router.Handle(route.Method, route.Path, route.Handler)
+	}
+	return router, nil
+}
+
+func setDatabase(ctx context.Context) error {
+	switch *dbType {
+	case "postgres":
+		supabase, err := db.NewSupabaseDB(ctx)
+		if err != nil {
+			return err
+		}
+		if err := jeopardy.UsePostgresDB(ctx, supabase); err != nil {
+			supabase.Close()
+			return err
+		}
+		logic.SetUserDB(supabase)
+	case "memory":
+		memoryDB, err := db.NewMemoryDBFromFile(*cluesFile)
+		if err != nil {
+			return err
+		}
+		jeopardy.UseMemoryDB(memoryDB)
+		logic.SetUserDB(memoryDB)
+	default:
+		return fmt.Errorf("unknown database type: %s", *dbType)
+	}
+	return nil
+}
+
+// migrate moves the Postgres schema between versions:
+//
+//	jeopardy migrate up [version]    apply migrations up to the version, or all of them
+//	jeopardy migrate down [version]  roll back to the version, or undo the last migration
+//	jeopardy migrate status          list the applied migrations
+func migrate(ctx context.Context, args []string) error {
+	if len(args) == 0 || len(args) > 2 {
+		return fmt.Errorf("usage: jeopardy migrate up|down|status [version]")
+	}
+	jeopardyDB, err := db.NewJeopardyDB(ctx)
+	if err != nil {
+		return err
+	}
+	defer jeopardyDB.Close()
+
+	migrations, err := db.Migrations()
+	if err != nil {
+		return err
+	}
+	applied, err := jeopardyDB.AppliedMigrations(ctx)
+	if err != nil {
+		return err
+	}
+
+	version := 0
+	if len(applied) > 0 {
+		version = applied[len(applied)-1].Version
+	}
+
+	var target int
+	switch args[0] {
+	case "status":
+		for _, m := range applied {
+			log.Printf("%04d_%s applied at %s", m.Version, m.Name, m.AppliedAt.Format(time.RFC3339))
+		}
+		log.Printf("At migration %d of %d", version, len(migrations))
+		return nil
+	case "up":
+		target = len(migrations)
+	case "down":
+		target = max(version-1, 0)
+	default:
+		return fmt.Errorf("unknown migrate command %s", args[0])
+	}
+	if len(args) == 2 {
+		if target, err = strconv.Atoi(args[1]); err != nil {
+			return fmt.Errorf("invalid version %s", args[1])
+		}
+	}
+	if args[0] == "up" && target < version || args[0] == "down" && target > version {
+		return fmt.Errorf("cannot migrate %s from %d to %d", args[0], version, target)
+	}
+
+	ran, err := jeopardyDB.Migrate(ctx, target)
+	for _, m := range ran {
+		log.Printf("Ran %s migration %04d_%s", args[0], m.Version, m.Name)
+	}
+	if err != nil {
+		return err
+	}
+	log.Printf("At migration %d of %d", target, len(migrations))
+	return nil
+}
+
+// fetchGames downloads the game pages of j-archive seasons for import to
+// read:
+//
+//	jeopardy fetch [-dir games] [-delay d] season...
+func fetchGames(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("fetch", flag.ContinueOnError)
+	dir := flags.String("dir", "games", "directory to save the game pages in")
+	delay := flags.Duration("delay", 2*time.Second, "time to wait between pages")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return fmt.Errorf("usage: jeopardy fetch [-dir games] [-delay d] season...")
+	}
+
+	for _, season := range flags.Args() {
+		fetched, err := importer.Fetch(ctx, importer.JArchiveURL, season, *dir, *delay)
+		log.Printf("Fetched %d games from season %s into %s", fetched, season, *dir)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importClues adds the clues in j-archive game pages and clue dumps to
+// jeopardy_clues:
+//
+//	jeopardy import [-dry-run] file-or-directory...
+func importClues(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "read and validate the clues without adding them")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return fmt.Errorf("usage: jeopardy import [-dry-run] file-or-directory...")
+	}
+
+	var report importer.Report
+	var err error
+	if *dryRun {
+		_, report, err = importer.ReadFiles(flags.Args())
+	} else {
+		jeopardyDB, dbErr := db.NewJeopardyDB(ctx)
+		if dbErr != nil {
+			return dbErr
+		}
+		defer jeopardyDB.Close()
+		report, err = importer.Import(ctx, jeopardyDB, flags.Args())
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, r := range report.Rejected {
+		log.Printf("Rejected %s (round %d, %s): %s", r.Category, r.Round, r.AirDate, r.Reason)
+	}
+	log.Printf("Read %d clues from %d files, %d duplicates, %d rejected categories, %d added",
+		report.Clues, report.Files, report.Duplicates, len(report.Rejected), report.Inserted)
+	return nil
+}
+
+// exportAnswers writes a sample of the alternatives and incorrect responses
+// in jeopardy_clues to the answer matcher's golden corpus:
+//
+//	jeopardy export-answers [-limit n] [-out file]
+func exportAnswers(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("export-answers", flag.ContinueOnError)
+	limit := flags.Int("limit", 500, "number of clues to export")
+	out := flags.String("out", "internal/jeopardy/testdata/answers.json", "file to write the clues to")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	jeopardyDB, err := db.NewJeopardyDB(ctx)
+	if err != nil {
+		return err
+	}
+	defer jeopardyDB.Close()
+	clues, err := jeopardyDB.ExportAnswers(ctx, *limit)
+	if err != nil {
+		return err
+	}
+
+	// one clue per line, so a new sample diffs clue by clue
+	var b strings.Builder
+	b.WriteString("[\n")
+	for i, c := range clues {
+		line, err := json.Marshal(struct {
+			Answer       string   `json:"answer"`
+			Alternatives []string `json:"alternatives"`
+			Incorrect    []string `json:"incorrect"`
+		}{c.Answer, c.Alternatives, c.Incorrect})
+		if err != nil {
+			return err
+		}
+		b.WriteString(" ")
+		b.Write(line)
+		if i < len(clues)-1 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("]\n")
+	if err := os.WriteFile(*out, []byte(b.String()), 0o644); err != nil {
+		return err
+	}
+	log.Printf("Exported %d clues to %s", len(clues), *out)
+	return nil
+}
+
+// simulate plays games between bots and prints the distribution of their
+// final scores:
+//
+//	jeopardy simulate [-games n] [-bots level,level...] [-full] [-seed n]
+func simulate(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	games := flags.Int("games", 1000, "number of games to simulate")
+	bots := flags.String("bots", "contestant,contestant,contestant", "comma separated levels of the bots in each game")
+	fullGame := flags.Bool("full", false, "play the second round as well as the first")
+	seed := flags.Uint64("seed", 0, "seed for repeatable simulations, random when 0")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		return fmt.Errorf("usage: jeopardy simulate [-games n] [-bots level,level...] [-full] [-seed n]")
+	}
+
+	report, err := jeopardy.Simulate(ctx, jeopardy.SimulationConfig{
+		Games:     *games,
+		BotLevels: strings.Split(*bots, ","),
+		FullGame:  *fullGame,
+		Seed:      *seed,
+	})
+	if err != nil {
+		return err
+	}
+	log.Printf("Simulated %d games", report.Games)
+	log.Printf("%-4s %-10s %9s %9s %7s %7s %7s %7s %7s %6s", "seat", "level", "mean", "stddev", "min", "p10", "median", "p90", "max", "wins")
+	for i, seat := range report.Seats {
+		log.Printf("%-4d %-10s %9.0f %9.0f %7d %7d %7d %7d %7d %5.1f%%",
+			i+1, seat.Level, seat.Mean, seat.StdDev, seat.Min, seat.P10, seat.Median, seat.P90, seat.Max, 100*seat.WinRate)
+	}
+	return nil
+}
+
+// loadTest serves the game on a local port with the memory database and
+// plays public games on it with clients that use the same requests and
+// sockets as the frontend:
+//
+//	jeopardy -db memory loadtest [-clients n] [-full] [-timeout duration] [-v]
+func loadTest(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("loadtest", flag.ContinueOnError)
+	clients := flags.Int("clients", 30, "number of clients, who join public games of up to 6 players")
+	fullGame := flags.Bool("full", false, "play the second round as well as the first")
+	timeout := flags.Duration("timeout", 30*time.Minute, "how long the clients get to finish their games")
+	verbose := flags.Bool("v", false, "log the server's requests and errors")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		return fmt.Errorf("usage: jeopardy -db memory loadtest [-clients n] [-full] [-timeout duration] [-v]")
+	}
+	if *dbType != "memory" {
+		return fmt.Errorf("the load test runs against the memory database, use -db memory")
+	}
+
+	if os.Getenv("JWT_RS512_KEY") != "" {
+		if err := auth.SetJWTKeys(); err != nil {
+			return err
+		}
+	} else if err := auth.GenerateJWTKeys(); err != nil {
+		return err
+	}
+	// the server logs with the standard logger, so the report has its own
+	out := log.New(log.Writer(), "", 0)
+	gin.SetMode(gin.ReleaseMode)
+	if !*verbose {
+		gin.DefaultWriter = io.Discard
+		log.SetOutput(io.Discard)
+		defer log.SetOutput(out.Writer())
+	}
+	router, err := newRouter()
+	if err != nil {
+		return err
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return err
+	}
+	server := &http.Server{Handler: router}
+	go server.Serve(listener)
+	defer server.Close()
+
+	out.Printf("Playing games with %d clients on %s", *clients, listener.Addr())
+	report, err := loadtest.Run(ctx, loadtest.Config{
+		URL:      "http://" + listener.Addr().String(),
+		Clients:  *clients,
+		FullGame: *fullGame,
+		Timeout:  *timeout,
+		Origin:   os.Getenv("ALLOW_ORIGIN"),
+	})
+	if err != nil {
+		return err
+	}
+	out.Printf("%d of %d clients finished %d games in %s", report.Finished, report.Clients, report.Games, report.Duration.Round(time.Millisecond))
+	out.Printf("%-8s %7s %10s %10s %10s %10s", "latency", "count", "p50", "p90", "p99", "max")
+	for _, l := range report.Latencies {
+		out.Printf("%-8s %7d %10s %10s %10s %10s", l.Name, l.Count,
+			l.P50.Round(time.Microsecond), l.P90.Round(time.Microsecond), l.P99.Round(time.Microsecond), l.Max.Round(time.Microsecond))
+	}
+	out.Printf("Sent %d messages, received %d, dropped %d, %d errors", report.Sent, report.Received, report.Dropped, report.Errors)
+	for _, e := range report.FirstErrors {
+		out.Printf("  %s", e)
+	}
+	out.Printf("%-10s %12s %12s %12s", "", "before", "peak", "after")
+	out.Printf("%-10s %12d %12d %12d", "goroutines", report.Goroutines.Before, report.Goroutines.Peak, report.Goroutines.After)
+	out.Printf("%-10s %12d %12d %12d", "heap bytes", report.HeapBytes.Before, report.HeapBytes.Peak, report.HeapBytes.After)
+	return nil
+}